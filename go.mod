@@ -13,6 +13,7 @@ require (
 	github.com/spf13/viper v1.20.1
 	golang.org/x/crypto v0.40.0
 	golang.org/x/net v0.41.0
+	golang.org/x/text v0.27.0
 )
 
 require (
@@ -36,6 +37,5 @@ require (
 	go.uber.org/multierr v1.9.0 // indirect
 	golang.org/x/sync v0.16.0 // indirect
 	golang.org/x/sys v0.34.0 // indirect
-	golang.org/x/text v0.27.0 // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
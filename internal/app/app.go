@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"sync"
 	"syscall"
 	"time"
 
@@ -17,14 +18,43 @@ import (
 	"github.com/nontypeable/financial-tracker/internal/auth"
 	"github.com/nontypeable/financial-tracker/internal/config"
 	accountDelivery "github.com/nontypeable/financial-tracker/internal/delivery/account"
+	budgetDelivery "github.com/nontypeable/financial-tracker/internal/delivery/budget"
+	categoryDelivery "github.com/nontypeable/financial-tracker/internal/delivery/category"
+	exportDelivery "github.com/nontypeable/financial-tracker/internal/delivery/export"
+	goalDelivery "github.com/nontypeable/financial-tracker/internal/delivery/goal"
+	importerDelivery "github.com/nontypeable/financial-tracker/internal/delivery/importer"
+	reconciliationDelivery "github.com/nontypeable/financial-tracker/internal/delivery/reconciliation"
+	recurringDelivery "github.com/nontypeable/financial-tracker/internal/delivery/recurring"
+	reportDelivery "github.com/nontypeable/financial-tracker/internal/delivery/report"
+	tagDelivery "github.com/nontypeable/financial-tracker/internal/delivery/tag"
 	transactionDelivery "github.com/nontypeable/financial-tracker/internal/delivery/transaction"
+	transferDelivery "github.com/nontypeable/financial-tracker/internal/delivery/transfer"
 	userDelivery "github.com/nontypeable/financial-tracker/internal/delivery/user"
 	accountRepository "github.com/nontypeable/financial-tracker/internal/repository/account"
+	budgetRepository "github.com/nontypeable/financial-tracker/internal/repository/budget"
+	categoryRepository "github.com/nontypeable/financial-tracker/internal/repository/category"
+	goalRepository "github.com/nontypeable/financial-tracker/internal/repository/goal"
+	importerRepository "github.com/nontypeable/financial-tracker/internal/repository/importer"
+	reconciliationRepository "github.com/nontypeable/financial-tracker/internal/repository/reconciliation"
+	recurringRepository "github.com/nontypeable/financial-tracker/internal/repository/recurring"
+	tagRepository "github.com/nontypeable/financial-tracker/internal/repository/tag"
 	transactionRepository "github.com/nontypeable/financial-tracker/internal/repository/transaction"
 	userRepository "github.com/nontypeable/financial-tracker/internal/repository/user"
+	"github.com/nontypeable/financial-tracker/internal/transactor"
 	accountUsecase "github.com/nontypeable/financial-tracker/internal/usecase/account"
+	budgetUsecase "github.com/nontypeable/financial-tracker/internal/usecase/budget"
+	categoryUsecase "github.com/nontypeable/financial-tracker/internal/usecase/category"
+	exportUsecase "github.com/nontypeable/financial-tracker/internal/usecase/export"
+	goalUsecase "github.com/nontypeable/financial-tracker/internal/usecase/goal"
+	importerUsecase "github.com/nontypeable/financial-tracker/internal/usecase/importer"
+	reconciliationUsecase "github.com/nontypeable/financial-tracker/internal/usecase/reconciliation"
+	recurringUsecase "github.com/nontypeable/financial-tracker/internal/usecase/recurring"
+	reportUsecase "github.com/nontypeable/financial-tracker/internal/usecase/report"
+	tagUsecase "github.com/nontypeable/financial-tracker/internal/usecase/tag"
 	transactionUsecase "github.com/nontypeable/financial-tracker/internal/usecase/transaction"
+	transferUsecase "github.com/nontypeable/financial-tracker/internal/usecase/transfer"
 	userUsecase "github.com/nontypeable/financial-tracker/internal/usecase/user"
+	"github.com/nontypeable/financial-tracker/internal/worker"
 	"golang.org/x/net/http2"
 	"golang.org/x/net/http2/h2c"
 )
@@ -33,6 +63,10 @@ type App struct {
 	router chi.Router
 	server *http.Server
 	config *config.ServerConfig
+
+	recurringWorker *worker.RecurringWorker
+	stopWorkers     context.CancelFunc
+	workers         sync.WaitGroup
 }
 
 func NewApp(cfg *config.Config, pool *pgxpool.Pool) *App {
@@ -52,7 +86,7 @@ func (app *App) setupMiddleware() {
 	app.router.Use(middleware.RealIP)
 	app.router.Use(middleware.Logger)
 	app.router.Use(middleware.Recoverer)
-	app.router.Use(middleware.Timeout(10 * time.Second))
+	app.router.Use(customMiddleware.Timeout(10*time.Second, "/export/"))
 }
 
 func (app *App) setupRoutes(cfg *config.Config, pool *pgxpool.Pool) error {
@@ -71,21 +105,77 @@ func (app *App) setupRoutes(cfg *config.Config, pool *pgxpool.Pool) error {
 
 	authMiddleware := customMiddleware.AuthMiddleware(tokenManager)
 
+	transactor := transactor.NewTransactor(pool)
+
 	userRepository := userRepository.NewRepository(pool)
-	userUsecase := userUsecase.NewService(userRepository, tokenManager)
+	accountRepository := accountRepository.NewRepository(pool)
+	transactionRepository := transactionRepository.NewRepository(pool)
+	categoryRepository := categoryRepository.NewRepository(pool)
+	tagRepository := tagRepository.NewRepository(pool)
+	reconciliationRepository := reconciliationRepository.NewRepository(pool)
+
+	categoryUsecase := categoryUsecase.NewService(categoryRepository, transactionRepository, transactor)
+	categoryHandler := categoryDelivery.NewHandler(categoryUsecase)
+	categoryHandler.RegisterRoutes(app.router, authMiddleware)
+
+	userUsecase := userUsecase.NewService(userRepository, categoryUsecase, tokenManager, transactor)
 	userHandler := userDelivery.NewHandler(userUsecase)
 	userHandler.RegisterRoutes(app.router, authMiddleware)
 
-	accountRepository := accountRepository.NewRepository(pool)
-	accountUsecase := accountUsecase.NewService(accountRepository)
+	accountUsecase := accountUsecase.NewService(accountRepository, transactionRepository, transactor)
 	accountHandler := accountDelivery.NewHandler(accountUsecase)
 	accountHandler.RegisterRoutes(app.router, authMiddleware)
 
-	transactionRepository := transactionRepository.NewRepository(pool)
-	transactionUsecase := transactionUsecase.NewService(transactionRepository)
+	transactionUsecase := transactionUsecase.NewService(transactionRepository, accountRepository, categoryRepository, tagRepository, reconciliationRepository, transactor)
 	transactionHandler := transactionDelivery.NewHandler(transactionUsecase)
 	transactionHandler.RegisterRoutes(app.router, authMiddleware)
 
+	tagUsecase := tagUsecase.NewService(tagRepository, transactor)
+	tagHandler := tagDelivery.NewHandler(tagUsecase)
+	tagHandler.RegisterRoutes(app.router, authMiddleware)
+
+	transferUsecase := transferUsecase.NewService(transactionRepository, accountRepository, reconciliationRepository, transactor)
+	transferHandler := transferDelivery.NewHandler(transferUsecase)
+	transferHandler.RegisterRoutes(app.router, authMiddleware)
+
+	recurringRepository := recurringRepository.NewRepository(pool)
+	recurringUsecase := recurringUsecase.NewService(recurringRepository, transactionUsecase, accountRepository, categoryRepository, transactor)
+	recurringHandler := recurringDelivery.NewHandler(recurringUsecase)
+	recurringHandler.RegisterRoutes(app.router, authMiddleware)
+
+	reconciliationUsecase := reconciliationUsecase.NewService(reconciliationRepository, accountRepository, transactionRepository, transactor)
+	reconciliationHandler := reconciliationDelivery.NewHandler(reconciliationUsecase)
+	reconciliationHandler.RegisterRoutes(app.router, authMiddleware)
+
+	importerRepository := importerRepository.NewRepository(pool)
+	importerUsecase := importerUsecase.NewService(importerRepository, transactionUsecase, transferUsecase, transactionRepository, accountRepository, categoryRepository, transactor)
+	importerHandler := importerDelivery.NewHandler(importerUsecase)
+	importerHandler.RegisterRoutes(app.router, authMiddleware)
+
+	exportUsecase := exportUsecase.NewService(transactionRepository, accountRepository, categoryRepository, reconciliationRepository)
+	exportHandler := exportDelivery.NewHandler(exportUsecase)
+	exportHandler.RegisterRoutes(app.router, authMiddleware)
+
+	budgetRepository := budgetRepository.NewRepository(pool)
+	budgetUsecase := budgetUsecase.NewService(budgetRepository, categoryRepository, transactor)
+	budgetHandler := budgetDelivery.NewHandler(budgetUsecase)
+	budgetHandler.RegisterRoutes(app.router, authMiddleware)
+
+	goalRepository := goalRepository.NewRepository(pool)
+	goalUsecase := goalUsecase.NewService(goalRepository, accountRepository, transactor)
+	goalHandler := goalDelivery.NewHandler(goalUsecase)
+	goalHandler.RegisterRoutes(app.router, authMiddleware)
+
+	reportUsecase := reportUsecase.NewService(transactionRepository, accountRepository)
+	reportHandler := reportDelivery.NewHandler(reportUsecase)
+	reportHandler.RegisterRoutes(app.router, authMiddleware)
+
+	var recurringInterval time.Duration
+	if cfg.Worker != nil {
+		recurringInterval = cfg.Worker.RecurringInterval
+	}
+	app.recurringWorker = worker.NewRecurringWorker(recurringUsecase, recurringInterval)
+
 	return nil
 }
 
@@ -94,6 +184,8 @@ func (app *App) Start() error {
 		return err
 	}
 
+	app.startWorkers()
+
 	sigCh := make(chan os.Signal, 1)
 	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
 	sig := <-sigCh
@@ -126,7 +218,29 @@ func (app *App) startServer() error {
 	return nil
 }
 
+func (app *App) startWorkers() {
+	if app.recurringWorker == nil {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	app.stopWorkers = cancel
+
+	app.workers.Add(1)
+	go func() {
+		defer app.workers.Done()
+
+		log.Println("Starting recurring transaction worker")
+		app.recurringWorker.Run(ctx)
+	}()
+}
+
 func (app *App) Stop(ctx context.Context) error {
+	if app.stopWorkers != nil {
+		app.stopWorkers()
+		app.workers.Wait()
+	}
+
 	if app.server == nil {
 		return nil
 	}
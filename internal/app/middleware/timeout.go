@@ -0,0 +1,28 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+// Timeout applies chi's request timeout to every route except those under
+// the exempt path prefixes, which stream responses of unbounded length.
+func Timeout(timeout time.Duration, exempt ...string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		limited := middleware.Timeout(timeout)(next)
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			for _, prefix := range exempt {
+				if strings.HasPrefix(r.URL.Path, prefix) {
+					next.ServeHTTP(w, r)
+					return
+				}
+			}
+
+			limited.ServeHTTP(w, r)
+		})
+	}
+}
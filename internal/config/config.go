@@ -13,6 +13,7 @@ type (
 		Database     *DatabaseConfig     `mapstructure:"database"`
 		Server       *ServerConfig       `mapstructure:"server"`
 		TokenManager *TokenManagerConfig `mapstructure:"token_manager"`
+		Worker       *WorkerConfig       `mapstructure:"worker"`
 	}
 
 	ServerConfig struct {
@@ -27,6 +28,10 @@ type (
 		RefreshTTL    time.Duration `mapstructure:"refresh_ttl"`
 	}
 
+	WorkerConfig struct {
+		RecurringInterval time.Duration `mapstructure:"recurring_interval"`
+	}
+
 	DatabaseConfig struct {
 		Host     string `mapstructure:"host"`
 		Port     int    `mapstructure:"port"`
@@ -4,4 +4,5 @@ type Key string
 
 const (
 	UserIDKey Key = "user_id"
+	TxKey     Key = "tx"
 )
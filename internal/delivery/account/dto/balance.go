@@ -0,0 +1,40 @@
+package dto
+
+import (
+	"net/url"
+	"time"
+
+	"github.com/google/uuid"
+	httpHelper "github.com/nontypeable/financial-tracker/internal/http"
+	"github.com/shopspring/decimal"
+)
+
+// BalanceRequest reads as_of from the query string. A bare YYYY-MM-DD date
+// covers the whole day.
+type BalanceRequest struct {
+	AsOf *time.Time
+}
+
+func (r *BalanceRequest) BindQuery(values url.Values) error {
+	asOf, err := httpHelper.QueryTime(values, "as_of", true)
+	if err != nil {
+		return err
+	}
+
+	r.AsOf = asOf
+
+	return nil
+}
+
+func (r *BalanceRequest) AsOfOrNow() time.Time {
+	if r.AsOf == nil {
+		return time.Now()
+	}
+	return *r.AsOf
+}
+
+type BalanceResponse struct {
+	AccountID uuid.UUID       `json:"account_id"`
+	AsOf      time.Time       `json:"as_of"`
+	Balance   decimal.Decimal `json:"balance"`
+}
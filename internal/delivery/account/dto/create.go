@@ -2,12 +2,14 @@ package dto
 
 import (
 	"github.com/google/uuid"
+	"github.com/nontypeable/financial-tracker/internal/domain/account"
 	"github.com/nontypeable/financial-tracker/internal/validator"
 	"github.com/shopspring/decimal"
 )
 
 type CreateRequest struct {
 	Name    string          `json:"name"`
+	Kind    account.Kind    `json:"kind" validate:"omitempty,oneof=asset liability"`
 	Balance decimal.Decimal `json:"balance"`
 }
 
@@ -0,0 +1,20 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nontypeable/financial-tracker/internal/domain/account"
+	"github.com/shopspring/decimal"
+)
+
+type GetResponse struct {
+	ID             uuid.UUID       `json:"id"`
+	Name           string          `json:"name"`
+	Kind           account.Kind    `json:"kind"`
+	Balance        decimal.Decimal `json:"balance"`
+	WorkingBalance decimal.Decimal `json:"working_balance"`
+	ClearedBalance decimal.Decimal `json:"cleared_balance"`
+	CreatedAt      time.Time       `json:"created_at"`
+	UpdatedAt      time.Time       `json:"updated_at"`
+}
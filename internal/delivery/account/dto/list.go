@@ -0,0 +1,5 @@
+package dto
+
+type ListResponse struct {
+	Accounts []GetResponse `json:"accounts"`
+}
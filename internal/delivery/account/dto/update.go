@@ -0,0 +1,15 @@
+package dto
+
+import (
+	"github.com/nontypeable/financial-tracker/internal/domain/account"
+	"github.com/nontypeable/financial-tracker/internal/validator"
+)
+
+type UpdateRequest struct {
+	Name string       `json:"name" validate:"omitempty,min=1,max=255"`
+	Kind account.Kind `json:"kind" validate:"omitempty,oneof=asset liability"`
+}
+
+func (r *UpdateRequest) Validate() error {
+	return validator.GetValidator().ValidateStruct(r)
+}
@@ -5,6 +5,7 @@ import (
 	"net/http"
 
 	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
 	"github.com/nontypeable/financial-tracker/internal/auth"
 	"github.com/nontypeable/financial-tracker/internal/delivery/account/dto"
 	"github.com/nontypeable/financial-tracker/internal/domain/account"
@@ -25,6 +26,12 @@ func (h *handler) RegisterRoutes(r chi.Router, authMiddleware func(http.Handler)
 			r.Use(authMiddleware)
 
 			r.Post("/", h.create)
+			r.Get("/", h.list)
+			r.Get("/{id}", h.get)
+			r.Patch("/{id}", h.update)
+			r.Delete("/{id}", h.delete)
+			r.Post("/{id}/recalculate", h.recalculate)
+			r.Get("/{id}/balance", h.balance)
 		})
 	})
 }
@@ -45,7 +52,7 @@ func (h *handler) create(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	accountID, err := h.service.Create(r.Context(), userID, payload.Name, payload.Balance)
+	accountID, err := h.service.Create(r.Context(), userID, payload.Name, payload.Kind, payload.Balance)
 	if err != nil {
 		status, msg := httpHelper.MapAppErrorToHTTP(err)
 		httpHelper.Error(w, status, msg)
@@ -56,3 +63,194 @@ func (h *handler) create(w http.ResponseWriter, r *http.Request) {
 		log.Printf("httpHelper.JSON: %v", err)
 	}
 }
+
+func (h *handler) list(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.UserIDFromContext(r.Context())
+	if !ok {
+		if err := httpHelper.Error(w, http.StatusInternalServerError, "internal server error"); err != nil {
+			log.Printf("httpHelper.Error: %v", err)
+		}
+		return
+	}
+
+	accounts, err := h.service.List(r.Context(), userID)
+	if err != nil {
+		status, msg := httpHelper.MapAppErrorToHTTP(err)
+		httpHelper.Error(w, status, msg)
+		return
+	}
+
+	response := dto.ListResponse{Accounts: make([]dto.GetResponse, 0, len(accounts))}
+	for _, a := range accounts {
+		response.Accounts = append(response.Accounts, toGetResponse(a))
+	}
+
+	if err := httpHelper.JSON(w, http.StatusOK, &response); err != nil {
+		log.Printf("httpHelper.JSON: %v", err)
+	}
+}
+
+func (h *handler) get(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.UserIDFromContext(r.Context())
+	if !ok {
+		if err := httpHelper.Error(w, http.StatusInternalServerError, "internal server error"); err != nil {
+			log.Printf("httpHelper.Error: %v", err)
+		}
+		return
+	}
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		httpHelper.Error(w, http.StatusBadRequest, "invalid account ID")
+		return
+	}
+
+	account, err := h.service.GetByID(r.Context(), userID, id)
+	if err != nil {
+		status, msg := httpHelper.MapAppErrorToHTTP(err)
+		httpHelper.Error(w, status, msg)
+		return
+	}
+
+	response := toGetResponse(account)
+	if err := httpHelper.JSON(w, http.StatusOK, &response); err != nil {
+		log.Printf("httpHelper.JSON: %v", err)
+	}
+}
+
+func (h *handler) update(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.UserIDFromContext(r.Context())
+	if !ok {
+		if err := httpHelper.Error(w, http.StatusInternalServerError, "internal server error"); err != nil {
+			log.Printf("httpHelper.Error: %v", err)
+		}
+		return
+	}
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		httpHelper.Error(w, http.StatusBadRequest, "invalid account ID")
+		return
+	}
+
+	var payload dto.UpdateRequest
+	if err := httpHelper.DecodeAndValidate(r, &payload); err != nil {
+		status, msg := httpHelper.MapAppErrorToHTTP(err)
+		httpHelper.Error(w, status, msg)
+		return
+	}
+
+	if err := h.service.Update(r.Context(), userID, id, payload.Name, payload.Kind); err != nil {
+		status, msg := httpHelper.MapAppErrorToHTTP(err)
+		httpHelper.Error(w, status, msg)
+		return
+	}
+
+	if err := httpHelper.JSON(w, http.StatusOK, nil); err != nil {
+		log.Printf("httpHelper.JSON: %v", err)
+	}
+}
+
+func (h *handler) delete(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.UserIDFromContext(r.Context())
+	if !ok {
+		if err := httpHelper.Error(w, http.StatusInternalServerError, "internal server error"); err != nil {
+			log.Printf("httpHelper.Error: %v", err)
+		}
+		return
+	}
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		httpHelper.Error(w, http.StatusBadRequest, "invalid account ID")
+		return
+	}
+
+	if err := h.service.Delete(r.Context(), userID, id); err != nil {
+		status, msg := httpHelper.MapAppErrorToHTTP(err)
+		httpHelper.Error(w, status, msg)
+		return
+	}
+
+	if err := httpHelper.JSON(w, http.StatusOK, nil); err != nil {
+		log.Printf("httpHelper.JSON: %v", err)
+	}
+}
+
+func (h *handler) recalculate(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.UserIDFromContext(r.Context())
+	if !ok {
+		if err := httpHelper.Error(w, http.StatusInternalServerError, "internal server error"); err != nil {
+			log.Printf("httpHelper.Error: %v", err)
+		}
+		return
+	}
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		httpHelper.Error(w, http.StatusBadRequest, "invalid account ID")
+		return
+	}
+
+	account, err := h.service.Recalculate(r.Context(), userID, id)
+	if err != nil {
+		status, msg := httpHelper.MapAppErrorToHTTP(err)
+		httpHelper.Error(w, status, msg)
+		return
+	}
+
+	response := toGetResponse(account)
+	if err := httpHelper.JSON(w, http.StatusOK, &response); err != nil {
+		log.Printf("httpHelper.JSON: %v", err)
+	}
+}
+
+func (h *handler) balance(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.UserIDFromContext(r.Context())
+	if !ok {
+		if err := httpHelper.Error(w, http.StatusInternalServerError, "internal server error"); err != nil {
+			log.Printf("httpHelper.Error: %v", err)
+		}
+		return
+	}
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		httpHelper.Error(w, http.StatusBadRequest, "invalid account ID")
+		return
+	}
+
+	var payload dto.BalanceRequest
+	if err := httpHelper.DecodeQueryAndValidate(r, &payload); err != nil {
+		status, msg := httpHelper.MapAppErrorToHTTP(err)
+		httpHelper.Error(w, status, msg)
+		return
+	}
+
+	asOf := payload.AsOfOrNow()
+
+	balance, err := h.service.BalanceAsOf(r.Context(), userID, id, asOf)
+	if err != nil {
+		status, msg := httpHelper.MapAppErrorToHTTP(err)
+		httpHelper.Error(w, status, msg)
+		return
+	}
+
+	response := dto.BalanceResponse{AccountID: id, AsOf: asOf, Balance: balance}
+	if err := httpHelper.JSON(w, http.StatusOK, &response); err != nil {
+		log.Printf("httpHelper.JSON: %v", err)
+	}
+}
+
+func toGetResponse(a *account.Account) dto.GetResponse {
+	return dto.GetResponse{
+		ID:             a.ID,
+		Name:           a.Name,
+		Kind:           a.Kind,
+		Balance:        a.Balance,
+		WorkingBalance: a.Balance,
+		ClearedBalance: a.ClearedBalance,
+		CreatedAt:      a.CreatedAt,
+		UpdatedAt:      a.UpdatedAt,
+	}
+}
@@ -0,0 +1,26 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/nontypeable/financial-tracker/internal/validator"
+)
+
+// CopyRequest names the month to fill from the one before it.
+type CopyRequest struct {
+	Month string `json:"month" validate:"required,datetime=2006-01"`
+}
+
+func (r *CopyRequest) Validate() error {
+	return validator.GetValidator().ValidateStruct(r)
+}
+
+// Date assumes the request has been validated.
+func (r *CopyRequest) Date() time.Time {
+	month, _ := time.Parse("2006-01", r.Month)
+	return month
+}
+
+type CopyResponse struct {
+	Copied int64 `json:"copied"`
+}
@@ -0,0 +1,37 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nontypeable/financial-tracker/internal/domain/budget"
+	"github.com/nontypeable/financial-tracker/internal/validator"
+	"github.com/shopspring/decimal"
+)
+
+type CreateRequest struct {
+	CategoryID uuid.UUID       `json:"category_id" validate:"required"`
+	Month      string          `json:"month" validate:"required,datetime=2006-01"`
+	Amount     decimal.Decimal `json:"amount"`
+	Rollover   bool            `json:"rollover"`
+}
+
+func (r *CreateRequest) Validate() error {
+	return validator.GetValidator().ValidateStruct(r)
+}
+
+// Params assumes the request has been validated.
+func (r *CreateRequest) Params() budget.CreateParams {
+	month, _ := time.Parse("2006-01", r.Month)
+
+	return budget.CreateParams{
+		CategoryID: r.CategoryID,
+		Month:      month,
+		Amount:     r.Amount,
+		Rollover:   r.Rollover,
+	}
+}
+
+type CreateResponse struct {
+	ID uuid.UUID `json:"id"`
+}
@@ -0,0 +1,96 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nontypeable/financial-tracker/internal/domain/budget"
+	"github.com/nontypeable/financial-tracker/internal/validator"
+	"github.com/shopspring/decimal"
+)
+
+// AssignRequest gives money to an envelope; a negative amount returns it
+// to the pool.
+type AssignRequest struct {
+	CategoryID uuid.UUID       `json:"category_id" validate:"required"`
+	Month      string          `json:"month" validate:"required,datetime=2006-01"`
+	Amount     decimal.Decimal `json:"amount"`
+	Note       string          `json:"note" validate:"max=255"`
+}
+
+func (r *AssignRequest) Validate() error {
+	return validator.GetValidator().ValidateStruct(r)
+}
+
+// Params assumes the request has been validated.
+func (r *AssignRequest) Params() budget.AssignParams {
+	month, _ := time.Parse("2006-01", r.Month)
+
+	return budget.AssignParams{
+		CategoryID: r.CategoryID,
+		Month:      month,
+		Amount:     r.Amount,
+		Note:       r.Note,
+	}
+}
+
+type AssignResponse struct {
+	ID uuid.UUID `json:"id"`
+}
+
+type MoveRequest struct {
+	FromCategoryID uuid.UUID       `json:"from_category_id" validate:"required"`
+	ToCategoryID   uuid.UUID       `json:"to_category_id" validate:"required"`
+	Month          string          `json:"month" validate:"required,datetime=2006-01"`
+	Amount         decimal.Decimal `json:"amount"`
+	Note           string          `json:"note" validate:"max=255"`
+}
+
+func (r *MoveRequest) Validate() error {
+	return validator.GetValidator().ValidateStruct(r)
+}
+
+// Params assumes the request has been validated.
+func (r *MoveRequest) Params() budget.MoveParams {
+	month, _ := time.Parse("2006-01", r.Month)
+
+	return budget.MoveParams{
+		FromCategoryID: r.FromCategoryID,
+		ToCategoryID:   r.ToCategoryID,
+		Month:          month,
+		Amount:         r.Amount,
+		Note:           r.Note,
+	}
+}
+
+type EnvelopeResponse struct {
+	CategoryID uuid.UUID       `json:"category_id"`
+	Carryover  decimal.Decimal `json:"carryover"`
+	Assigned   decimal.Decimal `json:"assigned"`
+	Spent      decimal.Decimal `json:"spent"`
+	Balance    decimal.Decimal `json:"balance"`
+}
+
+type EnvelopesResponse struct {
+	Month         string             `json:"month"`
+	Carryover     decimal.Decimal    `json:"carryover"`
+	Income        decimal.Decimal    `json:"income"`
+	Assigned      decimal.Decimal    `json:"assigned"`
+	ReadyToAssign decimal.Decimal    `json:"ready_to_assign"`
+	Envelopes     []EnvelopeResponse `json:"envelopes"`
+}
+
+type AssignmentResponse struct {
+	ID         uuid.UUID       `json:"id"`
+	CategoryID uuid.UUID       `json:"category_id"`
+	Month      string          `json:"month"`
+	Amount     decimal.Decimal `json:"amount"`
+	MoveID     *uuid.UUID      `json:"move_id,omitempty"`
+	Note       string          `json:"note,omitempty"`
+	CreatedAt  time.Time       `json:"created_at"`
+}
+
+type LedgerResponse struct {
+	Month       string               `json:"month"`
+	Assignments []AssignmentResponse `json:"assignments"`
+}
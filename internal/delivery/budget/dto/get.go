@@ -0,0 +1,18 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+type GetResponse struct {
+	ID         uuid.UUID       `json:"id"`
+	CategoryID uuid.UUID       `json:"category_id"`
+	Month      string          `json:"month"`
+	Amount     decimal.Decimal `json:"amount"`
+	Rollover   bool            `json:"rollover"`
+	CreatedAt  time.Time       `json:"created_at"`
+	UpdatedAt  time.Time       `json:"updated_at"`
+}
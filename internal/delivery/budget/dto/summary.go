@@ -0,0 +1,51 @@
+package dto
+
+import (
+	"net/url"
+	"time"
+
+	"github.com/nontypeable/financial-tracker/internal/validator"
+	"github.com/shopspring/decimal"
+)
+
+// MonthRequest selects the month to report on, the current one when
+// omitted.
+type MonthRequest struct {
+	Month string `validate:"omitempty,datetime=2006-01"`
+}
+
+func (r *MonthRequest) BindQuery(values url.Values) error {
+	r.Month = values.Get("month")
+	return nil
+}
+
+func (r *MonthRequest) Validate() error {
+	return validator.GetValidator().ValidateStruct(r)
+}
+
+// Date assumes the request has been validated.
+func (r *MonthRequest) Date() time.Time {
+	if r.Month == "" {
+		return time.Now().UTC()
+	}
+
+	month, _ := time.Parse("2006-01", r.Month)
+	return month
+}
+
+type ProgressResponse struct {
+	GetResponse
+	Carryover decimal.Decimal `json:"carryover"`
+	Available decimal.Decimal `json:"available"`
+	Spent     decimal.Decimal `json:"spent"`
+	Remaining decimal.Decimal `json:"remaining"`
+}
+
+type SummaryResponse struct {
+	Month     string             `json:"month"`
+	Budgets   []ProgressResponse `json:"budgets"`
+	Budgeted  decimal.Decimal    `json:"budgeted"`
+	Available decimal.Decimal    `json:"available"`
+	Spent     decimal.Decimal    `json:"spent"`
+	Remaining decimal.Decimal    `json:"remaining"`
+}
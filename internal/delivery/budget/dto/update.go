@@ -0,0 +1,23 @@
+package dto
+
+import (
+	"github.com/nontypeable/financial-tracker/internal/domain/budget"
+	"github.com/nontypeable/financial-tracker/internal/validator"
+	"github.com/shopspring/decimal"
+)
+
+type UpdateRequest struct {
+	Amount   *decimal.Decimal `json:"amount"`
+	Rollover *bool            `json:"rollover"`
+}
+
+func (r *UpdateRequest) Validate() error {
+	return validator.GetValidator().ValidateStruct(r)
+}
+
+func (r *UpdateRequest) Params() budget.UpdateParams {
+	return budget.UpdateParams{
+		Amount:   r.Amount,
+		Rollover: r.Rollover,
+	}
+}
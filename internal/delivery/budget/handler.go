@@ -0,0 +1,427 @@
+package budget
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/nontypeable/financial-tracker/internal/auth"
+	"github.com/nontypeable/financial-tracker/internal/delivery/budget/dto"
+	"github.com/nontypeable/financial-tracker/internal/domain/budget"
+	httpHelper "github.com/nontypeable/financial-tracker/internal/http"
+)
+
+const monthLayout = "2006-01"
+
+type handler struct {
+	service budget.Service
+}
+
+func NewHandler(service budget.Service) *handler {
+	return &handler{service: service}
+}
+
+func (h *handler) RegisterRoutes(r chi.Router, authMiddleware func(http.Handler) http.Handler) {
+	r.Route("/budget", func(r chi.Router) {
+		r.Group(func(r chi.Router) {
+			r.Use(authMiddleware)
+
+			r.Post("/", h.create)
+			r.Get("/", h.summary)
+			r.Post("/copy", h.copy)
+			r.Get("/envelope", h.envelopes)
+			r.Post("/envelope/assign", h.assign)
+			r.Post("/envelope/move", h.move)
+			r.Get("/envelope/ledger", h.ledger)
+			r.Delete("/envelope/ledger/{id}", h.deleteAssignment)
+			r.Get("/{id}", h.get)
+			r.Patch("/{id}", h.update)
+			r.Delete("/{id}", h.delete)
+		})
+	})
+}
+
+func (h *handler) create(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.UserIDFromContext(r.Context())
+	if !ok {
+		if err := httpHelper.Error(w, http.StatusInternalServerError, "internal server error"); err != nil {
+			log.Printf("httpHelper.Error: %v", err)
+		}
+		return
+	}
+
+	var payload dto.CreateRequest
+	if err := httpHelper.DecodeAndValidate(r, &payload); err != nil {
+		status, msg := httpHelper.MapAppErrorToHTTP(err)
+		httpHelper.Error(w, status, msg)
+		return
+	}
+
+	id, err := h.service.Create(r.Context(), userID, payload.Params())
+	if err != nil {
+		status, msg := httpHelper.MapAppErrorToHTTP(err)
+		httpHelper.Error(w, status, msg)
+		return
+	}
+
+	if err := httpHelper.JSON(w, http.StatusCreated, &dto.CreateResponse{ID: id}); err != nil {
+		log.Printf("httpHelper.JSON: %v", err)
+	}
+}
+
+func (h *handler) summary(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.UserIDFromContext(r.Context())
+	if !ok {
+		if err := httpHelper.Error(w, http.StatusInternalServerError, "internal server error"); err != nil {
+			log.Printf("httpHelper.Error: %v", err)
+		}
+		return
+	}
+
+	var query dto.MonthRequest
+	if err := httpHelper.DecodeQueryAndValidate(r, &query); err != nil {
+		status, msg := httpHelper.MapAppErrorToHTTP(err)
+		httpHelper.Error(w, status, msg)
+		return
+	}
+
+	summary, err := h.service.Summary(r.Context(), userID, query.Date())
+	if err != nil {
+		status, msg := httpHelper.MapAppErrorToHTTP(err)
+		httpHelper.Error(w, status, msg)
+		return
+	}
+
+	response := toSummaryResponse(summary)
+	if err := httpHelper.JSON(w, http.StatusOK, &response); err != nil {
+		log.Printf("httpHelper.JSON: %v", err)
+	}
+}
+
+func (h *handler) copy(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.UserIDFromContext(r.Context())
+	if !ok {
+		if err := httpHelper.Error(w, http.StatusInternalServerError, "internal server error"); err != nil {
+			log.Printf("httpHelper.Error: %v", err)
+		}
+		return
+	}
+
+	var payload dto.CopyRequest
+	if err := httpHelper.DecodeAndValidate(r, &payload); err != nil {
+		status, msg := httpHelper.MapAppErrorToHTTP(err)
+		httpHelper.Error(w, status, msg)
+		return
+	}
+
+	copied, err := h.service.CopyFromPrevious(r.Context(), userID, payload.Date())
+	if err != nil {
+		status, msg := httpHelper.MapAppErrorToHTTP(err)
+		httpHelper.Error(w, status, msg)
+		return
+	}
+
+	if err := httpHelper.JSON(w, http.StatusOK, &dto.CopyResponse{Copied: copied}); err != nil {
+		log.Printf("httpHelper.JSON: %v", err)
+	}
+}
+
+func (h *handler) get(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.UserIDFromContext(r.Context())
+	if !ok {
+		if err := httpHelper.Error(w, http.StatusInternalServerError, "internal server error"); err != nil {
+			log.Printf("httpHelper.Error: %v", err)
+		}
+		return
+	}
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		httpHelper.Error(w, http.StatusBadRequest, "invalid budget ID")
+		return
+	}
+
+	budget, err := h.service.GetByID(r.Context(), userID, id)
+	if err != nil {
+		status, msg := httpHelper.MapAppErrorToHTTP(err)
+		httpHelper.Error(w, status, msg)
+		return
+	}
+
+	response := toGetResponse(budget)
+	if err := httpHelper.JSON(w, http.StatusOK, &response); err != nil {
+		log.Printf("httpHelper.JSON: %v", err)
+	}
+}
+
+func (h *handler) update(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.UserIDFromContext(r.Context())
+	if !ok {
+		if err := httpHelper.Error(w, http.StatusInternalServerError, "internal server error"); err != nil {
+			log.Printf("httpHelper.Error: %v", err)
+		}
+		return
+	}
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		httpHelper.Error(w, http.StatusBadRequest, "invalid budget ID")
+		return
+	}
+
+	var payload dto.UpdateRequest
+	if err := httpHelper.DecodeAndValidate(r, &payload); err != nil {
+		status, msg := httpHelper.MapAppErrorToHTTP(err)
+		httpHelper.Error(w, status, msg)
+		return
+	}
+
+	if err := h.service.Update(r.Context(), userID, id, payload.Params()); err != nil {
+		status, msg := httpHelper.MapAppErrorToHTTP(err)
+		httpHelper.Error(w, status, msg)
+		return
+	}
+
+	if err := httpHelper.JSON(w, http.StatusOK, nil); err != nil {
+		log.Printf("httpHelper.JSON: %v", err)
+	}
+}
+
+func (h *handler) delete(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.UserIDFromContext(r.Context())
+	if !ok {
+		if err := httpHelper.Error(w, http.StatusInternalServerError, "internal server error"); err != nil {
+			log.Printf("httpHelper.Error: %v", err)
+		}
+		return
+	}
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		httpHelper.Error(w, http.StatusBadRequest, "invalid budget ID")
+		return
+	}
+
+	if err := h.service.Delete(r.Context(), userID, id); err != nil {
+		status, msg := httpHelper.MapAppErrorToHTTP(err)
+		httpHelper.Error(w, status, msg)
+		return
+	}
+
+	if err := httpHelper.JSON(w, http.StatusOK, nil); err != nil {
+		log.Printf("httpHelper.JSON: %v", err)
+	}
+}
+
+func (h *handler) envelopes(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.UserIDFromContext(r.Context())
+	if !ok {
+		if err := httpHelper.Error(w, http.StatusInternalServerError, "internal server error"); err != nil {
+			log.Printf("httpHelper.Error: %v", err)
+		}
+		return
+	}
+
+	var query dto.MonthRequest
+	if err := httpHelper.DecodeQueryAndValidate(r, &query); err != nil {
+		status, msg := httpHelper.MapAppErrorToHTTP(err)
+		httpHelper.Error(w, status, msg)
+		return
+	}
+
+	summary, err := h.service.Envelopes(r.Context(), userID, query.Date())
+	if err != nil {
+		status, msg := httpHelper.MapAppErrorToHTTP(err)
+		httpHelper.Error(w, status, msg)
+		return
+	}
+
+	response := toEnvelopesResponse(summary)
+	if err := httpHelper.JSON(w, http.StatusOK, &response); err != nil {
+		log.Printf("httpHelper.JSON: %v", err)
+	}
+}
+
+func (h *handler) assign(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.UserIDFromContext(r.Context())
+	if !ok {
+		if err := httpHelper.Error(w, http.StatusInternalServerError, "internal server error"); err != nil {
+			log.Printf("httpHelper.Error: %v", err)
+		}
+		return
+	}
+
+	var payload dto.AssignRequest
+	if err := httpHelper.DecodeAndValidate(r, &payload); err != nil {
+		status, msg := httpHelper.MapAppErrorToHTTP(err)
+		httpHelper.Error(w, status, msg)
+		return
+	}
+
+	id, err := h.service.Assign(r.Context(), userID, payload.Params())
+	if err != nil {
+		status, msg := httpHelper.MapAppErrorToHTTP(err)
+		httpHelper.Error(w, status, msg)
+		return
+	}
+
+	if err := httpHelper.JSON(w, http.StatusCreated, &dto.AssignResponse{ID: id}); err != nil {
+		log.Printf("httpHelper.JSON: %v", err)
+	}
+}
+
+func (h *handler) move(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.UserIDFromContext(r.Context())
+	if !ok {
+		if err := httpHelper.Error(w, http.StatusInternalServerError, "internal server error"); err != nil {
+			log.Printf("httpHelper.Error: %v", err)
+		}
+		return
+	}
+
+	var payload dto.MoveRequest
+	if err := httpHelper.DecodeAndValidate(r, &payload); err != nil {
+		status, msg := httpHelper.MapAppErrorToHTTP(err)
+		httpHelper.Error(w, status, msg)
+		return
+	}
+
+	if err := h.service.Move(r.Context(), userID, payload.Params()); err != nil {
+		status, msg := httpHelper.MapAppErrorToHTTP(err)
+		httpHelper.Error(w, status, msg)
+		return
+	}
+
+	if err := httpHelper.JSON(w, http.StatusCreated, nil); err != nil {
+		log.Printf("httpHelper.JSON: %v", err)
+	}
+}
+
+func (h *handler) ledger(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.UserIDFromContext(r.Context())
+	if !ok {
+		if err := httpHelper.Error(w, http.StatusInternalServerError, "internal server error"); err != nil {
+			log.Printf("httpHelper.Error: %v", err)
+		}
+		return
+	}
+
+	var query dto.MonthRequest
+	if err := httpHelper.DecodeQueryAndValidate(r, &query); err != nil {
+		status, msg := httpHelper.MapAppErrorToHTTP(err)
+		httpHelper.Error(w, status, msg)
+		return
+	}
+
+	month := budget.MonthOf(query.Date())
+
+	assignments, err := h.service.Ledger(r.Context(), userID, month)
+	if err != nil {
+		status, msg := httpHelper.MapAppErrorToHTTP(err)
+		httpHelper.Error(w, status, msg)
+		return
+	}
+
+	response := dto.LedgerResponse{
+		Month:       month.Format(monthLayout),
+		Assignments: make([]dto.AssignmentResponse, 0, len(assignments)),
+	}
+	for _, a := range assignments {
+		response.Assignments = append(response.Assignments, dto.AssignmentResponse{
+			ID:         a.ID,
+			CategoryID: a.CategoryID,
+			Month:      a.Month.Format(monthLayout),
+			Amount:     a.Amount,
+			MoveID:     a.MoveID,
+			Note:       a.Note,
+			CreatedAt:  a.CreatedAt,
+		})
+	}
+
+	if err := httpHelper.JSON(w, http.StatusOK, &response); err != nil {
+		log.Printf("httpHelper.JSON: %v", err)
+	}
+}
+
+func (h *handler) deleteAssignment(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.UserIDFromContext(r.Context())
+	if !ok {
+		if err := httpHelper.Error(w, http.StatusInternalServerError, "internal server error"); err != nil {
+			log.Printf("httpHelper.Error: %v", err)
+		}
+		return
+	}
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		httpHelper.Error(w, http.StatusBadRequest, "invalid assignment ID")
+		return
+	}
+
+	if err := h.service.DeleteAssignment(r.Context(), userID, id); err != nil {
+		status, msg := httpHelper.MapAppErrorToHTTP(err)
+		httpHelper.Error(w, status, msg)
+		return
+	}
+
+	if err := httpHelper.JSON(w, http.StatusOK, nil); err != nil {
+		log.Printf("httpHelper.JSON: %v", err)
+	}
+}
+
+func toGetResponse(b *budget.Budget) dto.GetResponse {
+	return dto.GetResponse{
+		ID:         b.ID,
+		CategoryID: b.CategoryID,
+		Month:      b.Month.Format(monthLayout),
+		Amount:     b.Amount,
+		Rollover:   b.Rollover,
+		CreatedAt:  b.CreatedAt,
+		UpdatedAt:  b.UpdatedAt,
+	}
+}
+
+func toSummaryResponse(s *budget.Summary) dto.SummaryResponse {
+	budgets := make([]dto.ProgressResponse, 0, len(s.Budgets))
+	for _, p := range s.Budgets {
+		budgets = append(budgets, dto.ProgressResponse{
+			GetResponse: toGetResponse(p.Budget),
+			Carryover:   p.Carryover,
+			Available:   p.Available,
+			Spent:       p.Spent,
+			Remaining:   p.Remaining,
+		})
+	}
+
+	return dto.SummaryResponse{
+		Month:     s.Month.Format(monthLayout),
+		Budgets:   budgets,
+		Budgeted:  s.Budgeted,
+		Available: s.Available,
+		Spent:     s.Spent,
+		Remaining: s.Remaining,
+	}
+}
+
+func toEnvelopesResponse(s *budget.EnvelopeSummary) dto.EnvelopesResponse {
+	envelopes := make([]dto.EnvelopeResponse, 0, len(s.Envelopes))
+	for _, e := range s.Envelopes {
+		envelopes = append(envelopes, dto.EnvelopeResponse{
+			CategoryID: e.CategoryID,
+			Carryover:  e.Carryover,
+			Assigned:   e.Assigned,
+			Spent:      e.Spent,
+			Balance:    e.Balance,
+		})
+	}
+
+	return dto.EnvelopesResponse{
+		Month:         s.Month.Format(monthLayout),
+		Carryover:     s.Carryover,
+		Income:        s.Income,
+		Assigned:      s.Assigned,
+		ReadyToAssign: s.ReadyToAssign,
+		Envelopes:     envelopes,
+	}
+}
@@ -0,0 +1,21 @@
+package dto
+
+import (
+	"github.com/google/uuid"
+	"github.com/nontypeable/financial-tracker/internal/domain/category"
+	"github.com/nontypeable/financial-tracker/internal/validator"
+)
+
+type CreateRequest struct {
+	ParentID *uuid.UUID            `json:"parent_id"`
+	Name     string                `json:"name" validate:"required,min=1,max=100"`
+	Type     category.CategoryType `json:"type" validate:"required,oneof=income expense"`
+}
+
+func (r *CreateRequest) Validate() error {
+	return validator.GetValidator().ValidateStruct(r)
+}
+
+type CreateResponse struct {
+	ID uuid.UUID `json:"id"`
+}
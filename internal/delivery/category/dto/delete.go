@@ -0,0 +1,25 @@
+package dto
+
+import (
+	"github.com/google/uuid"
+	"github.com/nontypeable/financial-tracker/internal/domain/category"
+	"github.com/nontypeable/financial-tracker/internal/validator"
+)
+
+// DeleteRequest must say what happens to the category's transactions:
+// either move them to another category or leave them uncategorized.
+type DeleteRequest struct {
+	ReassignTo   *uuid.UUID `json:"reassign_to" validate:"required_without=Uncategorize"`
+	Uncategorize bool       `json:"uncategorize" validate:"required_without=ReassignTo"`
+}
+
+func (r *DeleteRequest) Validate() error {
+	return validator.GetValidator().ValidateStruct(r)
+}
+
+func (r *DeleteRequest) Params() category.DeleteParams {
+	return category.DeleteParams{
+		ReassignTo:   r.ReassignTo,
+		Uncategorize: r.Uncategorize,
+	}
+}
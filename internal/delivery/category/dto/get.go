@@ -0,0 +1,18 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nontypeable/financial-tracker/internal/domain/category"
+)
+
+type GetResponse struct {
+	ID         uuid.UUID             `json:"id"`
+	ParentID   *uuid.UUID            `json:"parent_id,omitempty"`
+	Name       string                `json:"name"`
+	Type       category.CategoryType `json:"type"`
+	ArchivedAt *time.Time            `json:"archived_at,omitempty"`
+	CreatedAt  time.Time             `json:"created_at"`
+	UpdatedAt  time.Time             `json:"updated_at"`
+}
@@ -0,0 +1,10 @@
+package dto
+
+type TreeNode struct {
+	GetResponse
+	Children []TreeNode `json:"children,omitempty"`
+}
+
+type ListResponse struct {
+	Categories []TreeNode `json:"categories"`
+}
@@ -0,0 +1,27 @@
+package dto
+
+import (
+	"github.com/google/uuid"
+	"github.com/nontypeable/financial-tracker/internal/domain/category"
+	"github.com/nontypeable/financial-tracker/internal/validator"
+)
+
+type UpdateRequest struct {
+	Name        *string    `json:"name" validate:"omitempty,min=1,max=100"`
+	ParentID    *uuid.UUID `json:"parent_id"`
+	ClearParent bool       `json:"clear_parent"`
+	Archived    *bool      `json:"archived"`
+}
+
+func (r *UpdateRequest) Validate() error {
+	return validator.GetValidator().ValidateStruct(r)
+}
+
+func (r *UpdateRequest) Params() category.UpdateParams {
+	return category.UpdateParams{
+		Name:        r.Name,
+		ParentID:    r.ParentID,
+		ClearParent: r.ClearParent,
+		Archived:    r.Archived,
+	}
+}
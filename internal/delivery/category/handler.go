@@ -0,0 +1,204 @@
+package category
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/nontypeable/financial-tracker/internal/auth"
+	"github.com/nontypeable/financial-tracker/internal/delivery/category/dto"
+	"github.com/nontypeable/financial-tracker/internal/domain/category"
+	httpHelper "github.com/nontypeable/financial-tracker/internal/http"
+)
+
+type handler struct {
+	service category.Service
+}
+
+func NewHandler(service category.Service) *handler {
+	return &handler{service: service}
+}
+
+func (h *handler) RegisterRoutes(r chi.Router, authMiddleware func(http.Handler) http.Handler) {
+	r.Route("/category", func(r chi.Router) {
+		r.Group(func(r chi.Router) {
+			r.Use(authMiddleware)
+
+			r.Post("/", h.create)
+			r.Get("/", h.list)
+			r.Get("/{id}", h.get)
+			r.Patch("/{id}", h.update)
+			r.Delete("/{id}", h.delete)
+		})
+	})
+}
+
+func (h *handler) create(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.UserIDFromContext(r.Context())
+	if !ok {
+		if err := httpHelper.Error(w, http.StatusInternalServerError, "internal server error"); err != nil {
+			log.Printf("httpHelper.Error: %v", err)
+		}
+		return
+	}
+
+	var payload dto.CreateRequest
+	if err := httpHelper.DecodeAndValidate(r, &payload); err != nil {
+		status, msg := httpHelper.MapAppErrorToHTTP(err)
+		httpHelper.Error(w, status, msg)
+		return
+	}
+
+	id, err := h.service.Create(r.Context(), userID, payload.ParentID, payload.Name, payload.Type)
+	if err != nil {
+		status, msg := httpHelper.MapAppErrorToHTTP(err)
+		httpHelper.Error(w, status, msg)
+		return
+	}
+
+	if err := httpHelper.JSON(w, http.StatusCreated, &dto.CreateResponse{ID: id}); err != nil {
+		log.Printf("httpHelper.JSON: %v", err)
+	}
+}
+
+func (h *handler) list(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.UserIDFromContext(r.Context())
+	if !ok {
+		if err := httpHelper.Error(w, http.StatusInternalServerError, "internal server error"); err != nil {
+			log.Printf("httpHelper.Error: %v", err)
+		}
+		return
+	}
+
+	includeArchived := r.URL.Query().Get("include_archived") == "true"
+
+	categories, err := h.service.List(r.Context(), userID, includeArchived)
+	if err != nil {
+		status, msg := httpHelper.MapAppErrorToHTTP(err)
+		httpHelper.Error(w, status, msg)
+		return
+	}
+
+	response := dto.ListResponse{Categories: toTreeNodes(category.BuildTree(categories))}
+	if err := httpHelper.JSON(w, http.StatusOK, &response); err != nil {
+		log.Printf("httpHelper.JSON: %v", err)
+	}
+}
+
+func (h *handler) get(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.UserIDFromContext(r.Context())
+	if !ok {
+		if err := httpHelper.Error(w, http.StatusInternalServerError, "internal server error"); err != nil {
+			log.Printf("httpHelper.Error: %v", err)
+		}
+		return
+	}
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		httpHelper.Error(w, http.StatusBadRequest, "invalid category ID")
+		return
+	}
+
+	category, err := h.service.GetByID(r.Context(), userID, id)
+	if err != nil {
+		status, msg := httpHelper.MapAppErrorToHTTP(err)
+		httpHelper.Error(w, status, msg)
+		return
+	}
+
+	response := toGetResponse(category)
+	if err := httpHelper.JSON(w, http.StatusOK, &response); err != nil {
+		log.Printf("httpHelper.JSON: %v", err)
+	}
+}
+
+func (h *handler) update(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.UserIDFromContext(r.Context())
+	if !ok {
+		if err := httpHelper.Error(w, http.StatusInternalServerError, "internal server error"); err != nil {
+			log.Printf("httpHelper.Error: %v", err)
+		}
+		return
+	}
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		httpHelper.Error(w, http.StatusBadRequest, "invalid category ID")
+		return
+	}
+
+	var payload dto.UpdateRequest
+	if err := httpHelper.DecodeAndValidate(r, &payload); err != nil {
+		status, msg := httpHelper.MapAppErrorToHTTP(err)
+		httpHelper.Error(w, status, msg)
+		return
+	}
+
+	if err := h.service.Update(r.Context(), userID, id, payload.Params()); err != nil {
+		status, msg := httpHelper.MapAppErrorToHTTP(err)
+		httpHelper.Error(w, status, msg)
+		return
+	}
+
+	if err := httpHelper.JSON(w, http.StatusOK, nil); err != nil {
+		log.Printf("httpHelper.JSON: %v", err)
+	}
+}
+
+func (h *handler) delete(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.UserIDFromContext(r.Context())
+	if !ok {
+		if err := httpHelper.Error(w, http.StatusInternalServerError, "internal server error"); err != nil {
+			log.Printf("httpHelper.Error: %v", err)
+		}
+		return
+	}
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		httpHelper.Error(w, http.StatusBadRequest, "invalid category ID")
+		return
+	}
+
+	var payload dto.DeleteRequest
+	if err := httpHelper.DecodeAndValidate(r, &payload); err != nil {
+		status, msg := httpHelper.MapAppErrorToHTTP(err)
+		httpHelper.Error(w, status, msg)
+		return
+	}
+
+	if err := h.service.Delete(r.Context(), userID, id, payload.Params()); err != nil {
+		status, msg := httpHelper.MapAppErrorToHTTP(err)
+		httpHelper.Error(w, status, msg)
+		return
+	}
+
+	if err := httpHelper.JSON(w, http.StatusOK, nil); err != nil {
+		log.Printf("httpHelper.JSON: %v", err)
+	}
+}
+
+func toGetResponse(c *category.Category) dto.GetResponse {
+	return dto.GetResponse{
+		ID:         c.ID,
+		ParentID:   c.ParentID,
+		Name:       c.Name,
+		Type:       c.Type,
+		ArchivedAt: c.ArchivedAt,
+		CreatedAt:  c.CreatedAt,
+		UpdatedAt:  c.UpdatedAt,
+	}
+}
+
+func toTreeNodes(nodes []*category.Node) []dto.TreeNode {
+	result := make([]dto.TreeNode, 0, len(nodes))
+	for _, node := range nodes {
+		result = append(result, dto.TreeNode{
+			GetResponse: toGetResponse(node.Category),
+			Children:    toTreeNodes(node.Children),
+		})
+	}
+	return result
+}
@@ -0,0 +1,30 @@
+package dto
+
+import (
+	"net/url"
+
+	"github.com/nontypeable/financial-tracker/internal/domain/export"
+	"github.com/nontypeable/financial-tracker/internal/validator"
+)
+
+type JournalRequest struct {
+	Format   string `validate:"required,oneof=beancount ledger"`
+	Currency string `validate:"omitempty,len=3,uppercase"`
+}
+
+func (r *JournalRequest) BindQuery(values url.Values) error {
+	r.Format = values.Get("format")
+	r.Currency = values.Get("currency")
+	return nil
+}
+
+func (r *JournalRequest) Validate() error {
+	return validator.GetValidator().ValidateStruct(r)
+}
+
+func (r *JournalRequest) Params() export.JournalParams {
+	return export.JournalParams{
+		Format:   export.Format(r.Format),
+		Currency: r.Currency,
+	}
+}
@@ -0,0 +1,54 @@
+package dto
+
+import (
+	"net/url"
+
+	transactionDto "github.com/nontypeable/financial-tracker/internal/delivery/transaction/dto"
+	"github.com/nontypeable/financial-tracker/internal/domain/export"
+	"github.com/nontypeable/financial-tracker/internal/domain/transaction"
+	"github.com/nontypeable/financial-tracker/internal/validator"
+)
+
+// TransactionsRequest takes the transaction listing filters plus format and
+// currency. Rows come oldest first unless an order is given; cursor and
+// limit are ignored.
+type TransactionsRequest struct {
+	transactionDto.ListRequest
+	Format   string `validate:"oneof=csv ndjson ofx"`
+	Currency string `validate:"omitempty,len=3,uppercase"`
+}
+
+func (r *TransactionsRequest) BindQuery(values url.Values) error {
+	if err := r.ListRequest.BindQuery(values); err != nil {
+		return err
+	}
+
+	r.Format = values.Get("format")
+	if r.Format == "" {
+		r.Format = string(export.FormatCSV)
+	}
+	r.Currency = values.Get("currency")
+
+	return nil
+}
+
+func (r *TransactionsRequest) Validate() error {
+	return validator.GetValidator().ValidateStruct(r)
+}
+
+func (r *TransactionsRequest) Params() (export.TransactionsParams, error) {
+	filter, err := r.Filter()
+	if err != nil {
+		return export.TransactionsParams{}, err
+	}
+
+	if filter.Order == "" {
+		filter.Order = transaction.Ascending
+	}
+
+	return export.TransactionsParams{
+		Format:   export.Format(r.Format),
+		Filter:   filter,
+		Currency: r.Currency,
+	}, nil
+}
@@ -0,0 +1,140 @@
+package export
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/nontypeable/financial-tracker/internal/auth"
+	"github.com/nontypeable/financial-tracker/internal/delivery/export/dto"
+	"github.com/nontypeable/financial-tracker/internal/domain/export"
+	httpHelper "github.com/nontypeable/financial-tracker/internal/http"
+)
+
+var contentTypes = map[export.Format]string{
+	export.FormatCSV:       "text/csv; charset=utf-8",
+	export.FormatNDJSON:    "application/x-ndjson",
+	export.FormatOFX:       "application/x-ofx",
+	export.FormatBeancount: "text/plain; charset=utf-8",
+	export.FormatLedger:    "text/plain; charset=utf-8",
+}
+
+type handler struct {
+	service export.Service
+}
+
+func NewHandler(service export.Service) *handler {
+	return &handler{
+		service: service,
+	}
+}
+
+// RegisterRoutes mounts the export routes. They stream responses of any
+// length, so the app keeps them clear of the request timeout.
+func (h *handler) RegisterRoutes(r chi.Router, authMiddleware func(http.Handler) http.Handler) {
+	r.Route("/export", func(r chi.Router) {
+		r.Group(func(r chi.Router) {
+			r.Use(authMiddleware)
+
+			r.Get("/transactions", h.transactions)
+			r.Get("/journal", h.journal)
+		})
+	})
+}
+
+func (h *handler) transactions(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.UserIDFromContext(r.Context())
+	if !ok {
+		if err := httpHelper.Error(w, http.StatusInternalServerError, "internal server error"); err != nil {
+			log.Printf("httpHelper.Error: %v", err)
+		}
+		return
+	}
+
+	var query dto.TransactionsRequest
+	if err := httpHelper.DecodeQueryAndValidate(r, &query); err != nil {
+		status, msg := httpHelper.MapAppErrorToHTTP(err)
+		httpHelper.Error(w, status, msg)
+		return
+	}
+
+	params, err := query.Params()
+	if err != nil {
+		httpHelper.Error(w, http.StatusBadRequest, "invalid cursor")
+		return
+	}
+
+	out := newStreamWriter(w, "transactions", params.Format)
+	if err := h.service.Transactions(r.Context(), userID, params, out); err != nil {
+		if !out.started {
+			status, msg := httpHelper.MapAppErrorToHTTP(err)
+			httpHelper.Error(w, status, msg)
+			return
+		}
+
+		// The status line is gone; all that is left is to cut the body short.
+		log.Printf("export transactions: %v", err)
+	}
+}
+
+// journal writes the user's whole history as a Beancount or ledger-cli
+// journal.
+func (h *handler) journal(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.UserIDFromContext(r.Context())
+	if !ok {
+		if err := httpHelper.Error(w, http.StatusInternalServerError, "internal server error"); err != nil {
+			log.Printf("httpHelper.Error: %v", err)
+		}
+		return
+	}
+
+	var query dto.JournalRequest
+	if err := httpHelper.DecodeQueryAndValidate(r, &query); err != nil {
+		status, msg := httpHelper.MapAppErrorToHTTP(err)
+		httpHelper.Error(w, status, msg)
+		return
+	}
+
+	params := query.Params()
+
+	out := newStreamWriter(w, "journal", params.Format)
+	if err := h.service.Journal(r.Context(), userID, params, out); err != nil {
+		if !out.started {
+			status, msg := httpHelper.MapAppErrorToHTTP(err)
+			httpHelper.Error(w, status, msg)
+			return
+		}
+
+		log.Printf("export journal: %v", err)
+	}
+}
+
+// streamWriter sends the download headers with the first chunk of the
+// body, so that an error raised before any output still gets a JSON error
+// response.
+type streamWriter struct {
+	w           http.ResponseWriter
+	contentType string
+	filename    string
+	started     bool
+}
+
+func newStreamWriter(w http.ResponseWriter, name string, format export.Format) *streamWriter {
+	return &streamWriter{
+		w:           w,
+		contentType: contentTypes[format],
+		filename:    fmt.Sprintf("%s-%s.%s", name, time.Now().Format("20060102"), format),
+	}
+}
+
+func (s *streamWriter) Write(p []byte) (int, error) {
+	if !s.started {
+		s.w.Header().Set("Content-Type", s.contentType)
+		s.w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", s.filename))
+		s.w.WriteHeader(http.StatusOK)
+		s.started = true
+	}
+	return s.w.Write(p)
+}
@@ -0,0 +1,56 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nontypeable/financial-tracker/internal/domain/goal"
+	"github.com/nontypeable/financial-tracker/internal/validator"
+	"github.com/shopspring/decimal"
+)
+
+// LinkRequest links an account to a goal. Share is the fraction of its
+// balance set aside for the goal, the whole balance when omitted.
+type LinkRequest struct {
+	AccountID uuid.UUID        `json:"account_id" validate:"required"`
+	Share     *decimal.Decimal `json:"share"`
+}
+
+type CreateRequest struct {
+	Name         string          `json:"name" validate:"required,min=1,max=100"`
+	TargetAmount decimal.Decimal `json:"target_amount"`
+	TargetDate   string          `json:"target_date" validate:"required,datetime=2006-01-02"`
+	Accounts     []LinkRequest   `json:"accounts" validate:"required,min=1,dive"`
+}
+
+func (r *CreateRequest) Validate() error {
+	return validator.GetValidator().ValidateStruct(r)
+}
+
+// Params assumes the request has been validated.
+func (r *CreateRequest) Params() goal.CreateParams {
+	date, _ := time.Parse(time.DateOnly, r.TargetDate)
+
+	return goal.CreateParams{
+		Name:         r.Name,
+		TargetAmount: r.TargetAmount,
+		TargetDate:   date,
+		Accounts:     toLinks(r.Accounts),
+	}
+}
+
+type CreateResponse struct {
+	ID uuid.UUID `json:"id"`
+}
+
+func toLinks(requests []LinkRequest) []goal.Link {
+	links := make([]goal.Link, 0, len(requests))
+	for _, l := range requests {
+		share := decimal.NewFromInt(1)
+		if l.Share != nil {
+			share = *l.Share
+		}
+		links = append(links, goal.Link{AccountID: l.AccountID, Share: share})
+	}
+	return links
+}
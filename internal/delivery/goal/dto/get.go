@@ -0,0 +1,37 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nontypeable/financial-tracker/internal/domain/goal"
+	"github.com/shopspring/decimal"
+)
+
+type LinkResponse struct {
+	AccountID uuid.UUID       `json:"account_id"`
+	Share     decimal.Decimal `json:"share"`
+}
+
+type GetResponse struct {
+	ID              uuid.UUID       `json:"id"`
+	Name            string          `json:"name"`
+	TargetAmount    decimal.Decimal `json:"target_amount"`
+	TargetDate      string          `json:"target_date"`
+	Accounts        []LinkResponse  `json:"accounts"`
+	Current         decimal.Decimal `json:"current"`
+	Remaining       decimal.Decimal `json:"remaining"`
+	Percent         decimal.Decimal `json:"percent"`
+	MonthsLeft      int             `json:"months_left"`
+	RequiredMonthly decimal.Decimal `json:"required_monthly"`
+	Contributed     decimal.Decimal `json:"contributed"`
+	MonthlyPace     decimal.Decimal `json:"monthly_pace"`
+	Status          goal.Status     `json:"status"`
+	ArchivedAt      *time.Time      `json:"archived_at,omitempty"`
+	CreatedAt       time.Time       `json:"created_at"`
+	UpdatedAt       time.Time       `json:"updated_at"`
+}
+
+type ListResponse struct {
+	Goals []GetResponse `json:"goals"`
+}
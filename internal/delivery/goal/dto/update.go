@@ -0,0 +1,43 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/nontypeable/financial-tracker/internal/domain/goal"
+	"github.com/nontypeable/financial-tracker/internal/validator"
+	"github.com/shopspring/decimal"
+)
+
+// UpdateRequest changes only the fields it sets. Accounts, when given,
+// replaces every linked account.
+type UpdateRequest struct {
+	Name         *string          `json:"name" validate:"omitempty,min=1,max=100"`
+	TargetAmount *decimal.Decimal `json:"target_amount"`
+	TargetDate   *string          `json:"target_date" validate:"omitempty,datetime=2006-01-02"`
+	Accounts     []LinkRequest    `json:"accounts" validate:"omitempty,min=1,dive"`
+	Archived     *bool            `json:"archived"`
+}
+
+func (r *UpdateRequest) Validate() error {
+	return validator.GetValidator().ValidateStruct(r)
+}
+
+// Params assumes the request has been validated.
+func (r *UpdateRequest) Params() goal.UpdateParams {
+	params := goal.UpdateParams{
+		Name:         r.Name,
+		TargetAmount: r.TargetAmount,
+		Archived:     r.Archived,
+	}
+
+	if r.TargetDate != nil {
+		date, _ := time.Parse(time.DateOnly, *r.TargetDate)
+		params.TargetDate = &date
+	}
+
+	if r.Accounts != nil {
+		params.Accounts = toLinks(r.Accounts)
+	}
+
+	return params
+}
@@ -0,0 +1,178 @@
+package goal
+
+import (
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/nontypeable/financial-tracker/internal/auth"
+	"github.com/nontypeable/financial-tracker/internal/delivery/goal/dto"
+	"github.com/nontypeable/financial-tracker/internal/domain/goal"
+	httpHelper "github.com/nontypeable/financial-tracker/internal/http"
+)
+
+type handler struct {
+	service goal.Service
+}
+
+func NewHandler(service goal.Service) *handler {
+	return &handler{service: service}
+}
+
+func (h *handler) RegisterRoutes(r chi.Router, authMiddleware func(http.Handler) http.Handler) {
+	r.Route("/goal", func(r chi.Router) {
+		r.Group(func(r chi.Router) {
+			r.Use(authMiddleware)
+
+			r.Post("/", h.create)
+			r.Get("/", h.list)
+			r.Get("/{id}", h.get)
+			r.Patch("/{id}", h.update)
+		})
+	})
+}
+
+func (h *handler) create(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.UserIDFromContext(r.Context())
+	if !ok {
+		if err := httpHelper.Error(w, http.StatusInternalServerError, "internal server error"); err != nil {
+			log.Printf("httpHelper.Error: %v", err)
+		}
+		return
+	}
+
+	var payload dto.CreateRequest
+	if err := httpHelper.DecodeAndValidate(r, &payload); err != nil {
+		status, msg := httpHelper.MapAppErrorToHTTP(err)
+		httpHelper.Error(w, status, msg)
+		return
+	}
+
+	id, err := h.service.Create(r.Context(), userID, payload.Params())
+	if err != nil {
+		status, msg := httpHelper.MapAppErrorToHTTP(err)
+		httpHelper.Error(w, status, msg)
+		return
+	}
+
+	if err := httpHelper.JSON(w, http.StatusCreated, &dto.CreateResponse{ID: id}); err != nil {
+		log.Printf("httpHelper.JSON: %v", err)
+	}
+}
+
+func (h *handler) list(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.UserIDFromContext(r.Context())
+	if !ok {
+		if err := httpHelper.Error(w, http.StatusInternalServerError, "internal server error"); err != nil {
+			log.Printf("httpHelper.Error: %v", err)
+		}
+		return
+	}
+
+	includeArchived := r.URL.Query().Get("include_archived") == "true"
+
+	goals, err := h.service.List(r.Context(), userID, includeArchived)
+	if err != nil {
+		status, msg := httpHelper.MapAppErrorToHTTP(err)
+		httpHelper.Error(w, status, msg)
+		return
+	}
+
+	response := dto.ListResponse{Goals: make([]dto.GetResponse, 0, len(goals))}
+	for _, g := range goals {
+		response.Goals = append(response.Goals, toGetResponse(g))
+	}
+
+	if err := httpHelper.JSON(w, http.StatusOK, &response); err != nil {
+		log.Printf("httpHelper.JSON: %v", err)
+	}
+}
+
+func (h *handler) get(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.UserIDFromContext(r.Context())
+	if !ok {
+		if err := httpHelper.Error(w, http.StatusInternalServerError, "internal server error"); err != nil {
+			log.Printf("httpHelper.Error: %v", err)
+		}
+		return
+	}
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		httpHelper.Error(w, http.StatusBadRequest, "invalid goal ID")
+		return
+	}
+
+	goal, err := h.service.GetByID(r.Context(), userID, id)
+	if err != nil {
+		status, msg := httpHelper.MapAppErrorToHTTP(err)
+		httpHelper.Error(w, status, msg)
+		return
+	}
+
+	response := toGetResponse(goal)
+	if err := httpHelper.JSON(w, http.StatusOK, &response); err != nil {
+		log.Printf("httpHelper.JSON: %v", err)
+	}
+}
+
+func (h *handler) update(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.UserIDFromContext(r.Context())
+	if !ok {
+		if err := httpHelper.Error(w, http.StatusInternalServerError, "internal server error"); err != nil {
+			log.Printf("httpHelper.Error: %v", err)
+		}
+		return
+	}
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		httpHelper.Error(w, http.StatusBadRequest, "invalid goal ID")
+		return
+	}
+
+	var payload dto.UpdateRequest
+	if err := httpHelper.DecodeAndValidate(r, &payload); err != nil {
+		status, msg := httpHelper.MapAppErrorToHTTP(err)
+		httpHelper.Error(w, status, msg)
+		return
+	}
+
+	if err := h.service.Update(r.Context(), userID, id, payload.Params()); err != nil {
+		status, msg := httpHelper.MapAppErrorToHTTP(err)
+		httpHelper.Error(w, status, msg)
+		return
+	}
+
+	if err := httpHelper.JSON(w, http.StatusOK, nil); err != nil {
+		log.Printf("httpHelper.JSON: %v", err)
+	}
+}
+
+func toGetResponse(p *goal.Progress) dto.GetResponse {
+	accounts := make([]dto.LinkResponse, 0, len(p.Accounts))
+	for _, link := range p.Accounts {
+		accounts = append(accounts, dto.LinkResponse{AccountID: link.AccountID, Share: link.Share})
+	}
+
+	return dto.GetResponse{
+		ID:              p.ID,
+		Name:            p.Name,
+		TargetAmount:    p.TargetAmount,
+		TargetDate:      p.TargetDate.Format(time.DateOnly),
+		Accounts:        accounts,
+		Current:         p.Current,
+		Remaining:       p.Remaining,
+		Percent:         p.Percent,
+		MonthsLeft:      p.MonthsLeft,
+		RequiredMonthly: p.RequiredMonthly,
+		Contributed:     p.Contributed,
+		MonthlyPace:     p.MonthlyPace,
+		Status:          p.Status,
+		ArchivedAt:      p.ArchivedAt,
+		CreatedAt:       p.CreatedAt,
+		UpdatedAt:       p.UpdatedAt,
+	}
+}
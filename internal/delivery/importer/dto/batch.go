@@ -0,0 +1,69 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nontypeable/financial-tracker/internal/domain/transaction"
+	"github.com/shopspring/decimal"
+)
+
+type BatchResponse struct {
+	ID          uuid.UUID        `json:"id"`
+	AccountID   uuid.UUID        `json:"account_id"`
+	Format      string           `json:"format"`
+	Filename    string           `json:"filename,omitempty"`
+	Status      string           `json:"status"`
+	Valid       int              `json:"valid"`
+	Invalid     int              `json:"invalid"`
+	Duplicates  int              `json:"duplicates"`
+	Rows        []RowResponse    `json:"rows"`
+	Opening     *BalanceResponse `json:"opening_balance,omitempty"`
+	Closing     *BalanceResponse `json:"closing_balance,omitempty"`
+	Check       *BalanceCheck    `json:"balance_check,omitempty"`
+	CommittedAt *time.Time       `json:"committed_at,omitempty"`
+	CreatedAt   time.Time        `json:"created_at"`
+}
+
+type RowResponse struct {
+	Line                int                          `json:"line"`
+	OccurredAt          *time.Time                   `json:"occurred_at,omitempty"`
+	BookingDate         *string                      `json:"booking_date,omitempty"`
+	Amount              *decimal.Decimal             `json:"amount,omitempty"`
+	Type                *transaction.TransactionType `json:"type,omitempty"`
+	Description         string                       `json:"description,omitempty"`
+	ExternalID          string                       `json:"external_id,omitempty"`
+	Counterparty        string                       `json:"counterparty,omitempty"`
+	CounterpartyAccount string                       `json:"counterparty_account,omitempty"`
+	Error               string                       `json:"error,omitempty"`
+	TransactionID       *uuid.UUID                   `json:"transaction_id,omitempty"`
+	DuplicateOf         *uuid.UUID                   `json:"duplicate_of,omitempty"`
+	DuplicateScore      *float64                     `json:"duplicate_score,omitempty"`
+}
+
+type BalanceResponse struct {
+	Amount decimal.Decimal `json:"amount"`
+	Date   string          `json:"date"`
+}
+
+type BalanceCheck struct {
+	StatementBalance decimal.Decimal `json:"statement_balance"`
+	StatementDate    string          `json:"statement_date"`
+	AccountBalance   decimal.Decimal `json:"account_balance"`
+	Pending          decimal.Decimal `json:"pending"`
+	Difference       decimal.Decimal `json:"difference"`
+}
+
+type SummaryResponse struct {
+	AccountsCreated   []string          `json:"accounts_created"`
+	AccountsMatched   []string          `json:"accounts_matched"`
+	CategoriesCreated []string          `json:"categories_created"`
+	Transactions      int               `json:"transactions"`
+	Transfers         int               `json:"transfers"`
+	Skipped           []SkippedResponse `json:"skipped"`
+}
+
+type SkippedResponse struct {
+	Line    int    `json:"line"`
+	Message string `json:"message"`
+}
@@ -0,0 +1,44 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nontypeable/financial-tracker/internal/statement/csv"
+	"github.com/nontypeable/financial-tracker/internal/validator"
+)
+
+type CreateProfileRequest struct {
+	Name     string      `json:"name" validate:"required,min=1,max=100"`
+	Settings csv.Profile `json:"settings"`
+}
+
+func (r *CreateProfileRequest) Validate() error {
+	return validator.GetValidator().ValidateStruct(r)
+}
+
+type CreateProfileResponse struct {
+	ID uuid.UUID `json:"id"`
+}
+
+type UpdateProfileRequest struct {
+	Name     *string      `json:"name" validate:"omitempty,min=1,max=100"`
+	Settings *csv.Profile `json:"settings"`
+}
+
+func (r *UpdateProfileRequest) Validate() error {
+	return validator.GetValidator().ValidateStruct(r)
+}
+
+type ProfileResponse struct {
+	ID        uuid.UUID   `json:"id"`
+	Name      string      `json:"name"`
+	Format    string      `json:"format"`
+	Settings  csv.Profile `json:"settings"`
+	CreatedAt time.Time   `json:"created_at"`
+	UpdatedAt time.Time   `json:"updated_at"`
+}
+
+type ProfileListResponse struct {
+	Profiles []ProfileResponse `json:"profiles"`
+}
@@ -0,0 +1,565 @@
+package importer
+
+import (
+	"log"
+	"mime/multipart"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/nontypeable/financial-tracker/internal/auth"
+	"github.com/nontypeable/financial-tracker/internal/delivery/importer/dto"
+	"github.com/nontypeable/financial-tracker/internal/domain/importer"
+	httpHelper "github.com/nontypeable/financial-tracker/internal/http"
+	"github.com/nontypeable/financial-tracker/internal/statement/qif"
+)
+
+// maxUploadSize bounds a statement upload, including multipart overhead.
+const maxUploadSize = 10 << 20
+
+type handler struct {
+	service importer.Service
+}
+
+func NewHandler(service importer.Service) *handler {
+	return &handler{service: service}
+}
+
+func (h *handler) RegisterRoutes(r chi.Router, authMiddleware func(http.Handler) http.Handler) {
+	r.Route("/import", func(r chi.Router) {
+		r.Group(func(r chi.Router) {
+			r.Use(authMiddleware)
+
+			r.Post("/profile", h.createProfile)
+			r.Get("/profile", h.listProfiles)
+			r.Get("/profile/{id}", h.getProfile)
+			r.Patch("/profile/{id}", h.updateProfile)
+			r.Delete("/profile/{id}", h.deleteProfile)
+
+			r.Post("/csv", h.importCSV)
+			r.Post("/ofx", h.importOFX)
+			r.Post("/camt053", h.importCAMT053)
+			r.Post("/mt940", h.importMT940)
+			r.Post("/qif", h.importQIF)
+			r.Get("/{id}", h.getBatch)
+			r.Post("/{id}/commit", h.commit)
+			r.Delete("/{id}", h.discard)
+		})
+	})
+}
+
+func (h *handler) createProfile(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.UserIDFromContext(r.Context())
+	if !ok {
+		if err := httpHelper.Error(w, http.StatusInternalServerError, "internal server error"); err != nil {
+			log.Printf("httpHelper.Error: %v", err)
+		}
+		return
+	}
+
+	var payload dto.CreateProfileRequest
+	if err := httpHelper.DecodeAndValidate(r, &payload); err != nil {
+		status, msg := httpHelper.MapAppErrorToHTTP(err)
+		httpHelper.Error(w, status, msg)
+		return
+	}
+
+	id, err := h.service.CreateProfile(r.Context(), userID, payload.Name, payload.Settings)
+	if err != nil {
+		status, msg := httpHelper.MapAppErrorToHTTP(err)
+		httpHelper.Error(w, status, msg)
+		return
+	}
+
+	if err := httpHelper.JSON(w, http.StatusCreated, &dto.CreateProfileResponse{ID: id}); err != nil {
+		log.Printf("httpHelper.JSON: %v", err)
+	}
+}
+
+func (h *handler) listProfiles(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.UserIDFromContext(r.Context())
+	if !ok {
+		if err := httpHelper.Error(w, http.StatusInternalServerError, "internal server error"); err != nil {
+			log.Printf("httpHelper.Error: %v", err)
+		}
+		return
+	}
+
+	profiles, err := h.service.ListProfiles(r.Context(), userID)
+	if err != nil {
+		status, msg := httpHelper.MapAppErrorToHTTP(err)
+		httpHelper.Error(w, status, msg)
+		return
+	}
+
+	response := dto.ProfileListResponse{Profiles: make([]dto.ProfileResponse, 0, len(profiles))}
+	for _, profile := range profiles {
+		response.Profiles = append(response.Profiles, toProfileResponse(profile))
+	}
+
+	if err := httpHelper.JSON(w, http.StatusOK, &response); err != nil {
+		log.Printf("httpHelper.JSON: %v", err)
+	}
+}
+
+func (h *handler) getProfile(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.UserIDFromContext(r.Context())
+	if !ok {
+		if err := httpHelper.Error(w, http.StatusInternalServerError, "internal server error"); err != nil {
+			log.Printf("httpHelper.Error: %v", err)
+		}
+		return
+	}
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		httpHelper.Error(w, http.StatusBadRequest, "invalid import profile ID")
+		return
+	}
+
+	profile, err := h.service.GetProfile(r.Context(), userID, id)
+	if err != nil {
+		status, msg := httpHelper.MapAppErrorToHTTP(err)
+		httpHelper.Error(w, status, msg)
+		return
+	}
+
+	response := toProfileResponse(profile)
+	if err := httpHelper.JSON(w, http.StatusOK, &response); err != nil {
+		log.Printf("httpHelper.JSON: %v", err)
+	}
+}
+
+func (h *handler) updateProfile(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.UserIDFromContext(r.Context())
+	if !ok {
+		if err := httpHelper.Error(w, http.StatusInternalServerError, "internal server error"); err != nil {
+			log.Printf("httpHelper.Error: %v", err)
+		}
+		return
+	}
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		httpHelper.Error(w, http.StatusBadRequest, "invalid import profile ID")
+		return
+	}
+
+	var payload dto.UpdateProfileRequest
+	if err := httpHelper.DecodeAndValidate(r, &payload); err != nil {
+		status, msg := httpHelper.MapAppErrorToHTTP(err)
+		httpHelper.Error(w, status, msg)
+		return
+	}
+
+	params := importer.UpdateProfileParams{
+		Name:     payload.Name,
+		Settings: payload.Settings,
+	}
+
+	if err := h.service.UpdateProfile(r.Context(), userID, id, params); err != nil {
+		status, msg := httpHelper.MapAppErrorToHTTP(err)
+		httpHelper.Error(w, status, msg)
+		return
+	}
+
+	if err := httpHelper.JSON(w, http.StatusOK, nil); err != nil {
+		log.Printf("httpHelper.JSON: %v", err)
+	}
+}
+
+func (h *handler) deleteProfile(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.UserIDFromContext(r.Context())
+	if !ok {
+		if err := httpHelper.Error(w, http.StatusInternalServerError, "internal server error"); err != nil {
+			log.Printf("httpHelper.Error: %v", err)
+		}
+		return
+	}
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		httpHelper.Error(w, http.StatusBadRequest, "invalid import profile ID")
+		return
+	}
+
+	if err := h.service.DeleteProfile(r.Context(), userID, id); err != nil {
+		status, msg := httpHelper.MapAppErrorToHTTP(err)
+		httpHelper.Error(w, status, msg)
+		return
+	}
+
+	if err := httpHelper.JSON(w, http.StatusOK, nil); err != nil {
+		log.Printf("httpHelper.JSON: %v", err)
+	}
+}
+
+func (h *handler) importCSV(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.UserIDFromContext(r.Context())
+	if !ok {
+		if err := httpHelper.Error(w, http.StatusInternalServerError, "internal server error"); err != nil {
+			log.Printf("httpHelper.Error: %v", err)
+		}
+		return
+	}
+
+	upload, ok := readUpload(w, r)
+	if !ok {
+		return
+	}
+	defer upload.file.Close()
+
+	profileID, err := uuid.Parse(r.FormValue("profile_id"))
+	if err != nil {
+		httpHelper.Error(w, http.StatusBadRequest, "invalid import profile ID")
+		return
+	}
+
+	batch, err := h.service.ImportCSV(r.Context(), userID, upload.accountID, profileID, upload.filename, upload.file)
+	if err != nil {
+		status, msg := httpHelper.MapAppErrorToHTTP(err)
+		httpHelper.Error(w, status, msg)
+		return
+	}
+
+	response := toBatchResponse(batch)
+	if err := httpHelper.JSON(w, http.StatusCreated, &response); err != nil {
+		log.Printf("httpHelper.JSON: %v", err)
+	}
+}
+
+func (h *handler) importOFX(w http.ResponseWriter, r *http.Request) {
+	h.importStatement(w, r, importer.FormatOFX)
+}
+
+func (h *handler) importCAMT053(w http.ResponseWriter, r *http.Request) {
+	h.importStatement(w, r, importer.FormatCAMT053)
+}
+
+func (h *handler) importMT940(w http.ResponseWriter, r *http.Request) {
+	h.importStatement(w, r, importer.FormatMT940)
+}
+
+func (h *handler) importStatement(w http.ResponseWriter, r *http.Request, format importer.Format) {
+	userID, ok := auth.UserIDFromContext(r.Context())
+	if !ok {
+		if err := httpHelper.Error(w, http.StatusInternalServerError, "internal server error"); err != nil {
+			log.Printf("httpHelper.Error: %v", err)
+		}
+		return
+	}
+
+	upload, ok := readUpload(w, r)
+	if !ok {
+		return
+	}
+	defer upload.file.Close()
+
+	params := importer.StatementParams{
+		AccountID: upload.accountID,
+		Format:    format,
+		Filename:  upload.filename,
+		Encoding:  r.FormValue("encoding"),
+	}
+
+	batch, err := h.service.ImportStatement(r.Context(), userID, params, upload.file)
+	if err != nil {
+		status, msg := httpHelper.MapAppErrorToHTTP(err)
+		httpHelper.Error(w, status, msg)
+		return
+	}
+
+	response := toBatchResponse(batch)
+	if err := httpHelper.JSON(w, http.StatusCreated, &response); err != nil {
+		log.Printf("httpHelper.JSON: %v", err)
+	}
+}
+
+func (h *handler) importQIF(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.UserIDFromContext(r.Context())
+	if !ok {
+		if err := httpHelper.Error(w, http.StatusInternalServerError, "internal server error"); err != nil {
+			log.Printf("httpHelper.Error: %v", err)
+		}
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxUploadSize)
+	if err := r.ParseMultipartForm(maxUploadSize); err != nil {
+		httpHelper.Error(w, http.StatusBadRequest, "invalid multipart form")
+		return
+	}
+
+	var params importer.QIFParams
+
+	// The account is optional: files with !Account blocks name their own.
+	if raw := r.FormValue("account_id"); raw != "" {
+		accountID, err := uuid.Parse(raw)
+		if err != nil {
+			httpHelper.Error(w, http.StatusBadRequest, "invalid account ID")
+			return
+		}
+		params.AccountID = &accountID
+	}
+
+	switch order := qif.DateOrder(r.FormValue("date_order")); order {
+	case qif.DateOrderAuto, qif.DateOrderMDY, qif.DateOrderDMY:
+		params.DateOrder = order
+	default:
+		httpHelper.Error(w, http.StatusBadRequest, "date_order must be mdy or dmy")
+		return
+	}
+	params.Encoding = r.FormValue("encoding")
+
+	file, _, err := r.FormFile("file")
+	if err != nil {
+		httpHelper.Error(w, http.StatusBadRequest, "statement file is required")
+		return
+	}
+	defer file.Close()
+
+	summary, err := h.service.ImportQIF(r.Context(), userID, params, file)
+	if err != nil {
+		status, msg := httpHelper.MapAppErrorToHTTP(err)
+		httpHelper.Error(w, status, msg)
+		return
+	}
+
+	response := toSummaryResponse(summary)
+	if err := httpHelper.JSON(w, http.StatusCreated, &response); err != nil {
+		log.Printf("httpHelper.JSON: %v", err)
+	}
+}
+
+func (h *handler) getBatch(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.UserIDFromContext(r.Context())
+	if !ok {
+		if err := httpHelper.Error(w, http.StatusInternalServerError, "internal server error"); err != nil {
+			log.Printf("httpHelper.Error: %v", err)
+		}
+		return
+	}
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		httpHelper.Error(w, http.StatusBadRequest, "invalid import batch ID")
+		return
+	}
+
+	batch, err := h.service.GetBatch(r.Context(), userID, id)
+	if err != nil {
+		status, msg := httpHelper.MapAppErrorToHTTP(err)
+		httpHelper.Error(w, status, msg)
+		return
+	}
+
+	response := toBatchResponse(batch)
+	if err := httpHelper.JSON(w, http.StatusOK, &response); err != nil {
+		log.Printf("httpHelper.JSON: %v", err)
+	}
+}
+
+func (h *handler) commit(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.UserIDFromContext(r.Context())
+	if !ok {
+		if err := httpHelper.Error(w, http.StatusInternalServerError, "internal server error"); err != nil {
+			log.Printf("httpHelper.Error: %v", err)
+		}
+		return
+	}
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		httpHelper.Error(w, http.StatusBadRequest, "invalid import batch ID")
+		return
+	}
+
+	skipDuplicates, err := httpHelper.QueryBool(r.URL.Query(), "skip_duplicates")
+	if err != nil {
+		httpHelper.Error(w, http.StatusBadRequest, "invalid skip_duplicates")
+		return
+	}
+
+	batch, err := h.service.Commit(r.Context(), userID, id, skipDuplicates)
+	if err != nil {
+		status, msg := httpHelper.MapAppErrorToHTTP(err)
+		httpHelper.Error(w, status, msg)
+		return
+	}
+
+	response := toBatchResponse(batch)
+	if err := httpHelper.JSON(w, http.StatusOK, &response); err != nil {
+		log.Printf("httpHelper.JSON: %v", err)
+	}
+}
+
+func (h *handler) discard(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.UserIDFromContext(r.Context())
+	if !ok {
+		if err := httpHelper.Error(w, http.StatusInternalServerError, "internal server error"); err != nil {
+			log.Printf("httpHelper.Error: %v", err)
+		}
+		return
+	}
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		httpHelper.Error(w, http.StatusBadRequest, "invalid import batch ID")
+		return
+	}
+
+	if err := h.service.Discard(r.Context(), userID, id); err != nil {
+		status, msg := httpHelper.MapAppErrorToHTTP(err)
+		httpHelper.Error(w, status, msg)
+		return
+	}
+
+	if err := httpHelper.JSON(w, http.StatusOK, nil); err != nil {
+		log.Printf("httpHelper.JSON: %v", err)
+	}
+}
+
+type upload struct {
+	accountID uuid.UUID
+	filename  string
+	file      multipart.File
+}
+
+// readUpload parses a multipart statement upload carrying the file and the
+// target account. It writes the error response itself when it fails.
+func readUpload(w http.ResponseWriter, r *http.Request) (*upload, bool) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxUploadSize)
+	if err := r.ParseMultipartForm(maxUploadSize); err != nil {
+		httpHelper.Error(w, http.StatusBadRequest, "invalid multipart form")
+		return nil, false
+	}
+
+	accountID, err := uuid.Parse(r.FormValue("account_id"))
+	if err != nil {
+		httpHelper.Error(w, http.StatusBadRequest, "invalid account ID")
+		return nil, false
+	}
+
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		httpHelper.Error(w, http.StatusBadRequest, "statement file is required")
+		return nil, false
+	}
+
+	return &upload{accountID: accountID, filename: header.Filename, file: file}, true
+}
+
+func toSummaryResponse(s *importer.Summary) dto.SummaryResponse {
+	response := dto.SummaryResponse{
+		AccountsCreated:   nonNil(s.AccountsCreated),
+		AccountsMatched:   nonNil(s.AccountsMatched),
+		CategoriesCreated: nonNil(s.CategoriesCreated),
+		Transactions:      s.Transactions,
+		Transfers:         s.Transfers,
+		Skipped:           make([]dto.SkippedResponse, 0, len(s.Skipped)),
+	}
+
+	for _, skipped := range s.Skipped {
+		response.Skipped = append(response.Skipped, dto.SkippedResponse{
+			Line:    skipped.Line,
+			Message: skipped.Message,
+		})
+	}
+
+	return response
+}
+
+func nonNil(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
+
+func toProfileResponse(p *importer.Profile) dto.ProfileResponse {
+	return dto.ProfileResponse{
+		ID:        p.ID,
+		Name:      p.Name,
+		Format:    string(p.Format),
+		Settings:  p.Settings,
+		CreatedAt: p.CreatedAt,
+		UpdatedAt: p.UpdatedAt,
+	}
+}
+
+func toBatchResponse(b *importer.Batch) dto.BatchResponse {
+	valid, invalid := b.Counts()
+
+	response := dto.BatchResponse{
+		ID:          b.ID,
+		AccountID:   b.AccountID,
+		Format:      string(b.Format),
+		Filename:    b.Filename,
+		Status:      string(b.Status),
+		Valid:       valid,
+		Invalid:     invalid,
+		Duplicates:  b.Duplicates(),
+		Rows:        make([]dto.RowResponse, 0, len(b.Rows)),
+		CommittedAt: b.CommittedAt,
+		CreatedAt:   b.CreatedAt,
+	}
+
+	if b.OpeningBalance != nil && b.OpeningDate != nil {
+		response.Opening = &dto.BalanceResponse{
+			Amount: *b.OpeningBalance,
+			Date:   b.OpeningDate.Format(time.DateOnly),
+		}
+	}
+
+	if b.StatementBalance != nil && b.StatementDate != nil {
+		response.Closing = &dto.BalanceResponse{
+			Amount: *b.StatementBalance,
+			Date:   b.StatementDate.Format(time.DateOnly),
+		}
+	}
+
+	if b.Check != nil {
+		response.Check = &dto.BalanceCheck{
+			StatementBalance: b.Check.StatementBalance,
+			StatementDate:    b.Check.StatementDate.Format(time.DateOnly),
+			AccountBalance:   b.Check.AccountBalance,
+			Pending:          b.Check.Pending,
+			Difference:       b.Check.Difference,
+		}
+	}
+
+	for _, row := range b.Rows {
+		item := dto.RowResponse{
+			Line:                row.Line,
+			OccurredAt:          row.OccurredAt,
+			Description:         row.Description,
+			ExternalID:          row.ExternalID,
+			Counterparty:        row.Counterparty,
+			CounterpartyAccount: row.CounterpartyAccount,
+			Error:               row.Error,
+			TransactionID:       row.TransactionID,
+			DuplicateOf:         row.DuplicateOf,
+		}
+
+		if row.DuplicateOf != nil {
+			item.DuplicateScore = &row.DuplicateScore
+		}
+
+		if row.BookingDate != nil {
+			bookingDate := row.BookingDate.Format(time.DateOnly)
+			item.BookingDate = &bookingDate
+		}
+
+		if row.IsValid() {
+			amount := row.Amount.Abs()
+			kind := row.Type()
+			item.Amount = &amount
+			item.Type = &kind
+		}
+
+		response.Rows = append(response.Rows, item)
+	}
+
+	return response
+}
@@ -0,0 +1,20 @@
+package dto
+
+import (
+	"github.com/google/uuid"
+	"github.com/nontypeable/financial-tracker/internal/validator"
+)
+
+type ClearRequest struct {
+	TransactionIDs []uuid.UUID `json:"transaction_ids" validate:"required,min=1,max=500"`
+	// Cleared defaults to true; send false to move transactions back to pending.
+	Cleared *bool `json:"cleared"`
+}
+
+func (r *ClearRequest) Validate() error {
+	return validator.GetValidator().ValidateStruct(r)
+}
+
+func (r *ClearRequest) IsCleared() bool {
+	return r.Cleared == nil || *r.Cleared
+}
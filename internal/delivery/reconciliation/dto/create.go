@@ -0,0 +1,29 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nontypeable/financial-tracker/internal/validator"
+	"github.com/shopspring/decimal"
+)
+
+type CreateRequest struct {
+	AccountID        uuid.UUID       `json:"account_id" validate:"required"`
+	StatementDate    string          `json:"statement_date" validate:"required,datetime=2006-01-02"`
+	StatementBalance decimal.Decimal `json:"statement_balance"`
+}
+
+func (r *CreateRequest) Validate() error {
+	return validator.GetValidator().ValidateStruct(r)
+}
+
+// Date assumes the request has been validated.
+func (r *CreateRequest) Date() time.Time {
+	date, _ := time.Parse(time.DateOnly, r.StatementDate)
+	return date
+}
+
+type CreateResponse struct {
+	ID uuid.UUID `json:"id"`
+}
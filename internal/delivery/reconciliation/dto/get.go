@@ -0,0 +1,39 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nontypeable/financial-tracker/internal/domain/transaction"
+	"github.com/shopspring/decimal"
+)
+
+type GetResponse struct {
+	ID               uuid.UUID       `json:"id"`
+	AccountID        uuid.UUID       `json:"account_id"`
+	StatementDate    string          `json:"statement_date"`
+	StatementBalance decimal.Decimal `json:"statement_balance"`
+	CompletedAt      *time.Time      `json:"completed_at,omitempty"`
+	CreatedAt        time.Time       `json:"created_at"`
+	UpdatedAt        time.Time       `json:"updated_at"`
+}
+
+type SummaryResponse struct {
+	GetResponse
+	ClearedBalance decimal.Decimal       `json:"cleared_balance"`
+	Difference     decimal.Decimal       `json:"difference"`
+	Transactions   []TransactionResponse `json:"transactions"`
+}
+
+type TransactionResponse struct {
+	ID          uuid.UUID                   `json:"id"`
+	Amount      decimal.Decimal             `json:"amount"`
+	Type        transaction.TransactionType `json:"type"`
+	Description string                      `json:"description"`
+	Status      transaction.Status          `json:"status"`
+	OccurredAt  time.Time                   `json:"occurred_at"`
+}
+
+type ListResponse struct {
+	Reconciliations []GetResponse `json:"reconciliations"`
+}
@@ -0,0 +1,246 @@
+package reconciliation
+
+import (
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/nontypeable/financial-tracker/internal/auth"
+	"github.com/nontypeable/financial-tracker/internal/delivery/reconciliation/dto"
+	"github.com/nontypeable/financial-tracker/internal/domain/reconciliation"
+	httpHelper "github.com/nontypeable/financial-tracker/internal/http"
+)
+
+type handler struct {
+	service reconciliation.Service
+}
+
+func NewHandler(service reconciliation.Service) *handler {
+	return &handler{service: service}
+}
+
+func (h *handler) RegisterRoutes(r chi.Router, authMiddleware func(http.Handler) http.Handler) {
+	r.Route("/reconciliation", func(r chi.Router) {
+		r.Group(func(r chi.Router) {
+			r.Use(authMiddleware)
+
+			r.Post("/", h.create)
+			r.Get("/", h.list)
+			r.Get("/{id}", h.get)
+			r.Delete("/{id}", h.cancel)
+			r.Post("/{id}/clear", h.clear)
+			r.Post("/{id}/complete", h.complete)
+		})
+	})
+}
+
+func (h *handler) create(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.UserIDFromContext(r.Context())
+	if !ok {
+		if err := httpHelper.Error(w, http.StatusInternalServerError, "internal server error"); err != nil {
+			log.Printf("httpHelper.Error: %v", err)
+		}
+		return
+	}
+
+	var payload dto.CreateRequest
+	if err := httpHelper.DecodeAndValidate(r, &payload); err != nil {
+		status, msg := httpHelper.MapAppErrorToHTTP(err)
+		httpHelper.Error(w, status, msg)
+		return
+	}
+
+	id, err := h.service.Start(r.Context(), userID, payload.AccountID, payload.Date(), payload.StatementBalance)
+	if err != nil {
+		status, msg := httpHelper.MapAppErrorToHTTP(err)
+		httpHelper.Error(w, status, msg)
+		return
+	}
+
+	if err := httpHelper.JSON(w, http.StatusCreated, &dto.CreateResponse{ID: id}); err != nil {
+		log.Printf("httpHelper.JSON: %v", err)
+	}
+}
+
+func (h *handler) list(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.UserIDFromContext(r.Context())
+	if !ok {
+		if err := httpHelper.Error(w, http.StatusInternalServerError, "internal server error"); err != nil {
+			log.Printf("httpHelper.Error: %v", err)
+		}
+		return
+	}
+
+	accountID, err := uuid.Parse(r.URL.Query().Get("account_id"))
+	if err != nil {
+		httpHelper.Error(w, http.StatusBadRequest, "invalid account ID")
+		return
+	}
+
+	reconciliations, err := h.service.List(r.Context(), userID, accountID)
+	if err != nil {
+		status, msg := httpHelper.MapAppErrorToHTTP(err)
+		httpHelper.Error(w, status, msg)
+		return
+	}
+
+	response := dto.ListResponse{Reconciliations: make([]dto.GetResponse, 0, len(reconciliations))}
+	for _, rec := range reconciliations {
+		response.Reconciliations = append(response.Reconciliations, toGetResponse(rec))
+	}
+
+	if err := httpHelper.JSON(w, http.StatusOK, &response); err != nil {
+		log.Printf("httpHelper.JSON: %v", err)
+	}
+}
+
+func (h *handler) get(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.UserIDFromContext(r.Context())
+	if !ok {
+		if err := httpHelper.Error(w, http.StatusInternalServerError, "internal server error"); err != nil {
+			log.Printf("httpHelper.Error: %v", err)
+		}
+		return
+	}
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		httpHelper.Error(w, http.StatusBadRequest, "invalid reconciliation ID")
+		return
+	}
+
+	summary, err := h.service.GetByID(r.Context(), userID, id)
+	if err != nil {
+		status, msg := httpHelper.MapAppErrorToHTTP(err)
+		httpHelper.Error(w, status, msg)
+		return
+	}
+
+	response := toSummaryResponse(summary)
+	if err := httpHelper.JSON(w, http.StatusOK, &response); err != nil {
+		log.Printf("httpHelper.JSON: %v", err)
+	}
+}
+
+func (h *handler) clear(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.UserIDFromContext(r.Context())
+	if !ok {
+		if err := httpHelper.Error(w, http.StatusInternalServerError, "internal server error"); err != nil {
+			log.Printf("httpHelper.Error: %v", err)
+		}
+		return
+	}
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		httpHelper.Error(w, http.StatusBadRequest, "invalid reconciliation ID")
+		return
+	}
+
+	var payload dto.ClearRequest
+	if err := httpHelper.DecodeAndValidate(r, &payload); err != nil {
+		status, msg := httpHelper.MapAppErrorToHTTP(err)
+		httpHelper.Error(w, status, msg)
+		return
+	}
+
+	summary, err := h.service.Clear(r.Context(), userID, id, payload.TransactionIDs, payload.IsCleared())
+	if err != nil {
+		status, msg := httpHelper.MapAppErrorToHTTP(err)
+		httpHelper.Error(w, status, msg)
+		return
+	}
+
+	response := toSummaryResponse(summary)
+	if err := httpHelper.JSON(w, http.StatusOK, &response); err != nil {
+		log.Printf("httpHelper.JSON: %v", err)
+	}
+}
+
+func (h *handler) complete(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.UserIDFromContext(r.Context())
+	if !ok {
+		if err := httpHelper.Error(w, http.StatusInternalServerError, "internal server error"); err != nil {
+			log.Printf("httpHelper.Error: %v", err)
+		}
+		return
+	}
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		httpHelper.Error(w, http.StatusBadRequest, "invalid reconciliation ID")
+		return
+	}
+
+	if err := h.service.Complete(r.Context(), userID, id); err != nil {
+		status, msg := httpHelper.MapAppErrorToHTTP(err)
+		httpHelper.Error(w, status, msg)
+		return
+	}
+
+	if err := httpHelper.JSON(w, http.StatusOK, nil); err != nil {
+		log.Printf("httpHelper.JSON: %v", err)
+	}
+}
+
+func (h *handler) cancel(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.UserIDFromContext(r.Context())
+	if !ok {
+		if err := httpHelper.Error(w, http.StatusInternalServerError, "internal server error"); err != nil {
+			log.Printf("httpHelper.Error: %v", err)
+		}
+		return
+	}
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		httpHelper.Error(w, http.StatusBadRequest, "invalid reconciliation ID")
+		return
+	}
+
+	if err := h.service.Cancel(r.Context(), userID, id); err != nil {
+		status, msg := httpHelper.MapAppErrorToHTTP(err)
+		httpHelper.Error(w, status, msg)
+		return
+	}
+
+	if err := httpHelper.JSON(w, http.StatusOK, nil); err != nil {
+		log.Printf("httpHelper.JSON: %v", err)
+	}
+}
+
+func toGetResponse(r *reconciliation.Reconciliation) dto.GetResponse {
+	return dto.GetResponse{
+		ID:               r.ID,
+		AccountID:        r.AccountID,
+		StatementDate:    r.StatementDate.Format(time.DateOnly),
+		StatementBalance: r.StatementBalance,
+		CompletedAt:      r.CompletedAt,
+		CreatedAt:        r.CreatedAt,
+		UpdatedAt:        r.UpdatedAt,
+	}
+}
+
+func toSummaryResponse(s *reconciliation.Summary) dto.SummaryResponse {
+	response := dto.SummaryResponse{
+		GetResponse:    toGetResponse(s.Reconciliation),
+		ClearedBalance: s.ClearedBalance,
+		Difference:     s.Difference,
+		Transactions:   make([]dto.TransactionResponse, 0, len(s.Transactions)),
+	}
+
+	for _, t := range s.Transactions {
+		response.Transactions = append(response.Transactions, dto.TransactionResponse{
+			ID:          t.ID,
+			Amount:      t.Amount,
+			Type:        t.Type,
+			Description: t.Description,
+			Status:      t.Status,
+			OccurredAt:  t.OccurredAt,
+		})
+	}
+
+	return response
+}
@@ -0,0 +1,57 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nontypeable/financial-tracker/internal/domain/recurring"
+	"github.com/nontypeable/financial-tracker/internal/domain/transaction"
+	"github.com/nontypeable/financial-tracker/internal/validator"
+	"github.com/shopspring/decimal"
+)
+
+type CreateRequest struct {
+	AccountID   uuid.UUID                   `json:"account_id" validate:"required"`
+	Amount      decimal.Decimal             `json:"amount"`
+	Type        transaction.TransactionType `json:"type" validate:"required,oneof=income expense"`
+	Description string                      `json:"description" validate:"max=1000"`
+	CategoryID  *uuid.UUID                  `json:"category_id"`
+	Frequency   recurring.Frequency         `json:"frequency" validate:"required,oneof=daily weekly monthly yearly"`
+	Interval    int                         `json:"interval" validate:"omitempty,min=1,max=366"`
+	ByMonthDay  *int                        `json:"by_month_day" validate:"omitempty,min=1,max=31"`
+	StartDate   string                      `json:"start_date" validate:"required,datetime=2006-01-02"`
+	EndDate     *string                     `json:"end_date" validate:"omitempty,datetime=2006-01-02"`
+	Count       *int                        `json:"count" validate:"omitempty,min=1"`
+}
+
+func (r *CreateRequest) Validate() error {
+	return validator.GetValidator().ValidateStruct(r)
+}
+
+// Params assumes the request has been validated, so dates parse cleanly.
+func (r *CreateRequest) Params() recurring.CreateParams {
+	params := recurring.CreateParams{
+		AccountID:   r.AccountID,
+		Amount:      r.Amount,
+		Type:        r.Type,
+		Description: r.Description,
+		CategoryID:  r.CategoryID,
+		Frequency:   r.Frequency,
+		Interval:    r.Interval,
+		ByMonthDay:  r.ByMonthDay,
+		Count:       r.Count,
+	}
+
+	params.StartDate, _ = time.Parse(time.DateOnly, r.StartDate)
+
+	if r.EndDate != nil {
+		endDate, _ := time.Parse(time.DateOnly, *r.EndDate)
+		params.EndDate = &endDate
+	}
+
+	return params
+}
+
+type CreateResponse struct {
+	ID uuid.UUID `json:"id"`
+}
@@ -0,0 +1,33 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nontypeable/financial-tracker/internal/domain/recurring"
+	"github.com/nontypeable/financial-tracker/internal/domain/transaction"
+	"github.com/shopspring/decimal"
+)
+
+type GetResponse struct {
+	ID             uuid.UUID                   `json:"id"`
+	AccountID      uuid.UUID                   `json:"account_id"`
+	Amount         decimal.Decimal             `json:"amount"`
+	Type           transaction.TransactionType `json:"type"`
+	Description    string                      `json:"description"`
+	CategoryID     *uuid.UUID                  `json:"category_id,omitempty"`
+	Frequency      recurring.Frequency         `json:"frequency"`
+	Interval       int                         `json:"interval"`
+	ByMonthDay     *int                        `json:"by_month_day,omitempty"`
+	StartDate      string                      `json:"start_date"`
+	EndDate        *string                     `json:"end_date,omitempty"`
+	Count          *int                        `json:"count,omitempty"`
+	NextOccurrence *string                     `json:"next_occurrence,omitempty"`
+	PausedAt       *time.Time                  `json:"paused_at,omitempty"`
+	CreatedAt      time.Time                   `json:"created_at"`
+	UpdatedAt      time.Time                   `json:"updated_at"`
+}
+
+type ListResponse struct {
+	Rules []GetResponse `json:"rules"`
+}
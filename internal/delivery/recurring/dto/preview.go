@@ -0,0 +1,36 @@
+package dto
+
+import (
+	"net/url"
+
+	httpHelper "github.com/nontypeable/financial-tracker/internal/http"
+	"github.com/nontypeable/financial-tracker/internal/validator"
+)
+
+type PreviewRequest struct {
+	Limit int `validate:"omitempty,min=1,max=100"`
+}
+
+func (r *PreviewRequest) BindQuery(values url.Values) error {
+	limit, err := httpHelper.QueryInt(values, "limit")
+	if err != nil {
+		return err
+	}
+
+	r.Limit = limit
+
+	return nil
+}
+
+func (r *PreviewRequest) Validate() error {
+	return validator.GetValidator().ValidateStruct(r)
+}
+
+type OccurrenceResponse struct {
+	Date    string `json:"date"`
+	Skipped bool   `json:"skipped"`
+}
+
+type PreviewResponse struct {
+	Occurrences []OccurrenceResponse `json:"occurrences"`
+}
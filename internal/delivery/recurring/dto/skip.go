@@ -0,0 +1,20 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/nontypeable/financial-tracker/internal/validator"
+)
+
+type SkipRequest struct {
+	Date string `json:"date" validate:"required,datetime=2006-01-02"`
+}
+
+func (r *SkipRequest) Validate() error {
+	return validator.GetValidator().ValidateStruct(r)
+}
+
+func (r *SkipRequest) OccurrenceDate() time.Time {
+	date, _ := time.Parse(time.DateOnly, r.Date)
+	return date
+}
@@ -0,0 +1,82 @@
+package dto
+
+import (
+	"net/url"
+	"time"
+
+	"github.com/google/uuid"
+	transactionDto "github.com/nontypeable/financial-tracker/internal/delivery/transaction/dto"
+	"github.com/nontypeable/financial-tracker/internal/domain/report"
+	"github.com/nontypeable/financial-tracker/internal/domain/transaction"
+	httpHelper "github.com/nontypeable/financial-tracker/internal/http"
+	"github.com/nontypeable/financial-tracker/internal/validator"
+	"github.com/shopspring/decimal"
+)
+
+// BreakdownRequest takes the transaction listing filters plus the number of
+// descriptions to list. From and To are required; sorting, cursor and
+// limit are ignored.
+type BreakdownRequest struct {
+	transactionDto.ListRequest
+	Top int `validate:"min=0,max=100"`
+}
+
+func (r *BreakdownRequest) BindQuery(values url.Values) error {
+	if err := r.ListRequest.BindQuery(values); err != nil {
+		return err
+	}
+
+	var err error
+	if r.Top, err = httpHelper.QueryInt(values, "top"); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *BreakdownRequest) Validate() error {
+	return validator.GetValidator().ValidateStruct(r)
+}
+
+func (r *BreakdownRequest) Params() (report.BreakdownParams, error) {
+	filter, err := r.Filter()
+	if err != nil {
+		return report.BreakdownParams{}, err
+	}
+
+	return report.BreakdownParams{
+		Filter: filter,
+		Top:    r.Top,
+	}, nil
+}
+
+type ComparisonResponse struct {
+	Current       decimal.Decimal  `json:"current"`
+	Previous      decimal.Decimal  `json:"previous"`
+	Change        decimal.Decimal  `json:"change"`
+	ChangePercent *decimal.Decimal `json:"change_percent"`
+	Share         decimal.Decimal  `json:"share"`
+}
+
+type CategoryShareResponse struct {
+	CategoryID *uuid.UUID `json:"category_id"`
+	ParentID   *uuid.UUID `json:"parent_id,omitempty"`
+	Name       string     `json:"name"`
+	ComparisonResponse
+}
+
+type DescriptionShareResponse struct {
+	Description string `json:"description"`
+	Count       int    `json:"count"`
+	ComparisonResponse
+}
+
+type BreakdownResponse struct {
+	Type         transaction.TransactionType `json:"type"`
+	From         time.Time                   `json:"from"`
+	To           time.Time                   `json:"to"`
+	PreviousFrom time.Time                   `json:"previous_from"`
+	Total        ComparisonResponse          `json:"total"`
+	Categories   []CategoryShareResponse     `json:"categories"`
+	Descriptions []DescriptionShareResponse  `json:"descriptions"`
+}
@@ -0,0 +1,86 @@
+package dto
+
+import (
+	"net/url"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nontypeable/financial-tracker/internal/domain/report"
+	"github.com/nontypeable/financial-tracker/internal/domain/transaction"
+	httpHelper "github.com/nontypeable/financial-tracker/internal/http"
+	"github.com/nontypeable/financial-tracker/internal/validator"
+	"github.com/shopspring/decimal"
+)
+
+type CashFlowRequest struct {
+	AccountIDs  []uuid.UUID
+	CategoryIDs []uuid.UUID
+	TagIDsAny   []uuid.UUID
+	TagIDsAll   []uuid.UUID
+	From        *time.Time `validate:"required"`
+	To          *time.Time `validate:"required"`
+	Interval    string     `validate:"omitempty,oneof=day week month year"`
+}
+
+func (r *CashFlowRequest) BindQuery(values url.Values) error {
+	var err error
+
+	if r.AccountIDs, err = httpHelper.QueryUUIDs(values, "account_id"); err != nil {
+		return err
+	}
+	if r.CategoryIDs, err = httpHelper.QueryUUIDs(values, "category_id"); err != nil {
+		return err
+	}
+	if r.TagIDsAny, err = httpHelper.QueryUUIDs(values, "tag_any"); err != nil {
+		return err
+	}
+	if r.TagIDsAll, err = httpHelper.QueryUUIDs(values, "tag_all"); err != nil {
+		return err
+	}
+	if r.From, err = httpHelper.QueryTime(values, "from", false); err != nil {
+		return err
+	}
+	if r.To, err = httpHelper.QueryTime(values, "to", true); err != nil {
+		return err
+	}
+
+	r.Interval = values.Get("interval")
+	if r.Interval == "" {
+		r.Interval = string(transaction.Monthly)
+	}
+
+	return nil
+}
+
+func (r *CashFlowRequest) Validate() error {
+	return validator.GetValidator().ValidateStruct(r)
+}
+
+func (r *CashFlowRequest) Params() report.CashFlowParams {
+	return report.CashFlowParams{
+		Filter: transaction.Filter{
+			AccountIDs:  r.AccountIDs,
+			CategoryIDs: r.CategoryIDs,
+			TagIDsAny:   r.TagIDsAny,
+			TagIDsAll:   r.TagIDsAll,
+			From:        r.From,
+			To:          r.To,
+		},
+		Interval: transaction.Interval(r.Interval),
+	}
+}
+
+type PeriodResponse struct {
+	Start   string          `json:"start"`
+	Income  decimal.Decimal `json:"income"`
+	Expense decimal.Decimal `json:"expense"`
+	Net     decimal.Decimal `json:"net"`
+}
+
+type CashFlowResponse struct {
+	Interval transaction.Interval `json:"interval"`
+	Periods  []PeriodResponse     `json:"periods"`
+	Income   decimal.Decimal      `json:"income"`
+	Expense  decimal.Decimal      `json:"expense"`
+	Net      decimal.Decimal      `json:"net"`
+}
@@ -0,0 +1,83 @@
+package dto
+
+import (
+	"net/url"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nontypeable/financial-tracker/internal/domain/account"
+	"github.com/nontypeable/financial-tracker/internal/domain/report"
+	"github.com/nontypeable/financial-tracker/internal/domain/transaction"
+	httpHelper "github.com/nontypeable/financial-tracker/internal/http"
+	"github.com/nontypeable/financial-tracker/internal/validator"
+	"github.com/shopspring/decimal"
+)
+
+// NetWorthRequest reads the report range from the query string. to defaults
+// to now, and a bare YYYY-MM-DD date for it covers the whole day.
+type NetWorthRequest struct {
+	AccountIDs []uuid.UUID
+	From       *time.Time `validate:"required"`
+	To         *time.Time
+	Interval   string `validate:"omitempty,oneof=day week month year"`
+}
+
+func (r *NetWorthRequest) BindQuery(values url.Values) error {
+	var err error
+
+	if r.AccountIDs, err = httpHelper.QueryUUIDs(values, "account_id"); err != nil {
+		return err
+	}
+	if r.From, err = httpHelper.QueryTime(values, "from", false); err != nil {
+		return err
+	}
+	if r.To, err = httpHelper.QueryTime(values, "to", true); err != nil {
+		return err
+	}
+
+	r.Interval = values.Get("interval")
+	if r.Interval == "" {
+		r.Interval = string(transaction.Monthly)
+	}
+
+	return nil
+}
+
+func (r *NetWorthRequest) Validate() error {
+	return validator.GetValidator().ValidateStruct(r)
+}
+
+func (r *NetWorthRequest) Params() report.NetWorthParams {
+	to := time.Now()
+	if r.To != nil {
+		to = *r.To
+	}
+
+	return report.NetWorthParams{
+		AccountIDs: r.AccountIDs,
+		From:       *r.From,
+		To:         to,
+		Interval:   transaction.Interval(r.Interval),
+	}
+}
+
+type NetWorthPointResponse struct {
+	Start       string          `json:"start"`
+	AsOf        time.Time       `json:"as_of"`
+	Assets      decimal.Decimal `json:"assets"`
+	Liabilities decimal.Decimal `json:"liabilities"`
+	Net         decimal.Decimal `json:"net"`
+}
+
+type AccountWorthResponse struct {
+	AccountID uuid.UUID       `json:"account_id"`
+	Name      string          `json:"name"`
+	Kind      account.Kind    `json:"kind"`
+	Balance   decimal.Decimal `json:"balance"`
+}
+
+type NetWorthResponse struct {
+	Interval transaction.Interval    `json:"interval"`
+	Points   []NetWorthPointResponse `json:"points"`
+	Accounts []AccountWorthResponse  `json:"accounts"`
+}
@@ -0,0 +1,217 @@
+package report
+
+import (
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/nontypeable/financial-tracker/internal/auth"
+	"github.com/nontypeable/financial-tracker/internal/delivery/report/dto"
+	"github.com/nontypeable/financial-tracker/internal/domain/report"
+	"github.com/nontypeable/financial-tracker/internal/domain/transaction"
+	httpHelper "github.com/nontypeable/financial-tracker/internal/http"
+)
+
+type handler struct {
+	service report.Service
+}
+
+func NewHandler(service report.Service) *handler {
+	return &handler{service: service}
+}
+
+func (h *handler) RegisterRoutes(r chi.Router, authMiddleware func(http.Handler) http.Handler) {
+	r.Route("/report", func(r chi.Router) {
+		r.Group(func(r chi.Router) {
+			r.Use(authMiddleware)
+
+			r.Get("/cashflow", h.cashFlow)
+			r.Get("/breakdown", h.breakdown)
+			r.Get("/net-worth", h.netWorth)
+		})
+	})
+}
+
+func (h *handler) cashFlow(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.UserIDFromContext(r.Context())
+	if !ok {
+		if err := httpHelper.Error(w, http.StatusInternalServerError, "internal server error"); err != nil {
+			log.Printf("httpHelper.Error: %v", err)
+		}
+		return
+	}
+
+	var query dto.CashFlowRequest
+	if err := httpHelper.DecodeQueryAndValidate(r, &query); err != nil {
+		status, msg := httpHelper.MapAppErrorToHTTP(err)
+		httpHelper.Error(w, status, msg)
+		return
+	}
+
+	cashFlow, err := h.service.CashFlow(r.Context(), userID, query.Params())
+	if err != nil {
+		status, msg := httpHelper.MapAppErrorToHTTP(err)
+		httpHelper.Error(w, status, msg)
+		return
+	}
+
+	response := toCashFlowResponse(cashFlow)
+	if err := httpHelper.JSON(w, http.StatusOK, &response); err != nil {
+		log.Printf("httpHelper.JSON: %v", err)
+	}
+}
+
+func (h *handler) breakdown(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.UserIDFromContext(r.Context())
+	if !ok {
+		if err := httpHelper.Error(w, http.StatusInternalServerError, "internal server error"); err != nil {
+			log.Printf("httpHelper.Error: %v", err)
+		}
+		return
+	}
+
+	var query dto.BreakdownRequest
+	if err := httpHelper.DecodeQueryAndValidate(r, &query); err != nil {
+		status, msg := httpHelper.MapAppErrorToHTTP(err)
+		httpHelper.Error(w, status, msg)
+		return
+	}
+
+	params, err := query.Params()
+	if err != nil {
+		httpHelper.Error(w, http.StatusBadRequest, "invalid cursor")
+		return
+	}
+
+	breakdown, err := h.service.Breakdown(r.Context(), userID, params)
+	if err != nil {
+		status, msg := httpHelper.MapAppErrorToHTTP(err)
+		httpHelper.Error(w, status, msg)
+		return
+	}
+
+	response := toBreakdownResponse(breakdown)
+	if err := httpHelper.JSON(w, http.StatusOK, &response); err != nil {
+		log.Printf("httpHelper.JSON: %v", err)
+	}
+}
+
+func (h *handler) netWorth(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.UserIDFromContext(r.Context())
+	if !ok {
+		if err := httpHelper.Error(w, http.StatusInternalServerError, "internal server error"); err != nil {
+			log.Printf("httpHelper.Error: %v", err)
+		}
+		return
+	}
+
+	var query dto.NetWorthRequest
+	if err := httpHelper.DecodeQueryAndValidate(r, &query); err != nil {
+		status, msg := httpHelper.MapAppErrorToHTTP(err)
+		httpHelper.Error(w, status, msg)
+		return
+	}
+
+	netWorth, err := h.service.NetWorth(r.Context(), userID, query.Params())
+	if err != nil {
+		status, msg := httpHelper.MapAppErrorToHTTP(err)
+		httpHelper.Error(w, status, msg)
+		return
+	}
+
+	response := toNetWorthResponse(netWorth)
+	if err := httpHelper.JSON(w, http.StatusOK, &response); err != nil {
+		log.Printf("httpHelper.JSON: %v", err)
+	}
+}
+
+func toCashFlowResponse(c *transaction.CashFlow) dto.CashFlowResponse {
+	periods := make([]dto.PeriodResponse, 0, len(c.Periods))
+	for _, p := range c.Periods {
+		periods = append(periods, dto.PeriodResponse{
+			Start:   p.Start.Format(time.DateOnly),
+			Income:  p.Income,
+			Expense: p.Expense,
+			Net:     p.Net,
+		})
+	}
+
+	return dto.CashFlowResponse{
+		Interval: c.Interval,
+		Periods:  periods,
+		Income:   c.Income,
+		Expense:  c.Expense,
+		Net:      c.Net,
+	}
+}
+
+func toBreakdownResponse(b *report.Breakdown) dto.BreakdownResponse {
+	categories := make([]dto.CategoryShareResponse, 0, len(b.Categories))
+	for _, c := range b.Categories {
+		categories = append(categories, dto.CategoryShareResponse{
+			CategoryID:         c.CategoryID,
+			ParentID:           c.ParentID,
+			Name:               c.Name,
+			ComparisonResponse: toComparisonResponse(c.Comparison),
+		})
+	}
+
+	descriptions := make([]dto.DescriptionShareResponse, 0, len(b.Descriptions))
+	for _, d := range b.Descriptions {
+		descriptions = append(descriptions, dto.DescriptionShareResponse{
+			Description:        d.Description,
+			Count:              d.Count,
+			ComparisonResponse: toComparisonResponse(d.Comparison),
+		})
+	}
+
+	return dto.BreakdownResponse{
+		Type:         b.Type,
+		From:         b.From,
+		To:           b.To,
+		PreviousFrom: b.PreviousFrom,
+		Total:        toComparisonResponse(b.Total),
+		Categories:   categories,
+		Descriptions: descriptions,
+	}
+}
+
+func toNetWorthResponse(n *report.NetWorth) dto.NetWorthResponse {
+	points := make([]dto.NetWorthPointResponse, 0, len(n.Points))
+	for _, p := range n.Points {
+		points = append(points, dto.NetWorthPointResponse{
+			Start:       p.Start.Format(time.DateOnly),
+			AsOf:        p.AsOf,
+			Assets:      p.Assets,
+			Liabilities: p.Liabilities,
+			Net:         p.Net,
+		})
+	}
+
+	accounts := make([]dto.AccountWorthResponse, 0, len(n.Accounts))
+	for _, a := range n.Accounts {
+		accounts = append(accounts, dto.AccountWorthResponse{
+			AccountID: a.ID,
+			Name:      a.Name,
+			Kind:      a.Kind,
+			Balance:   a.Closing,
+		})
+	}
+
+	return dto.NetWorthResponse{
+		Interval: n.Interval,
+		Points:   points,
+		Accounts: accounts,
+	}
+}
+
+func toComparisonResponse(c report.Comparison) dto.ComparisonResponse {
+	return dto.ComparisonResponse{
+		Current:       c.Current,
+		Previous:      c.Previous,
+		Change:        c.Change,
+		ChangePercent: c.ChangePercent,
+		Share:         c.Share,
+	}
+}
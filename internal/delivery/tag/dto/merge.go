@@ -0,0 +1,14 @@
+package dto
+
+import (
+	"github.com/google/uuid"
+	"github.com/nontypeable/financial-tracker/internal/validator"
+)
+
+type MergeRequest struct {
+	Into uuid.UUID `json:"into" validate:"required"`
+}
+
+func (r *MergeRequest) Validate() error {
+	return validator.GetValidator().ValidateStruct(r)
+}
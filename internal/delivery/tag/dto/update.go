@@ -0,0 +1,11 @@
+package dto
+
+import "github.com/nontypeable/financial-tracker/internal/validator"
+
+type UpdateRequest struct {
+	Name string `json:"name" validate:"required,min=1,max=64"`
+}
+
+func (r *UpdateRequest) Validate() error {
+	return validator.GetValidator().ValidateStruct(r)
+}
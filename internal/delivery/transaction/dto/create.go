@@ -1,6 +1,8 @@
 package dto
 
 import (
+	"time"
+
 	"github.com/google/uuid"
 	"github.com/nontypeable/financial-tracker/internal/domain/transaction"
 	"github.com/nontypeable/financial-tracker/internal/validator"
@@ -8,16 +10,35 @@ import (
 )
 
 type CreateRequest struct {
-	AccountID   uuid.UUID                   `json:"account_id"`
+	AccountID   uuid.UUID                   `json:"account_id" validate:"required"`
 	Amount      decimal.Decimal             `json:"amount"`
-	Type        transaction.TransactionType `json:"type"`
-	Description string                      `json:"description"`
+	Type        transaction.TransactionType `json:"type" validate:"required,oneof=income expense"`
+	Description string                      `json:"description" validate:"max=1000"`
+	OccurredAt  *time.Time                  `json:"occurred_at"`
+	Status      transaction.Status          `json:"status" validate:"omitempty,oneof=pending cleared"`
+	CategoryID  *uuid.UUID                  `json:"category_id"`
+	Tags        []string                    `json:"tags" validate:"max=20,dive,min=1,max=64"`
+	Splits      []SplitRequest              `json:"splits" validate:"max=50,dive"`
 }
 
 func (r *CreateRequest) Validate() error {
 	return validator.GetValidator().ValidateStruct(r)
 }
 
+func (r *CreateRequest) Params() transaction.CreateParams {
+	return transaction.CreateParams{
+		AccountID:   r.AccountID,
+		Amount:      r.Amount,
+		Type:        r.Type,
+		Description: r.Description,
+		OccurredAt:  r.OccurredAt,
+		Status:      r.Status,
+		CategoryID:  r.CategoryID,
+		Tags:        r.Tags,
+		Splits:      toSplits(r.Splits),
+	}
+}
+
 type CreateResponse struct {
 	ID uuid.UUID `json:"id"`
 }
@@ -0,0 +1,65 @@
+package dto
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nontypeable/financial-tracker/internal/domain/transaction"
+	httpHelper "github.com/nontypeable/financial-tracker/internal/http"
+	"github.com/nontypeable/financial-tracker/internal/validator"
+)
+
+// DuplicatesRequest reads account_id and from from the query string; from
+// defaults to transaction.DuplicateLookback ago.
+type DuplicatesRequest struct {
+	AccountID *uuid.UUID
+	From      *time.Time
+}
+
+func (r *DuplicatesRequest) BindQuery(values url.Values) error {
+	if raw := values.Get("account_id"); raw != "" {
+		id, err := uuid.Parse(raw)
+		if err != nil {
+			return fmt.Errorf("account_id: %w", err)
+		}
+		r.AccountID = &id
+	}
+
+	from, err := httpHelper.QueryTime(values, "from", false)
+	if err != nil {
+		return err
+	}
+
+	r.From = from
+
+	return nil
+}
+
+func (r *DuplicatesRequest) Filter() transaction.DuplicateFilter {
+	return transaction.DuplicateFilter{
+		AccountID: r.AccountID,
+		From:      r.From,
+	}
+}
+
+type DuplicateResponse struct {
+	Original  GetResponse `json:"original"`
+	Duplicate GetResponse `json:"duplicate"`
+	Score     float64     `json:"score"`
+}
+
+type DuplicatesResponse struct {
+	Duplicates []DuplicateResponse `json:"duplicates"`
+}
+
+// MergeRequest names the duplicate to fold into the transaction addressed
+// by the URL.
+type MergeRequest struct {
+	DuplicateID uuid.UUID `json:"duplicate_id" validate:"required"`
+}
+
+func (r *MergeRequest) Validate() error {
+	return validator.GetValidator().ValidateStruct(r)
+}
@@ -0,0 +1,28 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nontypeable/financial-tracker/internal/domain/transaction"
+	"github.com/shopspring/decimal"
+)
+
+type GetResponse struct {
+	ID              uuid.UUID                   `json:"id"`
+	AccountID       uuid.UUID                   `json:"account_id"`
+	Amount          decimal.Decimal             `json:"amount"`
+	Type            transaction.TransactionType `json:"type"`
+	Description     string                      `json:"description"`
+	Status          transaction.Status          `json:"status"`
+	CategoryID      *uuid.UUID                  `json:"category_id,omitempty"`
+	TransferID      *uuid.UUID                  `json:"transfer_id,omitempty"`
+	RecurringRuleID *uuid.UUID                  `json:"recurring_rule_id,omitempty"`
+	ExternalID      *string                     `json:"external_id,omitempty"`
+	Tags            []string                    `json:"tags"`
+	Splits          []SplitResponse             `json:"splits,omitempty"`
+	OccurredAt      time.Time                   `json:"occurred_at"`
+	CreatedAt       time.Time                   `json:"created_at"`
+	UpdatedAt       time.Time                   `json:"updated_at"`
+	DeletedAt       *time.Time                  `json:"deleted_at,omitempty"`
+}
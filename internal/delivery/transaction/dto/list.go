@@ -0,0 +1,123 @@
+package dto
+
+import (
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nontypeable/financial-tracker/internal/domain/transaction"
+	httpHelper "github.com/nontypeable/financial-tracker/internal/http"
+	"github.com/nontypeable/financial-tracker/internal/validator"
+	"github.com/shopspring/decimal"
+)
+
+type ListRequest struct {
+	AccountIDs  []uuid.UUID
+	CategoryIDs []uuid.UUID
+	TagIDsAny   []uuid.UUID
+	TagIDsAll   []uuid.UUID
+	Type        string   `validate:"omitempty,oneof=income expense transfer"`
+	Statuses    []string `validate:"dive,oneof=pending cleared reconciled"`
+	From        *time.Time
+	To          *time.Time
+	AmountMin   *decimal.Decimal
+	AmountMax   *decimal.Decimal
+	Description string `validate:"max=255"`
+	SortBy      string `validate:"omitempty,oneof=date amount"`
+	Order       string `validate:"omitempty,oneof=asc desc"`
+	Cursor      string
+	Limit       int `validate:"min=0,max=200"`
+}
+
+func (r *ListRequest) BindQuery(values url.Values) error {
+	var err error
+
+	if r.AccountIDs, err = httpHelper.QueryUUIDs(values, "account_id"); err != nil {
+		return err
+	}
+	if r.CategoryIDs, err = httpHelper.QueryUUIDs(values, "category_id"); err != nil {
+		return err
+	}
+	if r.TagIDsAny, err = httpHelper.QueryUUIDs(values, "tag_any"); err != nil {
+		return err
+	}
+	if r.TagIDsAll, err = httpHelper.QueryUUIDs(values, "tag_all"); err != nil {
+		return err
+	}
+	if r.From, err = httpHelper.QueryTime(values, "from", false); err != nil {
+		return err
+	}
+	if r.To, err = httpHelper.QueryTime(values, "to", true); err != nil {
+		return err
+	}
+	if r.AmountMin, err = httpHelper.QueryDecimal(values, "amount_min"); err != nil {
+		return err
+	}
+	if r.AmountMax, err = httpHelper.QueryDecimal(values, "amount_max"); err != nil {
+		return err
+	}
+	if r.Limit, err = httpHelper.QueryInt(values, "limit"); err != nil {
+		return err
+	}
+
+	for _, raw := range values["status"] {
+		for _, part := range strings.Split(raw, ",") {
+			if part = strings.TrimSpace(part); part != "" {
+				r.Statuses = append(r.Statuses, part)
+			}
+		}
+	}
+
+	r.Type = values.Get("type")
+	r.Description = values.Get("description")
+	r.SortBy = values.Get("sort_by")
+	r.Order = values.Get("order")
+	r.Cursor = values.Get("cursor")
+
+	return nil
+}
+
+func (r *ListRequest) Validate() error {
+	return validator.GetValidator().ValidateStruct(r)
+}
+
+func (r *ListRequest) Filter() (transaction.Filter, error) {
+	filter := transaction.Filter{
+		AccountIDs:  r.AccountIDs,
+		CategoryIDs: r.CategoryIDs,
+		TagIDsAny:   r.TagIDsAny,
+		TagIDsAll:   r.TagIDsAll,
+		From:        r.From,
+		To:          r.To,
+		AmountMin:   r.AmountMin,
+		AmountMax:   r.AmountMax,
+		Description: r.Description,
+		SortBy:      transaction.SortField(r.SortBy),
+		Order:       transaction.SortOrder(r.Order),
+		Limit:       r.Limit,
+	}
+
+	for _, status := range r.Statuses {
+		filter.Statuses = append(filter.Statuses, transaction.Status(status))
+	}
+
+	if r.Type != "" {
+		transactionType := transaction.TransactionType(r.Type)
+		filter.Type = &transactionType
+	}
+
+	if r.Cursor != "" {
+		cursor, err := transaction.DecodeCursor(r.Cursor)
+		if err != nil {
+			return transaction.Filter{}, err
+		}
+		filter.Cursor = cursor
+	}
+
+	return filter, nil
+}
+
+type ListResponse struct {
+	Transactions []GetResponse `json:"transactions"`
+}
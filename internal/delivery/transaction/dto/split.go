@@ -0,0 +1,33 @@
+package dto
+
+import (
+	"github.com/google/uuid"
+	"github.com/nontypeable/financial-tracker/internal/domain/transaction"
+	"github.com/shopspring/decimal"
+)
+
+type SplitRequest struct {
+	CategoryID *uuid.UUID      `json:"category_id"`
+	Amount     decimal.Decimal `json:"amount"`
+	Memo       string          `json:"memo" validate:"max=1000"`
+}
+
+type SplitResponse struct {
+	ID         uuid.UUID       `json:"id"`
+	CategoryID *uuid.UUID      `json:"category_id,omitempty"`
+	Amount     decimal.Decimal `json:"amount"`
+	Memo       string          `json:"memo"`
+}
+
+func toSplits(requests []SplitRequest) []transaction.Split {
+	splits := make([]transaction.Split, 0, len(requests))
+	for _, r := range requests {
+		splits = append(splits, transaction.Split{
+			CategoryID: r.CategoryID,
+			Amount:     r.Amount,
+			Memo:       r.Memo,
+		})
+	}
+
+	return splits
+}
@@ -0,0 +1,15 @@
+package dto
+
+import "github.com/nontypeable/financial-tracker/internal/validator"
+
+type TagsRequest struct {
+	Tags []string `json:"tags" validate:"required,min=1,max=20,dive,min=1,max=64"`
+}
+
+func (r *TagsRequest) Validate() error {
+	return validator.GetValidator().ValidateStruct(r)
+}
+
+type BulkTagResponse struct {
+	Tagged int64 `json:"tagged"`
+}
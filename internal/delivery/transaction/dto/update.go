@@ -0,0 +1,52 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nontypeable/financial-tracker/internal/domain/transaction"
+	"github.com/nontypeable/financial-tracker/internal/validator"
+	"github.com/shopspring/decimal"
+)
+
+type UpdateRequest struct {
+	Amount        *decimal.Decimal `json:"amount"`
+	Type          *string          `json:"type" validate:"omitempty,oneof=income expense"`
+	Description   *string          `json:"description" validate:"omitempty,max=1000"`
+	OccurredAt    *time.Time       `json:"occurred_at"`
+	Status        *string          `json:"status" validate:"omitempty,oneof=pending cleared"`
+	CategoryID    *uuid.UUID       `json:"category_id"`
+	ClearCategory bool             `json:"clear_category"`
+	Splits        *[]SplitRequest  `json:"splits" validate:"omitempty,max=50,dive"`
+}
+
+func (r *UpdateRequest) Validate() error {
+	return validator.GetValidator().ValidateStruct(r)
+}
+
+func (r *UpdateRequest) Params() transaction.UpdateParams {
+	params := transaction.UpdateParams{
+		Amount:        r.Amount,
+		Description:   r.Description,
+		OccurredAt:    r.OccurredAt,
+		CategoryID:    r.CategoryID,
+		ClearCategory: r.ClearCategory,
+	}
+
+	if r.Status != nil {
+		status := transaction.Status(*r.Status)
+		params.Status = &status
+	}
+
+	if r.Type != nil {
+		transactionType := transaction.TransactionType(*r.Type)
+		params.Type = &transactionType
+	}
+
+	if r.Splits != nil {
+		splits := toSplits(*r.Splits)
+		params.Splits = &splits
+	}
+
+	return params
+}
@@ -5,6 +5,8 @@ import (
 	"net/http"
 
 	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/nontypeable/financial-tracker/internal/auth"
 	"github.com/nontypeable/financial-tracker/internal/delivery/transaction/dto"
 	"github.com/nontypeable/financial-tracker/internal/domain/transaction"
 	httpHelper "github.com/nontypeable/financial-tracker/internal/http"
@@ -26,11 +28,30 @@ func (h *handler) RegisterRoutes(r chi.Router, authMiddleware func(http.Handler)
 			r.Use(authMiddleware)
 
 			r.Post("/", h.create)
+			r.Get("/", h.list)
+			r.Get("/trash", h.listDeleted)
+			r.Post("/tags", h.bulkTag)
+			r.Get("/duplicates", h.listDuplicates)
+			r.Get("/{id}", h.get)
+			r.Patch("/{id}", h.update)
+			r.Delete("/{id}", h.delete)
+			r.Post("/{id}/restore", h.restore)
+			r.Post("/{id}/merge", h.merge)
+			r.Post("/{id}/tags", h.addTags)
+			r.Delete("/{id}/tags/{tagID}", h.removeTag)
 		})
 	})
 }
 
 func (h *handler) create(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.UserIDFromContext(r.Context())
+	if !ok {
+		if err := httpHelper.Error(w, http.StatusInternalServerError, "internal server error"); err != nil {
+			log.Printf("httpHelper.Error: %v", err)
+		}
+		return
+	}
+
 	var payload dto.CreateRequest
 	if err := httpHelper.DecodeAndValidate(r, &payload); err != nil {
 		status, msg := httpHelper.MapAppErrorToHTTP(err)
@@ -38,7 +59,7 @@ func (h *handler) create(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	id, err := h.service.Create(r.Context(), payload.AccountID, payload.Amount, payload.Type, payload.Description)
+	id, err := h.service.Create(r.Context(), userID, payload.Params())
 	if err != nil {
 		status, msg := httpHelper.MapAppErrorToHTTP(err)
 		httpHelper.Error(w, status, msg)
@@ -49,3 +70,399 @@ func (h *handler) create(w http.ResponseWriter, r *http.Request) {
 		log.Printf("httpHelper.JSON: %v", err)
 	}
 }
+
+func (h *handler) list(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.UserIDFromContext(r.Context())
+	if !ok {
+		if err := httpHelper.Error(w, http.StatusInternalServerError, "internal server error"); err != nil {
+			log.Printf("httpHelper.Error: %v", err)
+		}
+		return
+	}
+
+	var payload dto.ListRequest
+	if err := httpHelper.DecodeQueryAndValidate(r, &payload); err != nil {
+		status, msg := httpHelper.MapAppErrorToHTTP(err)
+		httpHelper.Error(w, status, msg)
+		return
+	}
+
+	filter, err := payload.Filter()
+	if err != nil {
+		httpHelper.Error(w, http.StatusBadRequest, "invalid cursor")
+		return
+	}
+
+	transactions, next, err := h.service.List(r.Context(), userID, filter)
+	if err != nil {
+		status, msg := httpHelper.MapAppErrorToHTTP(err)
+		httpHelper.Error(w, status, msg)
+		return
+	}
+
+	response := dto.ListResponse{Transactions: make([]dto.GetResponse, 0, len(transactions))}
+	for _, t := range transactions {
+		response.Transactions = append(response.Transactions, toGetResponse(t))
+	}
+
+	var nextCursor string
+	if next != nil {
+		nextCursor = next.Encode()
+	}
+
+	if err := httpHelper.Paginated(w, http.StatusOK, &response, nextCursor); err != nil {
+		log.Printf("httpHelper.Paginated: %v", err)
+	}
+}
+
+func (h *handler) get(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.UserIDFromContext(r.Context())
+	if !ok {
+		if err := httpHelper.Error(w, http.StatusInternalServerError, "internal server error"); err != nil {
+			log.Printf("httpHelper.Error: %v", err)
+		}
+		return
+	}
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		httpHelper.Error(w, http.StatusBadRequest, "invalid transaction ID")
+		return
+	}
+
+	transaction, err := h.service.GetByID(r.Context(), userID, id)
+	if err != nil {
+		status, msg := httpHelper.MapAppErrorToHTTP(err)
+		httpHelper.Error(w, status, msg)
+		return
+	}
+
+	response := toGetResponse(transaction)
+	if err := httpHelper.JSON(w, http.StatusOK, &response); err != nil {
+		log.Printf("httpHelper.JSON: %v", err)
+	}
+}
+
+func (h *handler) update(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.UserIDFromContext(r.Context())
+	if !ok {
+		if err := httpHelper.Error(w, http.StatusInternalServerError, "internal server error"); err != nil {
+			log.Printf("httpHelper.Error: %v", err)
+		}
+		return
+	}
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		httpHelper.Error(w, http.StatusBadRequest, "invalid transaction ID")
+		return
+	}
+
+	var payload dto.UpdateRequest
+	if err := httpHelper.DecodeAndValidate(r, &payload); err != nil {
+		status, msg := httpHelper.MapAppErrorToHTTP(err)
+		httpHelper.Error(w, status, msg)
+		return
+	}
+
+	if err := h.service.Update(r.Context(), userID, id, payload.Params()); err != nil {
+		status, msg := httpHelper.MapAppErrorToHTTP(err)
+		httpHelper.Error(w, status, msg)
+		return
+	}
+
+	if err := httpHelper.JSON(w, http.StatusOK, nil); err != nil {
+		log.Printf("httpHelper.JSON: %v", err)
+	}
+}
+
+func (h *handler) delete(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.UserIDFromContext(r.Context())
+	if !ok {
+		if err := httpHelper.Error(w, http.StatusInternalServerError, "internal server error"); err != nil {
+			log.Printf("httpHelper.Error: %v", err)
+		}
+		return
+	}
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		httpHelper.Error(w, http.StatusBadRequest, "invalid transaction ID")
+		return
+	}
+
+	if err := h.service.Delete(r.Context(), userID, id); err != nil {
+		status, msg := httpHelper.MapAppErrorToHTTP(err)
+		httpHelper.Error(w, status, msg)
+		return
+	}
+
+	if err := httpHelper.JSON(w, http.StatusOK, nil); err != nil {
+		log.Printf("httpHelper.JSON: %v", err)
+	}
+}
+
+func (h *handler) restore(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.UserIDFromContext(r.Context())
+	if !ok {
+		if err := httpHelper.Error(w, http.StatusInternalServerError, "internal server error"); err != nil {
+			log.Printf("httpHelper.Error: %v", err)
+		}
+		return
+	}
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		httpHelper.Error(w, http.StatusBadRequest, "invalid transaction ID")
+		return
+	}
+
+	if err := h.service.Restore(r.Context(), userID, id); err != nil {
+		status, msg := httpHelper.MapAppErrorToHTTP(err)
+		httpHelper.Error(w, status, msg)
+		return
+	}
+
+	if err := httpHelper.JSON(w, http.StatusOK, nil); err != nil {
+		log.Printf("httpHelper.JSON: %v", err)
+	}
+}
+
+func (h *handler) listDeleted(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.UserIDFromContext(r.Context())
+	if !ok {
+		if err := httpHelper.Error(w, http.StatusInternalServerError, "internal server error"); err != nil {
+			log.Printf("httpHelper.Error: %v", err)
+		}
+		return
+	}
+
+	transactions, err := h.service.ListDeleted(r.Context(), userID)
+	if err != nil {
+		status, msg := httpHelper.MapAppErrorToHTTP(err)
+		httpHelper.Error(w, status, msg)
+		return
+	}
+
+	response := dto.ListResponse{Transactions: make([]dto.GetResponse, 0, len(transactions))}
+	for _, t := range transactions {
+		response.Transactions = append(response.Transactions, toGetResponse(t))
+	}
+
+	if err := httpHelper.JSON(w, http.StatusOK, &response); err != nil {
+		log.Printf("httpHelper.JSON: %v", err)
+	}
+}
+
+func (h *handler) addTags(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.UserIDFromContext(r.Context())
+	if !ok {
+		if err := httpHelper.Error(w, http.StatusInternalServerError, "internal server error"); err != nil {
+			log.Printf("httpHelper.Error: %v", err)
+		}
+		return
+	}
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		httpHelper.Error(w, http.StatusBadRequest, "invalid transaction ID")
+		return
+	}
+
+	var payload dto.TagsRequest
+	if err := httpHelper.DecodeAndValidate(r, &payload); err != nil {
+		status, msg := httpHelper.MapAppErrorToHTTP(err)
+		httpHelper.Error(w, status, msg)
+		return
+	}
+
+	if err := h.service.AddTags(r.Context(), userID, id, payload.Tags); err != nil {
+		status, msg := httpHelper.MapAppErrorToHTTP(err)
+		httpHelper.Error(w, status, msg)
+		return
+	}
+
+	if err := httpHelper.JSON(w, http.StatusOK, nil); err != nil {
+		log.Printf("httpHelper.JSON: %v", err)
+	}
+}
+
+func (h *handler) removeTag(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.UserIDFromContext(r.Context())
+	if !ok {
+		if err := httpHelper.Error(w, http.StatusInternalServerError, "internal server error"); err != nil {
+			log.Printf("httpHelper.Error: %v", err)
+		}
+		return
+	}
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		httpHelper.Error(w, http.StatusBadRequest, "invalid transaction ID")
+		return
+	}
+
+	tagID, err := uuid.Parse(chi.URLParam(r, "tagID"))
+	if err != nil {
+		httpHelper.Error(w, http.StatusBadRequest, "invalid tag ID")
+		return
+	}
+
+	if err := h.service.RemoveTag(r.Context(), userID, id, tagID); err != nil {
+		status, msg := httpHelper.MapAppErrorToHTTP(err)
+		httpHelper.Error(w, status, msg)
+		return
+	}
+
+	if err := httpHelper.JSON(w, http.StatusOK, nil); err != nil {
+		log.Printf("httpHelper.JSON: %v", err)
+	}
+}
+
+// bulkTag tags every transaction matching the listing filters given in the
+// query string with the tags from the request body.
+func (h *handler) bulkTag(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.UserIDFromContext(r.Context())
+	if !ok {
+		if err := httpHelper.Error(w, http.StatusInternalServerError, "internal server error"); err != nil {
+			log.Printf("httpHelper.Error: %v", err)
+		}
+		return
+	}
+
+	var query dto.ListRequest
+	if err := httpHelper.DecodeQueryAndValidate(r, &query); err != nil {
+		status, msg := httpHelper.MapAppErrorToHTTP(err)
+		httpHelper.Error(w, status, msg)
+		return
+	}
+
+	filter, err := query.Filter()
+	if err != nil {
+		httpHelper.Error(w, http.StatusBadRequest, "invalid cursor")
+		return
+	}
+
+	var payload dto.TagsRequest
+	if err := httpHelper.DecodeAndValidate(r, &payload); err != nil {
+		status, msg := httpHelper.MapAppErrorToHTTP(err)
+		httpHelper.Error(w, status, msg)
+		return
+	}
+
+	tagged, err := h.service.BulkTag(r.Context(), userID, filter, payload.Tags)
+	if err != nil {
+		status, msg := httpHelper.MapAppErrorToHTTP(err)
+		httpHelper.Error(w, status, msg)
+		return
+	}
+
+	if err := httpHelper.JSON(w, http.StatusOK, &dto.BulkTagResponse{Tagged: tagged}); err != nil {
+		log.Printf("httpHelper.JSON: %v", err)
+	}
+}
+
+func (h *handler) listDuplicates(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.UserIDFromContext(r.Context())
+	if !ok {
+		if err := httpHelper.Error(w, http.StatusInternalServerError, "internal server error"); err != nil {
+			log.Printf("httpHelper.Error: %v", err)
+		}
+		return
+	}
+
+	var query dto.DuplicatesRequest
+	if err := httpHelper.DecodeQueryAndValidate(r, &query); err != nil {
+		status, msg := httpHelper.MapAppErrorToHTTP(err)
+		httpHelper.Error(w, status, msg)
+		return
+	}
+
+	pairs, err := h.service.ListDuplicates(r.Context(), userID, query.Filter())
+	if err != nil {
+		status, msg := httpHelper.MapAppErrorToHTTP(err)
+		httpHelper.Error(w, status, msg)
+		return
+	}
+
+	response := dto.DuplicatesResponse{Duplicates: make([]dto.DuplicateResponse, 0, len(pairs))}
+	for _, pair := range pairs {
+		response.Duplicates = append(response.Duplicates, dto.DuplicateResponse{
+			Original:  toGetResponse(pair.Original),
+			Duplicate: toGetResponse(pair.Duplicate),
+			Score:     pair.Score,
+		})
+	}
+
+	if err := httpHelper.JSON(w, http.StatusOK, &response); err != nil {
+		log.Printf("httpHelper.JSON: %v", err)
+	}
+}
+
+// merge folds the duplicate named in the body into the transaction from the
+// URL and returns the merged transaction.
+func (h *handler) merge(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.UserIDFromContext(r.Context())
+	if !ok {
+		if err := httpHelper.Error(w, http.StatusInternalServerError, "internal server error"); err != nil {
+			log.Printf("httpHelper.Error: %v", err)
+		}
+		return
+	}
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		httpHelper.Error(w, http.StatusBadRequest, "invalid transaction ID")
+		return
+	}
+
+	var payload dto.MergeRequest
+	if err := httpHelper.DecodeAndValidate(r, &payload); err != nil {
+		status, msg := httpHelper.MapAppErrorToHTTP(err)
+		httpHelper.Error(w, status, msg)
+		return
+	}
+
+	merged, err := h.service.Merge(r.Context(), userID, id, payload.DuplicateID)
+	if err != nil {
+		status, msg := httpHelper.MapAppErrorToHTTP(err)
+		httpHelper.Error(w, status, msg)
+		return
+	}
+
+	response := toGetResponse(merged)
+	if err := httpHelper.JSON(w, http.StatusOK, &response); err != nil {
+		log.Printf("httpHelper.JSON: %v", err)
+	}
+}
+
+func toGetResponse(t *transaction.Transaction) dto.GetResponse {
+	var splits []dto.SplitResponse
+	for _, split := range t.Splits {
+		splits = append(splits, dto.SplitResponse{
+			ID:         split.ID,
+			CategoryID: split.CategoryID,
+			Amount:     split.Amount,
+			Memo:       split.Memo,
+		})
+	}
+
+	return dto.GetResponse{
+		ID:              t.ID,
+		AccountID:       t.AccountID,
+		Amount:          t.Amount,
+		Type:            t.Type,
+		Description:     t.Description,
+		Status:          t.Status,
+		CategoryID:      t.CategoryID,
+		TransferID:      t.TransferID,
+		RecurringRuleID: t.RecurringRuleID,
+		ExternalID:      t.ExternalID,
+		Tags:            t.Tags,
+		Splits:          splits,
+		OccurredAt:      t.OccurredAt,
+		CreatedAt:       t.CreatedAt,
+		UpdatedAt:       t.UpdatedAt,
+		DeletedAt:       t.DeletedAt,
+	}
+}
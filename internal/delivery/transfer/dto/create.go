@@ -0,0 +1,39 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nontypeable/financial-tracker/internal/domain/transaction"
+	"github.com/nontypeable/financial-tracker/internal/domain/transfer"
+	"github.com/nontypeable/financial-tracker/internal/validator"
+	"github.com/shopspring/decimal"
+)
+
+type CreateRequest struct {
+	FromAccountID uuid.UUID          `json:"from_account_id" validate:"required"`
+	ToAccountID   uuid.UUID          `json:"to_account_id" validate:"required,nefield=FromAccountID"`
+	Amount        decimal.Decimal    `json:"amount"`
+	Description   string             `json:"description" validate:"max=1000"`
+	OccurredAt    *time.Time         `json:"occurred_at"`
+	Status        transaction.Status `json:"status" validate:"omitempty,oneof=pending cleared"`
+}
+
+func (r *CreateRequest) Validate() error {
+	return validator.GetValidator().ValidateStruct(r)
+}
+
+func (r *CreateRequest) Params() transfer.CreateParams {
+	return transfer.CreateParams{
+		FromAccountID: r.FromAccountID,
+		ToAccountID:   r.ToAccountID,
+		Amount:        r.Amount,
+		Description:   r.Description,
+		OccurredAt:    r.OccurredAt,
+		Status:        r.Status,
+	}
+}
+
+type CreateResponse struct {
+	ID uuid.UUID `json:"id"`
+}
@@ -0,0 +1,19 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+type GetResponse struct {
+	ID                    uuid.UUID       `json:"id"`
+	FromAccountID         uuid.UUID       `json:"from_account_id"`
+	ToAccountID           uuid.UUID       `json:"to_account_id"`
+	OutgoingTransactionID uuid.UUID       `json:"outgoing_transaction_id"`
+	IncomingTransactionID uuid.UUID       `json:"incoming_transaction_id"`
+	Amount                decimal.Decimal `json:"amount"`
+	Description           string          `json:"description"`
+	CreatedAt             time.Time       `json:"created_at"`
+}
@@ -7,21 +7,37 @@ import (
 	"github.com/shopspring/decimal"
 )
 
+// Kind tells what an account's balance stands for. A liability, such as a
+// credit card or a loan, is usually negative: it is the amount owed.
+type Kind string
+
+const (
+	Asset     Kind = "asset"
+	Liability Kind = "liability"
+)
+
 type Account struct {
-	ID        uuid.UUID       `db:"id"`
-	UserID    uuid.UUID       `db:"user_id"`
-	Name      string          `db:"name"`
-	Balance   decimal.Decimal `db:"balance"`
-	CreatedAt time.Time       `db:"created_at"`
-	UpdatedAt time.Time       `db:"updated_at"`
-	DeletedAt *time.Time      `db:"deleted_at"`
+	ID             uuid.UUID       `db:"id"`
+	UserID         uuid.UUID       `db:"user_id"`
+	Name           string          `db:"name"`
+	Kind           Kind            `db:"kind"`
+	Balance        decimal.Decimal `db:"balance"`
+	OpeningBalance decimal.Decimal `db:"opening_balance"`
+	// ClearedBalance counts only cleared and reconciled transactions; Balance
+	// is the working balance including pending ones. It is computed on read.
+	ClearedBalance decimal.Decimal `db:"-"`
+	CreatedAt      time.Time       `db:"created_at"`
+	UpdatedAt      time.Time       `db:"updated_at"`
+	DeletedAt      *time.Time      `db:"deleted_at"`
 }
 
-func NewAccount(userID uuid.UUID, name string, balance decimal.Decimal) *Account {
+func NewAccount(userID uuid.UUID, name string, kind Kind, balance decimal.Decimal) *Account {
 	return &Account{
-		UserID:  userID,
-		Name:    name,
-		Balance: balance,
+		UserID:         userID,
+		Name:           name,
+		Kind:           kind,
+		Balance:        balance,
+		OpeningBalance: balance,
 	}
 }
 
@@ -29,6 +45,10 @@ func (a *Account) BelongsUser(userID uuid.UUID) bool {
 	return a.UserID == userID
 }
 
+func (a *Account) Apply(delta decimal.Decimal) {
+	a.Balance = a.Balance.Add(delta)
+}
+
 func (a *Account) Delete() {
 	now := time.Now()
 	a.DeletedAt = &now
@@ -9,6 +9,10 @@ import (
 type Repository interface {
 	Create(ctx context.Context, account *Account) (uuid.UUID, error)
 	GetByID(ctx context.Context, id uuid.UUID) (*Account, error)
+	GetByIDForUpdate(ctx context.Context, id uuid.UUID) (*Account, error)
+	// GetByIDsForUpdate locks the accounts in ID order so that callers
+	// touching several accounts at once cannot deadlock each other.
+	GetByIDsForUpdate(ctx context.Context, ids []uuid.UUID) ([]*Account, error)
 	GetByUserID(ctx context.Context, userID uuid.UUID) ([]*Account, error)
 	Update(ctx context.Context, account *Account) error
 	Delete(ctx context.Context, userID, id uuid.UUID) error
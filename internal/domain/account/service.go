@@ -2,11 +2,20 @@ package account
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/shopspring/decimal"
 )
 
 type Service interface {
-	Create(ctx context.Context, userID uuid.UUID, name string, balance decimal.Decimal) (uuid.UUID, error)
+	Create(ctx context.Context, userID uuid.UUID, name string, kind Kind, balance decimal.Decimal) (uuid.UUID, error)
+	GetByID(ctx context.Context, userID, id uuid.UUID) (*Account, error)
+	List(ctx context.Context, userID uuid.UUID) ([]*Account, error)
+	Update(ctx context.Context, userID, id uuid.UUID, name string, kind Kind) error
+	Delete(ctx context.Context, userID, id uuid.UUID) error
+	Recalculate(ctx context.Context, userID, id uuid.UUID) (*Account, error)
+	// BalanceAsOf returns the balance including only transactions that
+	// occurred before asOf.
+	BalanceAsOf(ctx context.Context, userID, id uuid.UUID, asOf time.Time) (decimal.Decimal, error)
 }
@@ -0,0 +1,120 @@
+package budget
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// Budget caps the spending of one expense category in one calendar month.
+// Month is always the first day of the month. A budget set on a category
+// also covers its subcategories, except those with a budget of their own.
+type Budget struct {
+	ID         uuid.UUID       `db:"id"`
+	UserID     uuid.UUID       `db:"user_id"`
+	CategoryID uuid.UUID       `db:"category_id"`
+	Month      time.Time       `db:"month"`
+	Amount     decimal.Decimal `db:"amount"`
+	// Rollover carries what is left unspent at the end of the month over to
+	// the category's budget for the next month.
+	Rollover  bool      `db:"rollover"`
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+func NewBudget(userID, categoryID uuid.UUID, month time.Time, amount decimal.Decimal, rollover bool) *Budget {
+	return &Budget{
+		UserID:     userID,
+		CategoryID: categoryID,
+		Month:      MonthOf(month),
+		Amount:     amount,
+		Rollover:   rollover,
+	}
+}
+
+func (b *Budget) BelongsUser(userID uuid.UUID) bool {
+	return b.UserID == userID
+}
+
+// MonthOf returns the first day of the month t falls in.
+func MonthOf(t time.Time) time.Time {
+	year, month, _ := t.Date()
+	return time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+}
+
+// Actual is a budget with the expenses booked against it in its month.
+type Actual struct {
+	*Budget
+	Spent decimal.Decimal
+}
+
+// Progress is a budget measured against its month's spending. Available is
+// the amount plus what rolled over from the previous month; Remaining is
+// negative once the budget is overspent.
+type Progress struct {
+	*Budget
+	Carryover decimal.Decimal
+	Available decimal.Decimal
+	Spent     decimal.Decimal
+	Remaining decimal.Decimal
+}
+
+// Summary is the budget-vs-actual view of one month.
+type Summary struct {
+	Month     time.Time
+	Budgets   []*Progress
+	Budgeted  decimal.Decimal
+	Available decimal.Decimal
+	Spent     decimal.Decimal
+	Remaining decimal.Decimal
+}
+
+// Summarize walks the budgets of each category month by month and returns
+// the progress of those set for the given month. A month's positive
+// remainder is carried over only when its budget has rollover enabled and
+// the category is budgeted in the month right after it. Actuals must be
+// ordered by category and month.
+func Summarize(month time.Time, actuals []*Actual) *Summary {
+	month = MonthOf(month)
+
+	summary := &Summary{
+		Month:     month,
+		Budgets:   []*Progress{},
+		Budgeted:  decimal.Zero,
+		Available: decimal.Zero,
+		Spent:     decimal.Zero,
+		Remaining: decimal.Zero,
+	}
+
+	var previous *Progress
+	for _, actual := range actuals {
+		carryover := decimal.Zero
+		if previous != nil && previous.Rollover && previous.CategoryID == actual.CategoryID &&
+			previous.Month.AddDate(0, 1, 0).Equal(actual.Month) && previous.Remaining.IsPositive() {
+			carryover = previous.Remaining
+		}
+
+		available := actual.Amount.Add(carryover)
+		progress := &Progress{
+			Budget:    actual.Budget,
+			Carryover: carryover,
+			Available: available,
+			Spent:     actual.Spent,
+			Remaining: available.Sub(actual.Spent),
+		}
+		previous = progress
+
+		if !actual.Month.Equal(month) {
+			continue
+		}
+
+		summary.Budgets = append(summary.Budgets, progress)
+		summary.Budgeted = summary.Budgeted.Add(progress.Amount)
+		summary.Available = summary.Available.Add(progress.Available)
+		summary.Spent = summary.Spent.Add(progress.Spent)
+		summary.Remaining = summary.Remaining.Add(progress.Remaining)
+	}
+
+	return summary
+}
@@ -0,0 +1,122 @@
+package budget
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// Assignment is one entry of the envelope ledger: money given to an
+// expense category's envelope from the to-be-assigned pool, or returned to
+// it when negative. A move between envelopes is recorded as two entries
+// sharing a MoveID.
+//
+// Envelopes start in the month of their first assignment and the pool in
+// the month of the user's first assignment. Balances are cumulative, so
+// an overspent envelope carries its negative balance into the next month.
+type Assignment struct {
+	ID         uuid.UUID       `db:"id"`
+	UserID     uuid.UUID       `db:"user_id"`
+	CategoryID uuid.UUID       `db:"category_id"`
+	Month      time.Time       `db:"month"`
+	Amount     decimal.Decimal `db:"amount"`
+	MoveID     *uuid.UUID      `db:"move_id"`
+	Note       string          `db:"note"`
+	CreatedAt  time.Time       `db:"created_at"`
+}
+
+func NewAssignment(userID, categoryID uuid.UUID, month time.Time, amount decimal.Decimal, note string) *Assignment {
+	return &Assignment{
+		UserID:     userID,
+		CategoryID: categoryID,
+		Month:      MonthOf(month),
+		Amount:     amount,
+		Note:       note,
+	}
+}
+
+// NewMove takes amount out of one envelope and puts it into another.
+func NewMove(userID, fromCategoryID, toCategoryID uuid.UUID, month time.Time, amount decimal.Decimal, note string) (from, to *Assignment) {
+	moveID := uuid.New()
+
+	from = NewAssignment(userID, fromCategoryID, month, amount.Neg(), note)
+	from.MoveID = &moveID
+
+	to = NewAssignment(userID, toCategoryID, month, amount, note)
+	to.MoveID = &moveID
+
+	return from, to
+}
+
+func (a *Assignment) BelongsUser(userID uuid.UUID) bool {
+	return a.UserID == userID
+}
+
+// EnvelopeActivity is what was assigned to and spent from one envelope
+// before a month and during it.
+type EnvelopeActivity struct {
+	CategoryID     uuid.UUID
+	AssignedBefore decimal.Decimal
+	Assigned       decimal.Decimal
+	SpentBefore    decimal.Decimal
+	Spent          decimal.Decimal
+}
+
+// PoolActivity is the income received and the money assigned out of the
+// pool before a month and during it.
+type PoolActivity struct {
+	IncomeBefore   decimal.Decimal
+	Income         decimal.Decimal
+	AssignedBefore decimal.Decimal
+	Assigned       decimal.Decimal
+}
+
+// Envelope is the state of one envelope in a month. Carryover is the
+// balance brought in from the month before, negative when it was overspent.
+type Envelope struct {
+	CategoryID uuid.UUID
+	Carryover  decimal.Decimal
+	Assigned   decimal.Decimal
+	Spent      decimal.Decimal
+	Balance    decimal.Decimal
+}
+
+// EnvelopeSummary is the zero-based budget of one month. ReadyToAssign is
+// the income not yet given to any envelope; it is negative when more was
+// assigned than received.
+type EnvelopeSummary struct {
+	Month         time.Time
+	Carryover     decimal.Decimal
+	Income        decimal.Decimal
+	Assigned      decimal.Decimal
+	ReadyToAssign decimal.Decimal
+	Envelopes     []*Envelope
+}
+
+func SummarizeEnvelopes(month time.Time, pool *PoolActivity, activity []*EnvelopeActivity) *EnvelopeSummary {
+	carryover := pool.IncomeBefore.Sub(pool.AssignedBefore)
+
+	summary := &EnvelopeSummary{
+		Month:         MonthOf(month),
+		Carryover:     carryover,
+		Income:        pool.Income,
+		Assigned:      pool.Assigned,
+		ReadyToAssign: carryover.Add(pool.Income).Sub(pool.Assigned),
+		Envelopes:     make([]*Envelope, 0, len(activity)),
+	}
+
+	for _, a := range activity {
+		carryover := a.AssignedBefore.Sub(a.SpentBefore)
+
+		summary.Envelopes = append(summary.Envelopes, &Envelope{
+			CategoryID: a.CategoryID,
+			Carryover:  carryover,
+			Assigned:   a.Assigned,
+			Spent:      a.Spent,
+			Balance:    carryover.Add(a.Assigned).Sub(a.Spent),
+		})
+	}
+
+	return summary
+}
@@ -0,0 +1,41 @@
+package budget
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Repository interface {
+	Create(ctx context.Context, budget *Budget) (uuid.UUID, error)
+	GetByID(ctx context.Context, id uuid.UUID) (*Budget, error)
+	Update(ctx context.Context, budget *Budget) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	// GetActuals returns every budget, from the given month back, of the
+	// categories the user has budgeted in that month, each with its month's
+	// expenses summed in the category and the subcategories it covers.
+	// Budgets are ordered by category and month.
+	GetActuals(ctx context.Context, userID uuid.UUID, month time.Time) ([]*Actual, error)
+	// CopyMonth copies the user's budgets of one month into another,
+	// skipping archived categories and those already budgeted there, and
+	// returns how many budgets were created.
+	CopyMonth(ctx context.Context, userID uuid.UUID, from, to time.Time) (int64, error)
+
+	CreateAssignment(ctx context.Context, assignment *Assignment) (uuid.UUID, error)
+	GetAssignmentByID(ctx context.Context, id uuid.UUID) (*Assignment, error)
+	// GetAssignments lists the user's envelope ledger for a month in the
+	// order it was written.
+	GetAssignments(ctx context.Context, userID uuid.UUID, month time.Time) ([]*Assignment, error)
+	// DeleteAssignment removes a ledger entry together with the other half
+	// of its move, if it is one.
+	DeleteAssignment(ctx context.Context, id uuid.UUID) error
+	// GetEnvelopeActivity returns, for every envelope started by the given
+	// month, what was assigned to it and what was spent in its category and
+	// the subcategories without an envelope of their own. Spending is
+	// counted from the envelope's first month.
+	GetEnvelopeActivity(ctx context.Context, userID uuid.UUID, month time.Time) ([]*EnvelopeActivity, error)
+	// GetPoolActivity nets the user's income and assignments from the first
+	// month with an assignment, or the given month when there is none yet.
+	GetPoolActivity(ctx context.Context, userID uuid.UUID, month time.Time) (*PoolActivity, error)
+}
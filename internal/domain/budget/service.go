@@ -0,0 +1,57 @@
+package budget
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+type CreateParams struct {
+	CategoryID uuid.UUID
+	Month      time.Time
+	Amount     decimal.Decimal
+	Rollover   bool
+}
+
+type UpdateParams struct {
+	Amount   *decimal.Decimal
+	Rollover *bool
+}
+
+type AssignParams struct {
+	CategoryID uuid.UUID
+	Month      time.Time
+	Amount     decimal.Decimal
+	Note       string
+}
+
+type MoveParams struct {
+	FromCategoryID uuid.UUID
+	ToCategoryID   uuid.UUID
+	Month          time.Time
+	Amount         decimal.Decimal
+	Note           string
+}
+
+type Service interface {
+	Create(ctx context.Context, userID uuid.UUID, params CreateParams) (uuid.UUID, error)
+	GetByID(ctx context.Context, userID, id uuid.UUID) (*Budget, error)
+	Update(ctx context.Context, userID, id uuid.UUID, params UpdateParams) error
+	Delete(ctx context.Context, userID, id uuid.UUID) error
+	// Summary compares the budgets of a month with what was spent.
+	Summary(ctx context.Context, userID uuid.UUID, month time.Time) (*Summary, error)
+	// CopyFromPrevious copies last month's budgets into the given month and
+	// returns how many were created. Categories already budgeted are kept.
+	CopyFromPrevious(ctx context.Context, userID uuid.UUID, month time.Time) (int64, error)
+
+	// Assign gives money from the to-be-assigned pool to an envelope, or
+	// returns it to the pool when the amount is negative.
+	Assign(ctx context.Context, userID uuid.UUID, params AssignParams) (uuid.UUID, error)
+	// Move takes money out of one envelope and puts it into another.
+	Move(ctx context.Context, userID uuid.UUID, params MoveParams) error
+	DeleteAssignment(ctx context.Context, userID, id uuid.UUID) error
+	Envelopes(ctx context.Context, userID uuid.UUID, month time.Time) (*EnvelopeSummary, error)
+	Ledger(ctx context.Context, userID uuid.UUID, month time.Time) ([]*Assignment, error)
+}
@@ -0,0 +1,114 @@
+package category
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type CategoryType string
+
+const (
+	Income  CategoryType = "income"
+	Expense CategoryType = "expense"
+)
+
+type Category struct {
+	ID         uuid.UUID    `db:"id"`
+	UserID     uuid.UUID    `db:"user_id"`
+	ParentID   *uuid.UUID   `db:"parent_id"`
+	Name       string       `db:"name"`
+	Type       CategoryType `db:"type"`
+	ArchivedAt *time.Time   `db:"archived_at"`
+	CreatedAt  time.Time    `db:"created_at"`
+	UpdatedAt  time.Time    `db:"updated_at"`
+	DeletedAt  *time.Time   `db:"deleted_at"`
+}
+
+func NewCategory(userID uuid.UUID, parentID *uuid.UUID, name string, categoryType CategoryType) *Category {
+	return &Category{
+		UserID:   userID,
+		ParentID: parentID,
+		Name:     name,
+		Type:     categoryType,
+	}
+}
+
+func (c *Category) BelongsUser(userID uuid.UUID) bool {
+	return c.UserID == userID
+}
+
+func (c *Category) IsArchived() bool {
+	return c.ArchivedAt != nil
+}
+
+func (c *Category) Archive() {
+	now := time.Now()
+	c.ArchivedAt = &now
+	c.UpdatedAt = now
+}
+
+func (c *Category) Unarchive() {
+	c.ArchivedAt = nil
+	c.UpdatedAt = time.Now()
+}
+
+func (c *Category) Delete() {
+	now := time.Now()
+	c.DeletedAt = &now
+	c.UpdatedAt = now
+}
+
+type Node struct {
+	*Category
+	Children []*Node
+}
+
+// BuildTree arranges a user's categories into root nodes with nested
+// children, keeping the input order within each level.
+func BuildTree(categories []*Category) []*Node {
+	nodes := make(map[uuid.UUID]*Node, len(categories))
+	for _, c := range categories {
+		nodes[c.ID] = &Node{Category: c}
+	}
+
+	var roots []*Node
+	for _, c := range categories {
+		node := nodes[c.ID]
+		if c.ParentID != nil {
+			if parent, ok := nodes[*c.ParentID]; ok {
+				parent.Children = append(parent.Children, node)
+				continue
+			}
+		}
+		roots = append(roots, node)
+	}
+
+	return roots
+}
+
+type Seed struct {
+	Name     string
+	Type     CategoryType
+	Children []string
+}
+
+// DefaultSeeds is the starter set created for every new user.
+var DefaultSeeds = []Seed{
+	{Name: "Salary", Type: Income},
+	{Name: "Business", Type: Income},
+	{Name: "Investments", Type: Income, Children: []string{"Dividends", "Interest"}},
+	{Name: "Gifts", Type: Income},
+	{Name: "Other income", Type: Income},
+	{Name: "Housing", Type: Expense, Children: []string{"Rent", "Utilities", "Maintenance"}},
+	{Name: "Food", Type: Expense, Children: []string{"Groceries", "Restaurants"}},
+	{Name: "Transport", Type: Expense, Children: []string{"Fuel", "Public transport", "Taxi"}},
+	{Name: "Health", Type: Expense},
+	{Name: "Shopping", Type: Expense, Children: []string{"Clothing", "Household goods", "Electronics"}},
+	{Name: "Entertainment", Type: Expense, Children: []string{"Subscriptions"}},
+	{Name: "Education", Type: Expense},
+	{Name: "Travel", Type: Expense},
+	{Name: "Gifts", Type: Expense},
+	{Name: "Fees", Type: Expense},
+	{Name: "Other expenses", Type: Expense},
+}
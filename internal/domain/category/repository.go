@@ -0,0 +1,16 @@
+package category
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type Repository interface {
+	Create(ctx context.Context, category *Category) (uuid.UUID, error)
+	GetByID(ctx context.Context, id uuid.UUID) (*Category, error)
+	GetByUserID(ctx context.Context, userID uuid.UUID, includeArchived bool) ([]*Category, error)
+	Update(ctx context.Context, category *Category) error
+	Delete(ctx context.Context, userID, id uuid.UUID) error
+	Reparent(ctx context.Context, fromParentID uuid.UUID, toParentID *uuid.UUID) error
+}
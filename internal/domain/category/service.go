@@ -0,0 +1,28 @@
+package category
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type UpdateParams struct {
+	Name        *string
+	ParentID    *uuid.UUID
+	ClearParent bool
+	Archived    *bool
+}
+
+type DeleteParams struct {
+	ReassignTo   *uuid.UUID
+	Uncategorize bool
+}
+
+type Service interface {
+	Create(ctx context.Context, userID uuid.UUID, parentID *uuid.UUID, name string, categoryType CategoryType) (uuid.UUID, error)
+	GetByID(ctx context.Context, userID, id uuid.UUID) (*Category, error)
+	List(ctx context.Context, userID uuid.UUID, includeArchived bool) ([]*Category, error)
+	Update(ctx context.Context, userID, id uuid.UUID, params UpdateParams) error
+	Delete(ctx context.Context, userID, id uuid.UUID, params DeleteParams) error
+	SeedDefaults(ctx context.Context, userID uuid.UUID) error
+}
@@ -0,0 +1,13 @@
+package export
+
+type Format string
+
+const (
+	FormatCSV Format = "csv"
+	// FormatNDJSON writes one JSON object per line.
+	FormatNDJSON Format = "ndjson"
+	FormatOFX    Format = "ofx"
+	// FormatBeancount and FormatLedger are plain-text accounting journals.
+	FormatBeancount Format = "beancount"
+	FormatLedger    Format = "ledger"
+)
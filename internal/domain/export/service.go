@@ -0,0 +1,35 @@
+package export
+
+import (
+	"context"
+	"io"
+
+	"github.com/google/uuid"
+	"github.com/nontypeable/financial-tracker/internal/domain/transaction"
+)
+
+type TransactionsParams struct {
+	Format Format
+	Filter transaction.Filter
+	// Currency is the ISO 4217 code written to OFX statements, which
+	// require one; the tracker itself does not record currencies.
+	Currency string
+}
+
+type JournalParams struct {
+	Format Format
+	// Currency is the commodity every amount is written in.
+	Currency string
+}
+
+type Service interface {
+	// Transactions writes the user's transactions matching the filter to w
+	// as they are read from the database. The filter's cursor and limit are
+	// ignored. Output is buffered, so an error raised before the first rows
+	// leaves w untouched.
+	Transactions(ctx context.Context, userID uuid.UUID, params TransactionsParams, w io.Writer) error
+	// Journal writes all of the user's accounts and transactions as a
+	// double-entry journal, with a balance assertion after every completed
+	// reconciliation.
+	Journal(ctx context.Context, userID uuid.UUID, params JournalParams, w io.Writer) error
+}
@@ -0,0 +1,156 @@
+package goal
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// ContributionMonths is how many trailing months of contributions set the
+// pace a goal is projected at.
+const ContributionMonths = 3
+
+type Status string
+
+const (
+	Achieved Status = "achieved"
+	OnTrack  Status = "on_track"
+	Behind   Status = "behind"
+)
+
+// Goal is a savings target. The money saved towards it is the linked
+// share of each account's balance. TargetDate is a calendar day.
+type Goal struct {
+	ID           uuid.UUID       `db:"id"`
+	UserID       uuid.UUID       `db:"user_id"`
+	Name         string          `db:"name"`
+	TargetAmount decimal.Decimal `db:"target_amount"`
+	TargetDate   time.Time       `db:"target_date"`
+	Accounts     []Link          `db:"-"`
+	ArchivedAt   *time.Time      `db:"archived_at"`
+	CreatedAt    time.Time       `db:"created_at"`
+	UpdatedAt    time.Time       `db:"updated_at"`
+}
+
+// ShareScale is the number of decimal places a share is stored with.
+const ShareScale = 4
+
+// Link ties an account to a goal. Share is the fraction of the account's
+// balance counted towards the goal, from just above 0 up to 1, with at most
+// ShareScale decimal places.
+type Link struct {
+	AccountID uuid.UUID
+	Share     decimal.Decimal
+}
+
+func NewGoal(userID uuid.UUID, name string, targetAmount decimal.Decimal, targetDate time.Time, accounts []Link) *Goal {
+	year, month, day := targetDate.Date()
+
+	return &Goal{
+		UserID:       userID,
+		Name:         name,
+		TargetAmount: targetAmount,
+		TargetDate:   time.Date(year, month, day, 0, 0, 0, 0, time.UTC),
+		Accounts:     accounts,
+	}
+}
+
+func (g *Goal) BelongsUser(userID uuid.UUID) bool {
+	return g.UserID == userID
+}
+
+func (g *Goal) IsArchived() bool {
+	return g.ArchivedAt != nil
+}
+
+func (g *Goal) Archive() {
+	now := time.Now()
+	g.ArchivedAt = &now
+	g.UpdatedAt = now
+}
+
+func (g *Goal) Unarchive() {
+	g.ArchivedAt = nil
+	g.UpdatedAt = time.Now()
+}
+
+// AccountIDs lists the linked accounts.
+func (g *Goal) AccountIDs() []uuid.UUID {
+	ids := make([]uuid.UUID, 0, len(g.Accounts))
+	for _, link := range g.Accounts {
+		ids = append(ids, link.AccountID)
+	}
+	return ids
+}
+
+// Progress is a goal measured on a given day. Contributed is the net
+// amount added to the linked shares over the last ContributionMonths
+// months, and MonthlyPace its monthly average. RequiredMonthly is what
+// must be saved in each month left to reach the target in time.
+type Progress struct {
+	*Goal
+	Current         decimal.Decimal
+	Remaining       decimal.Decimal
+	Percent         decimal.Decimal
+	MonthsLeft      int
+	RequiredMonthly decimal.Decimal
+	Contributed     decimal.Decimal
+	MonthlyPace     decimal.Decimal
+	Status          Status
+}
+
+// Evaluate measures the goal on the given day from the current balances of
+// its accounts and the contributions over the trailing window. A goal is
+// on track when saving at its recent pace reaches the target by the
+// target date.
+func Evaluate(g *Goal, balances map[uuid.UUID]decimal.Decimal, contributed decimal.Decimal, today time.Time) *Progress {
+	current := decimal.Zero
+	for _, link := range g.Accounts {
+		current = current.Add(balances[link.AccountID].Mul(link.Share))
+	}
+
+	remaining := decimal.Max(g.TargetAmount.Sub(current), decimal.Zero)
+	months := MonthsBetween(today, g.TargetDate)
+	pace := contributed.Div(decimal.NewFromInt(ContributionMonths))
+
+	p := &Progress{
+		Goal:            g,
+		Current:         current,
+		Remaining:       remaining,
+		Percent:         current.Div(g.TargetAmount).Mul(decimal.NewFromInt(100)).Round(2),
+		MonthsLeft:      months,
+		RequiredMonthly: remaining,
+		Contributed:     contributed,
+		MonthlyPace:     pace,
+	}
+
+	if months > 0 {
+		p.RequiredMonthly = remaining.Div(decimal.NewFromInt(int64(months))).RoundCeil(2)
+	}
+
+	switch {
+	case remaining.IsZero():
+		p.Status = Achieved
+	case months > 0 && pace.Mul(decimal.NewFromInt(int64(months))).GreaterThanOrEqual(remaining):
+		p.Status = OnTrack
+	default:
+		p.Status = Behind
+	}
+
+	return p
+}
+
+// MonthsBetween counts the months from today until the target date, a
+// started month counting as a whole one. It is 0 once the date has passed.
+func MonthsBetween(today, target time.Time) int {
+	ty, tm, td := today.Date()
+	gy, gm, gd := target.Date()
+
+	months := (gy-ty)*12 + int(gm-tm)
+	if gd > td {
+		months++
+	}
+
+	return max(months, 0)
+}
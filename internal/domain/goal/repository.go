@@ -0,0 +1,21 @@
+package goal
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+type Repository interface {
+	Create(ctx context.Context, goal *Goal) (uuid.UUID, error)
+	GetByID(ctx context.Context, id uuid.UUID) (*Goal, error)
+	GetByUserID(ctx context.Context, userID uuid.UUID, includeArchived bool) ([]*Goal, error)
+	Update(ctx context.Context, goal *Goal) error
+	ReplaceAccounts(ctx context.Context, goalID uuid.UUID, accounts []Link) error
+	// SumContributions nets, per goal, the transactions of the linked
+	// accounts that occurred in [from, to), each weighted by the account's
+	// share. Goals without such transactions are absent from the map.
+	SumContributions(ctx context.Context, goalIDs []uuid.UUID, from, to time.Time) (map[uuid.UUID]decimal.Decimal, error)
+}
@@ -0,0 +1,33 @@
+package goal
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+type CreateParams struct {
+	Name         string
+	TargetAmount decimal.Decimal
+	TargetDate   time.Time
+	Accounts     []Link
+}
+
+// UpdateParams changes only what is set; a non-nil Accounts replaces every
+// linked account.
+type UpdateParams struct {
+	Name         *string
+	TargetAmount *decimal.Decimal
+	TargetDate   *time.Time
+	Accounts     []Link
+	Archived     *bool
+}
+
+type Service interface {
+	Create(ctx context.Context, userID uuid.UUID, params CreateParams) (uuid.UUID, error)
+	GetByID(ctx context.Context, userID, id uuid.UUID) (*Progress, error)
+	List(ctx context.Context, userID uuid.UUID, includeArchived bool) ([]*Progress, error)
+	Update(ctx context.Context, userID, id uuid.UUID, params UpdateParams) error
+}
@@ -0,0 +1,365 @@
+package importer
+
+import (
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nontypeable/financial-tracker/internal/domain/transaction"
+	"github.com/nontypeable/financial-tracker/internal/statement"
+	"github.com/nontypeable/financial-tracker/internal/statement/csv"
+	"github.com/shopspring/decimal"
+)
+
+type Format string
+
+const (
+	FormatCSV Format = "csv"
+	FormatOFX Format = "ofx"
+	// FormatCAMT053 is the ISO 20022 bank-to-customer statement.
+	FormatCAMT053 Format = "camt053"
+	FormatMT940   Format = "mt940"
+)
+
+type Status string
+
+const (
+	StatusPreview   Status = "preview"
+	StatusCommitted Status = "committed"
+)
+
+// Profile is a saved column mapping for one bank's CSV export.
+type Profile struct {
+	ID        uuid.UUID   `db:"id"`
+	UserID    uuid.UUID   `db:"user_id"`
+	Name      string      `db:"name"`
+	Format    Format      `db:"format"`
+	Settings  csv.Profile `db:"settings"`
+	CreatedAt time.Time   `db:"created_at"`
+	UpdatedAt time.Time   `db:"updated_at"`
+}
+
+func NewProfile(userID uuid.UUID, name string, settings csv.Profile) *Profile {
+	return &Profile{
+		UserID:   userID,
+		Name:     name,
+		Format:   FormatCSV,
+		Settings: settings,
+	}
+}
+
+func (p *Profile) BelongsUser(userID uuid.UUID) bool {
+	return p.UserID == userID
+}
+
+// Batch is one uploaded statement. It stays a preview until committed,
+// when its valid rows become transactions.
+type Batch struct {
+	ID        uuid.UUID `db:"id"`
+	UserID    uuid.UUID `db:"user_id"`
+	AccountID uuid.UUID `db:"account_id"`
+	Format    Format    `db:"format"`
+	Filename  string    `db:"filename"`
+	Status    Status    `db:"status"`
+	Rows      []Row     `db:"-"`
+	// OpeningBalance and OpeningDate are the opening balance reported in
+	// the file, when the format has one.
+	OpeningBalance *decimal.Decimal `db:"opening_balance"`
+	OpeningDate    *time.Time       `db:"opening_date"`
+	// StatementBalance and StatementDate are the closing balance reported
+	// in the file, when the format has one.
+	StatementBalance *decimal.Decimal `db:"statement_balance"`
+	StatementDate    *time.Time       `db:"statement_date"`
+	// Check compares the statement balance with the account. It is
+	// computed on read.
+	Check       *BalanceCheck `db:"-"`
+	CommittedAt *time.Time    `db:"committed_at"`
+	CreatedAt   time.Time     `db:"created_at"`
+	UpdatedAt   time.Time     `db:"updated_at"`
+}
+
+// Row is a parsed statement line. Rows with an error are shown in the
+// preview but never committed.
+type Row struct {
+	ID          uuid.UUID       `db:"id"`
+	Line        int             `db:"line"`
+	OccurredAt  *time.Time      `db:"occurred_at"`
+	BookingDate *time.Time      `db:"booking_date"`
+	Amount      decimal.Decimal `db:"amount"`
+	Description string          `db:"description"`
+	ExternalID  string          `db:"external_id"`
+	// Counterparty and CounterpartyAccount are shown in the preview only;
+	// the committed transaction carries them in its description.
+	Counterparty        string     `db:"counterparty"`
+	CounterpartyAccount string     `db:"counterparty_account"`
+	Error               string     `db:"error"`
+	TransactionID       *uuid.UUID `db:"transaction_id"`
+	// DuplicateOf is the existing transaction the row most likely repeats,
+	// with the detector's score. Both are computed on read.
+	DuplicateOf    *uuid.UUID `db:"-"`
+	DuplicateScore float64    `db:"-"`
+}
+
+func NewBatch(userID, accountID uuid.UUID, format Format, filename string, stmt *statement.Statement) *Batch {
+	batch := &Batch{
+		UserID:    userID,
+		AccountID: accountID,
+		Format:    format,
+		Filename:  filename,
+		Status:    StatusPreview,
+	}
+
+	for _, entry := range stmt.Entries {
+		date := entry.Date
+		batch.Rows = append(batch.Rows, Row{
+			Line:                entry.Line,
+			OccurredAt:          &date,
+			BookingDate:         entry.BookingDate,
+			Amount:              entry.Amount,
+			Description:         entry.Description,
+			ExternalID:          entry.ExternalID,
+			Counterparty:        entry.Counterparty,
+			CounterpartyAccount: entry.CounterpartyAccount,
+		})
+	}
+
+	if stmt.OpeningBalance != nil {
+		batch.OpeningBalance = &stmt.OpeningBalance.Amount
+		batch.OpeningDate = &stmt.OpeningBalance.Date
+	}
+
+	if stmt.ClosingBalance != nil {
+		batch.StatementBalance = &stmt.ClosingBalance.Amount
+		batch.StatementDate = &stmt.ClosingBalance.Date
+	}
+
+	for _, rowErr := range stmt.Errors {
+		batch.Rows = append(batch.Rows, Row{
+			Line:  rowErr.Line,
+			Error: rowErr.Message,
+		})
+	}
+
+	sort.SliceStable(batch.Rows, func(i, j int) bool {
+		return batch.Rows[i].Line < batch.Rows[j].Line
+	})
+
+	return batch
+}
+
+func (b *Batch) BelongsUser(userID uuid.UUID) bool {
+	return b.UserID == userID
+}
+
+func (b *Batch) IsCommitted() bool {
+	return b.Status == StatusCommitted
+}
+
+func (b *Batch) Commit() {
+	now := time.Now()
+	b.Status = StatusCommitted
+	b.CommittedAt = &now
+	b.UpdatedAt = now
+}
+
+// MarkDuplicates rejects rows whose external ID is already used by a
+// transaction of the account or by an earlier row of the batch, and
+// returns the rows it rejected.
+func (b *Batch) MarkDuplicates(existing map[string]struct{}) []*Row {
+	var rejected []*Row
+	seen := make(map[string]struct{})
+	for i := range b.Rows {
+		row := &b.Rows[i]
+		if !row.IsValid() || row.ExternalID == "" || row.TransactionID != nil {
+			continue
+		}
+
+		if _, ok := existing[row.ExternalID]; ok {
+			row.Error = "already imported"
+			rejected = append(rejected, row)
+			continue
+		}
+		if _, ok := seen[row.ExternalID]; ok {
+			row.Error = "duplicate of an earlier row"
+			rejected = append(rejected, row)
+			continue
+		}
+		seen[row.ExternalID] = struct{}{}
+	}
+	return rejected
+}
+
+// ExternalIDs lists the external IDs of the rows still to be imported.
+func (b *Batch) ExternalIDs() []string {
+	var ids []string
+	for _, row := range b.Rows {
+		if row.IsValid() && row.ExternalID != "" && row.TransactionID == nil {
+			ids = append(ids, row.ExternalID)
+		}
+	}
+	return ids
+}
+
+// Span returns the dates of the earliest and latest rows still to be
+// imported; ok is false when there are none.
+func (b *Batch) Span() (from, to time.Time, ok bool) {
+	for _, row := range b.Rows {
+		if !row.IsValid() || row.TransactionID != nil || row.OccurredAt == nil {
+			continue
+		}
+
+		if !ok || row.OccurredAt.Before(from) {
+			from = *row.OccurredAt
+		}
+		if !ok || row.OccurredAt.After(to) {
+			to = *row.OccurredAt
+		}
+		ok = true
+	}
+	return from, to, ok
+}
+
+// FlagDuplicates points rows still to be imported at the existing
+// transactions they most likely repeat. Matches are taken best score first
+// and each transaction is claimed by one row at most, so two equal
+// purchases on one day are not both flagged against a single recorded one.
+func (b *Batch) FlagDuplicates(existing []*transaction.Transaction) {
+	type match struct {
+		row   *Row
+		id    uuid.UUID
+		score float64
+	}
+
+	var matches []match
+	for i := range b.Rows {
+		row := &b.Rows[i]
+		row.DuplicateOf, row.DuplicateScore = nil, 0
+		if !row.IsValid() || row.TransactionID != nil || row.OccurredAt == nil {
+			continue
+		}
+
+		candidate := transaction.Candidate{
+			AccountID:   b.AccountID,
+			Amount:      row.Amount,
+			OccurredAt:  *row.OccurredAt,
+			Description: row.Description,
+			ExternalID:  row.ExternalID,
+		}
+
+		for _, t := range existing {
+			score := transaction.DuplicateScore(candidate, t.Candidate())
+			if score >= transaction.DuplicateThreshold {
+				matches = append(matches, match{row: row, id: t.ID, score: score})
+			}
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].score > matches[j].score
+	})
+
+	claimed := make(map[uuid.UUID]struct{})
+	for _, m := range matches {
+		if m.row.DuplicateOf != nil {
+			continue
+		}
+		if _, ok := claimed[m.id]; ok {
+			continue
+		}
+
+		id := m.id
+		m.row.DuplicateOf, m.row.DuplicateScore = &id, m.score
+		claimed[id] = struct{}{}
+	}
+}
+
+// RejectFlagged rejects the rows flagged as likely duplicates and returns
+// them.
+func (b *Batch) RejectFlagged() []*Row {
+	var rejected []*Row
+	for i := range b.Rows {
+		row := &b.Rows[i]
+		if row.IsValid() && row.DuplicateOf != nil {
+			row.Error = "likely duplicate of an existing transaction"
+			rejected = append(rejected, row)
+		}
+	}
+	return rejected
+}
+
+// Duplicates counts the rows flagged as likely duplicates.
+func (b *Batch) Duplicates() int {
+	n := 0
+	for _, row := range b.Rows {
+		if row.DuplicateOf != nil {
+			n++
+		}
+	}
+	return n
+}
+
+// Counts returns how many rows will be imported and how many were rejected.
+func (b *Batch) Counts() (valid, invalid int) {
+	for _, row := range b.Rows {
+		if row.IsValid() {
+			valid++
+		} else {
+			invalid++
+		}
+	}
+	return valid, invalid
+}
+
+func (r *Row) IsValid() bool {
+	return r.Error == ""
+}
+
+// Type derives the transaction type from the sign of the amount.
+func (r *Row) Type() transaction.TransactionType {
+	if r.Amount.IsNegative() {
+		return transaction.Expense
+	}
+	return transaction.Income
+}
+
+// BalanceCheck reconciles the statement's closing balance with the account
+// as of the statement date. Pending is what the batch would add on commit,
+// and Difference is what would remain unexplained afterwards.
+type BalanceCheck struct {
+	StatementBalance decimal.Decimal
+	StatementDate    time.Time
+	AccountBalance   decimal.Decimal
+	Pending          decimal.Decimal
+	Difference       decimal.Decimal
+}
+
+// Cutoff is the first instant after the statement date.
+func (b *Batch) Cutoff() time.Time {
+	return b.StatementDate.AddDate(0, 0, 1)
+}
+
+// PendingBefore nets the uncommitted valid rows dated before the cutoff.
+func (b *Batch) PendingBefore(cutoff time.Time) decimal.Decimal {
+	sum := decimal.Zero
+	if b.IsCommitted() {
+		return sum
+	}
+
+	for _, row := range b.Rows {
+		if row.IsValid() && row.OccurredAt != nil && row.OccurredAt.Before(cutoff) {
+			sum = sum.Add(row.Amount)
+		}
+	}
+	return sum
+}
+
+// Summary reports the outcome of a QIF import. Skipped lists records that
+// were not imported, with the reason.
+type Summary struct {
+	AccountsCreated   []string
+	AccountsMatched   []string
+	CategoriesCreated []string
+	Transactions      int
+	Transfers         int
+	Skipped           []statement.RowError
+}
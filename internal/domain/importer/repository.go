@@ -0,0 +1,24 @@
+package importer
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type Repository interface {
+	CreateProfile(ctx context.Context, profile *Profile) (uuid.UUID, error)
+	GetProfileByID(ctx context.Context, id uuid.UUID) (*Profile, error)
+	GetProfilesByUserID(ctx context.Context, userID uuid.UUID) ([]*Profile, error)
+	UpdateProfile(ctx context.Context, profile *Profile) error
+	DeleteProfile(ctx context.Context, userID, id uuid.UUID) error
+	// CreateBatch stores the batch together with its rows.
+	CreateBatch(ctx context.Context, batch *Batch) (uuid.UUID, error)
+	GetBatchByID(ctx context.Context, id uuid.UUID) (*Batch, error)
+	GetBatchByIDForUpdate(ctx context.Context, id uuid.UUID) (*Batch, error)
+	UpdateBatch(ctx context.Context, batch *Batch) error
+	// DeleteBatch removes an uncommitted batch and its rows.
+	DeleteBatch(ctx context.Context, id uuid.UUID) error
+	// UpdateRow stores the row's transaction link and error.
+	UpdateRow(ctx context.Context, row *Row) error
+}
@@ -0,0 +1,56 @@
+package importer
+
+import (
+	"context"
+	"io"
+
+	"github.com/google/uuid"
+	"github.com/nontypeable/financial-tracker/internal/statement/csv"
+	"github.com/nontypeable/financial-tracker/internal/statement/qif"
+)
+
+type UpdateProfileParams struct {
+	Name     *string
+	Settings *csv.Profile
+}
+
+type StatementParams struct {
+	AccountID uuid.UUID
+	Format    Format
+	Filename  string
+	// Encoding applies to MT940 files only; OFX and camt.053 files declare
+	// their own.
+	Encoding string
+}
+
+type QIFParams struct {
+	// AccountID receives transactions that the file does not place in a
+	// named account. It is required only for such files.
+	AccountID *uuid.UUID
+	DateOrder qif.DateOrder
+	Encoding  string
+}
+
+type Service interface {
+	CreateProfile(ctx context.Context, userID uuid.UUID, name string, settings csv.Profile) (uuid.UUID, error)
+	GetProfile(ctx context.Context, userID, id uuid.UUID) (*Profile, error)
+	ListProfiles(ctx context.Context, userID uuid.UUID) ([]*Profile, error)
+	UpdateProfile(ctx context.Context, userID, id uuid.UUID, params UpdateProfileParams) error
+	DeleteProfile(ctx context.Context, userID, id uuid.UUID) error
+	// ImportCSV parses the file with the saved profile and stores the result
+	// as a preview batch for the account.
+	ImportCSV(ctx context.Context, userID, accountID, profileID uuid.UUID, filename string, file io.Reader) (*Batch, error)
+	// ImportStatement parses an OFX, camt.053 or MT940 file into a preview
+	// batch. Rows whose bank reference was imported before are rejected.
+	ImportStatement(ctx context.Context, userID uuid.UUID, params StatementParams, file io.Reader) (*Batch, error)
+	// ImportQIF creates the file's accounts, categories, transactions and
+	// transfers in one database transaction, matching existing accounts
+	// and categories by name.
+	ImportQIF(ctx context.Context, userID uuid.UUID, params QIFParams, file io.Reader) (*Summary, error)
+	GetBatch(ctx context.Context, userID, id uuid.UUID) (*Batch, error)
+	// Commit turns every valid row of a preview batch into a cleared
+	// transaction, all in one database transaction. With skipDuplicates,
+	// rows flagged as likely duplicates are rejected instead.
+	Commit(ctx context.Context, userID, id uuid.UUID, skipDuplicates bool) (*Batch, error)
+	Discard(ctx context.Context, userID, id uuid.UUID) error
+}
@@ -0,0 +1,66 @@
+package reconciliation
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nontypeable/financial-tracker/internal/domain/transaction"
+	"github.com/shopspring/decimal"
+)
+
+// Reconciliation matches an account's cleared transactions against a bank
+// statement. StatementDate is a calendar day; the statement covers every
+// transaction that occurred up to the end of that day.
+type Reconciliation struct {
+	ID               uuid.UUID       `db:"id"`
+	AccountID        uuid.UUID       `db:"account_id"`
+	StatementDate    time.Time       `db:"statement_date"`
+	StatementBalance decimal.Decimal `db:"statement_balance"`
+	CompletedAt      *time.Time      `db:"completed_at"`
+	CreatedAt        time.Time       `db:"created_at"`
+	UpdatedAt        time.Time       `db:"updated_at"`
+}
+
+// Summary is a reconciliation together with the state of the account it
+// is being worked against.
+type Summary struct {
+	*Reconciliation
+	ClearedBalance decimal.Decimal
+	// Difference is what is left to tick off: statement minus cleared balance.
+	Difference decimal.Decimal
+	// Transactions are the unreconciled entries up to the statement date.
+	Transactions []*transaction.Transaction
+}
+
+func NewReconciliation(accountID uuid.UUID, statementDate time.Time, statementBalance decimal.Decimal) *Reconciliation {
+	year, month, day := statementDate.Date()
+
+	return &Reconciliation{
+		AccountID:        accountID,
+		StatementDate:    time.Date(year, month, day, 0, 0, 0, 0, time.UTC),
+		StatementBalance: statementBalance,
+	}
+}
+
+// Cutoff is the exclusive upper bound on occurred_at for transactions
+// covered by the statement.
+func (r *Reconciliation) Cutoff() time.Time {
+	return r.StatementDate.AddDate(0, 0, 1)
+}
+
+func (r *Reconciliation) IsCompleted() bool {
+	return r.CompletedAt != nil
+}
+
+// Certifies reports whether t falls within the balance a completed
+// reconciliation vouched for, so that posting, moving or restoring it would
+// change that balance.
+func (r *Reconciliation) Certifies(t *transaction.Transaction) bool {
+	return r.IsCompleted() && r.AccountID == t.AccountID && t.OccurredAt.Before(r.Cutoff())
+}
+
+func (r *Reconciliation) Complete() {
+	now := time.Now()
+	r.CompletedAt = &now
+	r.UpdatedAt = now
+}
@@ -0,0 +1,17 @@
+package reconciliation
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type Repository interface {
+	Create(ctx context.Context, reconciliation *Reconciliation) (uuid.UUID, error)
+	GetByID(ctx context.Context, id uuid.UUID) (*Reconciliation, error)
+	GetByIDForUpdate(ctx context.Context, id uuid.UUID) (*Reconciliation, error)
+	GetByAccountID(ctx context.Context, accountID uuid.UUID) ([]*Reconciliation, error)
+	Update(ctx context.Context, reconciliation *Reconciliation) error
+	// Delete removes a reconciliation that has not been completed yet.
+	Delete(ctx context.Context, id uuid.UUID) error
+}
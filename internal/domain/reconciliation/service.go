@@ -0,0 +1,22 @@
+package reconciliation
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+type Service interface {
+	Start(ctx context.Context, userID, accountID uuid.UUID, statementDate time.Time, statementBalance decimal.Decimal) (uuid.UUID, error)
+	GetByID(ctx context.Context, userID, id uuid.UUID) (*Summary, error)
+	List(ctx context.Context, userID, accountID uuid.UUID) ([]*Reconciliation, error)
+	// Clear ticks transactions off as cleared, or back to pending when
+	// cleared is false.
+	Clear(ctx context.Context, userID, id uuid.UUID, transactionIDs []uuid.UUID, cleared bool) (*Summary, error)
+	// Complete locks every cleared transaction up to the statement date.
+	// It fails unless the cleared balance equals the statement balance.
+	Complete(ctx context.Context, userID, id uuid.UUID) error
+	Cancel(ctx context.Context, userID, id uuid.UUID) error
+}
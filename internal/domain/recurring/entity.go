@@ -0,0 +1,210 @@
+package recurring
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nontypeable/financial-tracker/internal/domain/transaction"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	DefaultPreviewLimit = 12
+	MaxPreviewLimit     = 100
+)
+
+type Frequency string
+
+const (
+	Daily   Frequency = "daily"
+	Weekly  Frequency = "weekly"
+	Monthly Frequency = "monthly"
+	Yearly  Frequency = "yearly"
+)
+
+// Rule is a template transaction posted on a schedule. Schedule dates are
+// calendar days in UTC.
+type Rule struct {
+	ID          uuid.UUID                   `db:"id"`
+	UserID      uuid.UUID                   `db:"user_id"`
+	AccountID   uuid.UUID                   `db:"account_id"`
+	Amount      decimal.Decimal             `db:"amount"`
+	Type        transaction.TransactionType `db:"type"`
+	Description string                      `db:"description"`
+	CategoryID  *uuid.UUID                  `db:"category_id"`
+	Frequency   Frequency                   `db:"frequency"`
+	Interval    int                         `db:"interval_count"`
+	// ByMonthDay pins monthly and yearly rules to a day of the month; days
+	// past the end of a shorter month fall on its last day.
+	ByMonthDay *int       `db:"by_month_day"`
+	StartDate  time.Time  `db:"start_date"`
+	EndDate    *time.Time `db:"end_date"`
+	Count      *int       `db:"max_occurrences"`
+	// LastOccurrence is the latest occurrence already posted or skipped.
+	LastOccurrence *time.Time `db:"last_occurrence"`
+	NextOccurrence *time.Time `db:"next_occurrence"`
+	PausedAt       *time.Time `db:"paused_at"`
+	CreatedAt      time.Time  `db:"created_at"`
+	UpdatedAt      time.Time  `db:"updated_at"`
+	DeletedAt      *time.Time `db:"deleted_at"`
+}
+
+type Occurrence struct {
+	Date    time.Time
+	Skipped bool
+}
+
+func NewRule(userID uuid.UUID, params CreateParams) *Rule {
+	rule := &Rule{
+		UserID:      userID,
+		AccountID:   params.AccountID,
+		Amount:      params.Amount,
+		Type:        params.Type,
+		Description: params.Description,
+		CategoryID:  params.CategoryID,
+		Frequency:   params.Frequency,
+		Interval:    params.Interval,
+		ByMonthDay:  params.ByMonthDay,
+		StartDate:   Date(params.StartDate),
+		Count:       params.Count,
+	}
+
+	if rule.Interval == 0 {
+		rule.Interval = 1
+	}
+
+	if params.EndDate != nil {
+		endDate := Date(*params.EndDate)
+		rule.EndDate = &endDate
+	}
+
+	rule.NextOccurrence = rule.Next()
+
+	return rule
+}
+
+// Date truncates t to its calendar day in UTC.
+func Date(t time.Time) time.Time {
+	year, month, day := t.UTC().Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func (r *Rule) BelongsUser(userID uuid.UUID) bool {
+	return r.UserID == userID
+}
+
+func (r *Rule) IsPaused() bool {
+	return r.PausedAt != nil
+}
+
+func (r *Rule) Pause() {
+	now := time.Now()
+	r.PausedAt = &now
+	r.UpdatedAt = now
+}
+
+// Resume reactivates the rule from today on; occurrences that fell due
+// while it was paused are not posted.
+func (r *Rule) Resume(today time.Time) {
+	yesterday := Date(today).AddDate(0, 0, -1)
+	if r.LastOccurrence == nil || r.LastOccurrence.Before(yesterday) {
+		r.LastOccurrence = &yesterday
+	}
+
+	r.PausedAt = nil
+	r.NextOccurrence = r.Next()
+	r.UpdatedAt = time.Now()
+}
+
+// Advance records date as handled and moves the rule to its next occurrence.
+func (r *Rule) Advance(date time.Time) {
+	r.LastOccurrence = &date
+	r.NextOccurrence = r.Next()
+	r.UpdatedAt = time.Now()
+}
+
+// Next returns the first occurrence after LastOccurrence, or nil once the
+// schedule is exhausted.
+func (r *Rule) Next() *time.Time {
+	dates := r.Occurrences(r.LastOccurrence, nil, 1)
+	if len(dates) == 0 {
+		return nil
+	}
+	return &dates[0]
+}
+
+// Occurrences lists scheduled dates strictly after after (when set) and up
+// to and including until (when set), stopping at limit dates when limit is
+// positive. Callers must bound the result by until, limit, or the rule's
+// own end date or count.
+func (r *Rule) Occurrences(after, until *time.Time, limit int) []time.Time {
+	var dates []time.Time
+
+	seen := 0
+	for n := 0; ; n++ {
+		date := r.nth(n)
+		if date.Before(r.StartDate) {
+			continue
+		}
+
+		seen++
+		if r.Count != nil && seen > *r.Count {
+			break
+		}
+		if r.EndDate != nil && date.After(*r.EndDate) {
+			break
+		}
+		if until != nil && date.After(*until) {
+			break
+		}
+		if after != nil && !date.After(*after) {
+			continue
+		}
+
+		dates = append(dates, date)
+		if limit > 0 && len(dates) >= limit {
+			break
+		}
+	}
+
+	return dates
+}
+
+// IsScheduled reports whether date is an occurrence not yet posted or skipped.
+func (r *Rule) IsScheduled(date time.Time) bool {
+	date = Date(date)
+	dates := r.Occurrences(r.LastOccurrence, &date, 0)
+	return len(dates) > 0 && dates[len(dates)-1].Equal(date)
+}
+
+func (r *Rule) nth(n int) time.Time {
+	step := n * r.Interval
+
+	switch r.Frequency {
+	case Weekly:
+		return r.StartDate.AddDate(0, 0, 7*step)
+	case Monthly:
+		return r.monthDay(r.StartDate.Year(), r.StartDate.Month()+time.Month(step))
+	case Yearly:
+		return r.monthDay(r.StartDate.Year()+step, r.StartDate.Month())
+	default:
+		return r.StartDate.AddDate(0, 0, step)
+	}
+}
+
+// monthDay resolves the rule's day of month within the given month,
+// normalizing month overflow and clamping to the month's last day.
+func (r *Rule) monthDay(year int, month time.Month) time.Time {
+	first := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+
+	day := r.StartDate.Day()
+	if r.ByMonthDay != nil {
+		day = *r.ByMonthDay
+	}
+
+	if last := first.AddDate(0, 1, -1).Day(); day > last {
+		day = last
+	}
+
+	return first.AddDate(0, 0, day-1)
+}
@@ -0,0 +1,181 @@
+package recurring
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func day(s string) time.Time {
+	t, err := time.Parse(time.DateOnly, s)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func days(ss ...string) []time.Time {
+	dates := make([]time.Time, 0, len(ss))
+	for _, s := range ss {
+		dates = append(dates, day(s))
+	}
+	return dates
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func TestOccurrences(t *testing.T) {
+	tests := []struct {
+		name  string
+		rule  Rule
+		after *time.Time
+		until *time.Time
+		limit int
+		want  []time.Time
+	}{
+		{
+			name:  "daily every third day",
+			rule:  Rule{Frequency: Daily, Interval: 3, StartDate: day("2024-02-27")},
+			limit: 3,
+			want:  days("2024-02-27", "2024-03-01", "2024-03-04"),
+		},
+		{
+			name:  "weekly every other week",
+			rule:  Rule{Frequency: Weekly, Interval: 2, StartDate: day("2024-12-23")},
+			limit: 3,
+			want:  days("2024-12-23", "2025-01-06", "2025-01-20"),
+		},
+		{
+			name:  "monthly on the 31st clamps without drifting",
+			rule:  Rule{Frequency: Monthly, Interval: 1, StartDate: day("2024-01-31")},
+			limit: 4,
+			want:  days("2024-01-31", "2024-02-29", "2024-03-31", "2024-04-30"),
+		},
+		{
+			name:  "monthly every second month from a month end",
+			rule:  Rule{Frequency: Monthly, Interval: 2, StartDate: day("2023-12-31")},
+			limit: 4,
+			want:  days("2023-12-31", "2024-02-29", "2024-04-30", "2024-06-30"),
+		},
+		{
+			name:  "monthly every third month across years",
+			rule:  Rule{Frequency: Monthly, Interval: 3, StartDate: day("2024-11-15")},
+			limit: 3,
+			want:  days("2024-11-15", "2025-02-15", "2025-05-15"),
+		},
+		{
+			name:  "by month day later than the start",
+			rule:  Rule{Frequency: Monthly, Interval: 1, ByMonthDay: ptr(30), StartDate: day("2025-01-15")},
+			limit: 3,
+			want:  days("2025-01-30", "2025-02-28", "2025-03-30"),
+		},
+		{
+			name:  "by month day earlier than the start skips the first month",
+			rule:  Rule{Frequency: Monthly, Interval: 1, ByMonthDay: ptr(10), StartDate: day("2025-01-15")},
+			limit: 2,
+			want:  days("2025-02-10", "2025-03-10"),
+		},
+		{
+			name:  "yearly on a leap day",
+			rule:  Rule{Frequency: Yearly, Interval: 1, StartDate: day("2024-02-29")},
+			limit: 5,
+			want:  days("2024-02-29", "2025-02-28", "2026-02-28", "2027-02-28", "2028-02-29"),
+		},
+		{
+			name:  "yearly every other year",
+			rule:  Rule{Frequency: Yearly, Interval: 2, StartDate: day("2024-06-01")},
+			limit: 3,
+			want:  days("2024-06-01", "2026-06-01", "2028-06-01"),
+		},
+		{
+			name: "end date is inclusive",
+			rule: Rule{Frequency: Weekly, Interval: 1, StartDate: day("2025-01-01"), EndDate: ptr(day("2025-01-15"))},
+			want: days("2025-01-01", "2025-01-08", "2025-01-15"),
+		},
+		{
+			name: "count stops the schedule",
+			rule: Rule{Frequency: Monthly, Interval: 1, StartDate: day("2025-01-01"), Count: ptr(3)},
+			want: days("2025-01-01", "2025-02-01", "2025-03-01"),
+		},
+		{
+			name:  "count includes occurrences before after",
+			rule:  Rule{Frequency: Monthly, Interval: 1, StartDate: day("2025-01-01"), Count: ptr(3)},
+			after: ptr(day("2025-01-01")),
+			want:  days("2025-02-01", "2025-03-01"),
+		},
+		{
+			name:  "after is exclusive and until inclusive",
+			rule:  Rule{Frequency: Daily, Interval: 1, StartDate: day("2025-01-01")},
+			after: ptr(day("2025-01-03")),
+			until: ptr(day("2025-01-06")),
+			want:  days("2025-01-04", "2025-01-05", "2025-01-06"),
+		},
+		{
+			name:  "until before the start",
+			rule:  Rule{Frequency: Daily, Interval: 1, StartDate: day("2025-01-10")},
+			until: ptr(day("2025-01-09")),
+			want:  nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.rule.Occurrences(tt.after, tt.until, tt.limit)
+			if !slices.EqualFunc(got, tt.want, time.Time.Equal) {
+				t.Errorf("Occurrences() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAdvance(t *testing.T) {
+	rule := Rule{Frequency: Monthly, Interval: 1, StartDate: day("2025-01-31"), Count: ptr(2)}
+	rule.NextOccurrence = rule.Next()
+
+	var posted []time.Time
+	for rule.NextOccurrence != nil {
+		posted = append(posted, *rule.NextOccurrence)
+		rule.Advance(*rule.NextOccurrence)
+	}
+
+	if want := days("2025-01-31", "2025-02-28"); !slices.EqualFunc(posted, want, time.Time.Equal) {
+		t.Errorf("posted %v, want %v", posted, want)
+	}
+}
+
+func TestIsScheduled(t *testing.T) {
+	rule := Rule{Frequency: Weekly, Interval: 1, StartDate: day("2025-01-06"), LastOccurrence: ptr(day("2025-01-13"))}
+
+	tests := []struct {
+		date time.Time
+		want bool
+	}{
+		{day("2025-01-13"), false},
+		{day("2025-01-20"), true},
+		{day("2025-01-21"), false},
+		{day("2025-02-03"), true},
+	}
+
+	for _, tt := range tests {
+		if got := rule.IsScheduled(tt.date); got != tt.want {
+			t.Errorf("IsScheduled(%s) = %t, want %t", tt.date.Format(time.DateOnly), got, tt.want)
+		}
+	}
+}
+
+func TestResume(t *testing.T) {
+	rule := Rule{Frequency: Monthly, Interval: 1, StartDate: day("2025-01-05"), LastOccurrence: ptr(day("2025-01-05"))}
+	rule.Pause()
+
+	// Occurrences that fell due while paused are not posted.
+	rule.Resume(day("2025-04-05"))
+
+	if rule.IsPaused() {
+		t.Error("rule is still paused")
+	}
+	if rule.NextOccurrence == nil || !rule.NextOccurrence.Equal(day("2025-04-05")) {
+		t.Errorf("next occurrence = %v, want 2025-04-05", rule.NextOccurrence)
+	}
+}
@@ -0,0 +1,21 @@
+package recurring
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Repository interface {
+	Create(ctx context.Context, rule *Rule) (uuid.UUID, error)
+	GetByID(ctx context.Context, id uuid.UUID) (*Rule, error)
+	GetByIDForUpdate(ctx context.Context, id uuid.UUID) (*Rule, error)
+	GetByUserID(ctx context.Context, userID uuid.UUID) ([]*Rule, error)
+	Update(ctx context.Context, rule *Rule) error
+	Delete(ctx context.Context, userID, id uuid.UUID) error
+	// ListDue returns active rules whose next occurrence is on or before date.
+	ListDue(ctx context.Context, date time.Time) ([]uuid.UUID, error)
+	AddSkip(ctx context.Context, ruleID uuid.UUID, date time.Time) error
+	GetSkips(ctx context.Context, ruleID uuid.UUID) ([]time.Time, error)
+}
@@ -0,0 +1,38 @@
+package recurring
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nontypeable/financial-tracker/internal/domain/transaction"
+	"github.com/shopspring/decimal"
+)
+
+type CreateParams struct {
+	AccountID   uuid.UUID
+	Amount      decimal.Decimal
+	Type        transaction.TransactionType
+	Description string
+	CategoryID  *uuid.UUID
+	Frequency   Frequency
+	Interval    int
+	ByMonthDay  *int
+	StartDate   time.Time
+	EndDate     *time.Time
+	Count       *int
+}
+
+type Service interface {
+	Create(ctx context.Context, userID uuid.UUID, params CreateParams) (uuid.UUID, error)
+	GetByID(ctx context.Context, userID, id uuid.UUID) (*Rule, error)
+	List(ctx context.Context, userID uuid.UUID) ([]*Rule, error)
+	Delete(ctx context.Context, userID, id uuid.UUID) error
+	Preview(ctx context.Context, userID, id uuid.UUID, limit int) ([]Occurrence, error)
+	Skip(ctx context.Context, userID, id uuid.UUID, date time.Time) error
+	Pause(ctx context.Context, userID, id uuid.UUID) error
+	Resume(ctx context.Context, userID, id uuid.UUID) error
+	// MaterializeDue posts every occurrence due on or before today and
+	// returns how many transactions were created.
+	MaterializeDue(ctx context.Context, today time.Time) (int, error)
+}
@@ -0,0 +1,92 @@
+package report
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nontypeable/financial-tracker/internal/domain/transaction"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	DefaultTop = 10
+	MaxTop     = 100
+)
+
+// Comparison sets an amount against the same figure for the previous
+// period. Share is the amount's percentage of the period total, and
+// ChangePercent is nil when there is nothing to compare with.
+type Comparison struct {
+	Current       decimal.Decimal
+	Previous      decimal.Decimal
+	Change        decimal.Decimal
+	ChangePercent *decimal.Decimal
+	Share         decimal.Decimal
+}
+
+func Compare(current, previous, total decimal.Decimal) Comparison {
+	hundred := decimal.NewFromInt(100)
+
+	c := Comparison{
+		Current:  current,
+		Previous: previous,
+		Change:   current.Sub(previous),
+		Share:    decimal.Zero,
+	}
+
+	if !total.IsZero() {
+		c.Share = current.Div(total).Mul(hundred).Round(2)
+	}
+
+	if !previous.IsZero() {
+		percent := c.Change.Div(previous.Abs()).Mul(hundred).Round(2)
+		c.ChangePercent = &percent
+	}
+
+	return c
+}
+
+type CategoryShare struct {
+	CategoryID *uuid.UUID
+	ParentID   *uuid.UUID
+	Name       string
+	Comparison
+}
+
+type DescriptionShare struct {
+	Description string
+	Count       int
+	Comparison
+}
+
+// Breakdown shows where the money of one transaction type went in a period
+// and how that compares with the period of the same length before it.
+// Category totals include their subcategories, so shares of nested
+// categories overlap with their parents'.
+type Breakdown struct {
+	Type         transaction.TransactionType
+	From         time.Time
+	To           time.Time
+	PreviousFrom time.Time
+	Total        Comparison
+	Categories   []CategoryShare
+	Descriptions []DescriptionShare
+}
+
+// PreviousPeriod returns the start of the period of the same length right
+// before [from, to). Ranges of whole calendar months are shifted by months,
+// so October is compared with September rather than with the 31 days
+// before it.
+func PreviousPeriod(from, to time.Time) time.Time {
+	if isMonthStart(from) && isMonthStart(to) {
+		months := (to.Year()-from.Year())*12 + int(to.Month()-from.Month())
+		return from.AddDate(0, -months, 0)
+	}
+
+	return from.Add(-to.Sub(from))
+}
+
+func isMonthStart(t time.Time) bool {
+	t = t.UTC()
+	return t.Day() == 1 && t.Hour() == 0 && t.Minute() == 0 && t.Second() == 0 && t.Nanosecond() == 0
+}
@@ -0,0 +1,101 @@
+package report
+
+import (
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nontypeable/financial-tracker/internal/domain/account"
+	"github.com/nontypeable/financial-tracker/internal/domain/transaction"
+	"github.com/shopspring/decimal"
+)
+
+// NetWorthPoint is what the accounts were worth at AsOf, the end of the
+// period starting at Start. Liabilities is the amount owed, positive while
+// the liability accounts are in debt, and Net is assets less liabilities.
+type NetWorthPoint struct {
+	Start       time.Time
+	AsOf        time.Time
+	Assets      decimal.Decimal
+	Liabilities decimal.Decimal
+	Net         decimal.Decimal
+}
+
+// AccountWorth is an account with its balance at the end of the report.
+type AccountWorth struct {
+	*account.Account
+	Closing decimal.Decimal
+}
+
+// NetWorth follows the worth of the accounts over a date range, one point
+// per period, with each account's balance at the end of the range. It has
+// no points when there are no accounts to follow.
+type NetWorth struct {
+	Interval transaction.Interval
+	Points   []NetWorthPoint
+	Accounts []AccountWorth
+}
+
+// SumNetWorth adds the account balances up per period, keeping assets and
+// liabilities apart. Balances of accounts not listed are ignored.
+func SumNetWorth(interval transaction.Interval, accounts []*account.Account, balances []*transaction.AccountBalance) *NetWorth {
+	byID := make(map[uuid.UUID]*account.Account, len(accounts))
+	for _, a := range accounts {
+		byID[a.ID] = a
+	}
+
+	closing := make(map[uuid.UUID]*transaction.AccountBalance, len(accounts))
+	points := make(map[int64]*NetWorthPoint)
+	var starts []int64
+
+	for _, b := range balances {
+		a, ok := byID[b.AccountID]
+		if !ok {
+			continue
+		}
+
+		key := b.Start.Unix()
+		p, ok := points[key]
+		if !ok {
+			p = &NetWorthPoint{
+				Start:       b.Start,
+				AsOf:        b.AsOf,
+				Assets:      decimal.Zero,
+				Liabilities: decimal.Zero,
+			}
+			points[key] = p
+			starts = append(starts, key)
+		}
+
+		if a.Kind == account.Liability {
+			p.Liabilities = p.Liabilities.Sub(b.Balance)
+		} else {
+			p.Assets = p.Assets.Add(b.Balance)
+		}
+
+		if last, ok := closing[a.ID]; !ok || b.Start.After(last.Start) {
+			closing[a.ID] = b
+		}
+	}
+
+	netWorth := &NetWorth{
+		Interval: interval,
+		Points:   make([]NetWorthPoint, 0, len(starts)),
+		Accounts: make([]AccountWorth, 0, len(accounts)),
+	}
+
+	slices.Sort(starts)
+	for _, start := range starts {
+		p := points[start]
+		p.Net = p.Assets.Sub(p.Liabilities)
+		netWorth.Points = append(netWorth.Points, *p)
+	}
+
+	for _, a := range accounts {
+		if last, ok := closing[a.ID]; ok {
+			netWorth.Accounts = append(netWorth.Accounts, AccountWorth{Account: a, Closing: last.Balance})
+		}
+	}
+
+	return netWorth
+}
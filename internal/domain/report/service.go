@@ -0,0 +1,39 @@
+package report
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nontypeable/financial-tracker/internal/domain/transaction"
+)
+
+// CashFlowParams selects the transactions to report on. The filter's From
+// and To bound the report and must both be set.
+type CashFlowParams struct {
+	Filter   transaction.Filter
+	Interval transaction.Interval
+}
+
+// BreakdownParams selects the transactions to break down. The filter's
+// From and To bound the period and must both be set; its type defaults to
+// expense. Top limits the descriptions listed.
+type BreakdownParams struct {
+	Filter transaction.Filter
+	Top    int
+}
+
+// NetWorthParams bounds a net worth report to [From, To), optionally
+// following only some of the accounts.
+type NetWorthParams struct {
+	AccountIDs []uuid.UUID
+	From       time.Time
+	To         time.Time
+	Interval   transaction.Interval
+}
+
+type Service interface {
+	CashFlow(ctx context.Context, userID uuid.UUID, params CashFlowParams) (*transaction.CashFlow, error)
+	Breakdown(ctx context.Context, userID uuid.UUID, params BreakdownParams) (*Breakdown, error)
+	NetWorth(ctx context.Context, userID uuid.UUID, params NetWorthParams) (*NetWorth, error)
+}
@@ -0,0 +1,52 @@
+package tag
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Tag struct {
+	ID        uuid.UUID `db:"id"`
+	UserID    uuid.UUID `db:"user_id"`
+	Name      string    `db:"name"`
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+func NewTag(userID uuid.UUID, name string) *Tag {
+	return &Tag{
+		UserID: userID,
+		Name:   NormalizeName(name),
+	}
+}
+
+func (t *Tag) BelongsUser(userID uuid.UUID) bool {
+	return t.UserID == userID
+}
+
+// NormalizeName makes "Vacation 2026" and " vacation-2026 " the same tag.
+func NormalizeName(name string) string {
+	return strings.Join(strings.Fields(strings.ToLower(name)), "-")
+}
+
+// NormalizeNames normalizes and de-duplicates names, dropping empty ones.
+func NormalizeNames(names []string) []string {
+	seen := make(map[string]struct{}, len(names))
+	result := make([]string, 0, len(names))
+
+	for _, name := range names {
+		name = NormalizeName(name)
+		if name == "" {
+			continue
+		}
+		if _, ok := seen[name]; ok {
+			continue
+		}
+		seen[name] = struct{}{}
+		result = append(result, name)
+	}
+
+	return result
+}
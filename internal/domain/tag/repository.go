@@ -0,0 +1,18 @@
+package tag
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type Repository interface {
+	// Ensure returns the user's tags with the given names, creating any that
+	// do not exist yet.
+	Ensure(ctx context.Context, userID uuid.UUID, names []string) ([]*Tag, error)
+	GetByID(ctx context.Context, id uuid.UUID) (*Tag, error)
+	GetByUserID(ctx context.Context, userID uuid.UUID) ([]*Tag, error)
+	Update(ctx context.Context, tag *Tag) error
+	Merge(ctx context.Context, fromID, intoID uuid.UUID) error
+	Delete(ctx context.Context, userID, id uuid.UUID) error
+}
@@ -0,0 +1,14 @@
+package tag
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type Service interface {
+	List(ctx context.Context, userID uuid.UUID) ([]*Tag, error)
+	Rename(ctx context.Context, userID, id uuid.UUID, name string) error
+	Merge(ctx context.Context, userID, fromID, intoID uuid.UUID) error
+	Delete(ctx context.Context, userID, id uuid.UUID) error
+}
@@ -0,0 +1,172 @@
+package transaction
+
+import (
+	"math"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	// DuplicateWindow is how many days apart two entries may be dated and
+	// still be the same transaction; card payments often post a few days
+	// after the purchase.
+	DuplicateWindow = 4
+	// DuplicateThreshold is the lowest score reported as a likely duplicate.
+	DuplicateThreshold = 0.6
+	// DuplicateLookback is how far back suspected duplicates are searched
+	// when no start date is given.
+	DuplicateLookback = 90 * 24 * time.Hour
+)
+
+// Candidate is what the duplicate detector compares: a stored transaction
+// or a statement line that has not been imported yet. Amount is signed.
+type Candidate struct {
+	AccountID   uuid.UUID
+	Amount      decimal.Decimal
+	OccurredAt  time.Time
+	Description string
+	ExternalID  string
+}
+
+func (t *Transaction) Candidate() Candidate {
+	c := Candidate{
+		AccountID:   t.AccountID,
+		Amount:      t.SignedAmount(),
+		OccurredAt:  t.OccurredAt,
+		Description: t.Description,
+	}
+	if t.ExternalID != nil {
+		c.ExternalID = *t.ExternalID
+	}
+	return c
+}
+
+// DuplicatePair is a suspected duplicate: Duplicate was recorded after
+// Original and probably describes the same money movement.
+type DuplicatePair struct {
+	Original  *Transaction
+	Duplicate *Transaction
+	Score     float64
+}
+
+type DuplicateFilter struct {
+	UserID    uuid.UUID
+	AccountID *uuid.UUID
+	From      *time.Time
+}
+
+// DuplicateScore rates from 0 to 1 how likely two entries are the same
+// transaction. Entries of different accounts or amounts, or dated further
+// apart than DuplicateWindow, never match. Matching external IDs are
+// conclusive either way: the bank gives every entry its own.
+func DuplicateScore(a, b Candidate) float64 {
+	if a.AccountID != b.AccountID || !a.Amount.Equal(b.Amount) {
+		return 0
+	}
+
+	if a.ExternalID != "" && b.ExternalID != "" {
+		if a.ExternalID == b.ExternalID {
+			return 1
+		}
+		return 0
+	}
+
+	days := math.Abs(dateOf(a.OccurredAt).Sub(dateOf(b.OccurredAt)).Hours()) / 24
+	if days > DuplicateWindow {
+		return 0
+	}
+
+	proximity := 1 - days/(DuplicateWindow+1)
+
+	return 0.3 + 0.4*proximity + 0.3*similarity(a.Description, b.Description)
+}
+
+func dateOf(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// similarity compares two descriptions after dropping case, punctuation and
+// numbers such as card or reference numbers. A description whose words all
+// appear in the other counts as a full match, so "Starbucks" matches
+// "STARBUCKS STORE 1234 SEATTLE"; otherwise the bigram overlap is used.
+func similarity(a, b string) float64 {
+	wordsA, wordsB := words(a), words(b)
+	if len(wordsA) == 0 || len(wordsB) == 0 {
+		return 0
+	}
+
+	if len(wordsA) > len(wordsB) {
+		wordsA, wordsB = wordsB, wordsA
+	}
+
+	set := make(map[string]struct{}, len(wordsB))
+	for _, w := range wordsB {
+		set[w] = struct{}{}
+	}
+
+	contained := 0
+	for _, w := range wordsA {
+		if _, ok := set[w]; ok {
+			contained++
+		}
+	}
+	if contained == len(wordsA) {
+		return 1
+	}
+
+	return dice(strings.Join(wordsA, " "), strings.Join(wordsB, " "))
+}
+
+func words(s string) []string {
+	fields := strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	var words []string
+	for _, f := range fields {
+		if strings.IndexFunc(f, unicode.IsLetter) >= 0 {
+			words = append(words, f)
+		}
+	}
+	return words
+}
+
+// dice is the Sørensen–Dice coefficient of the character bigrams.
+func dice(a, b string) float64 {
+	bigramsA, bigramsB := bigrams(a), bigrams(b)
+	if len(bigramsA) == 0 || len(bigramsB) == 0 {
+		return 0
+	}
+
+	counts := make(map[string]int, len(bigramsB))
+	for _, g := range bigramsB {
+		counts[g]++
+	}
+
+	shared := 0
+	for _, g := range bigramsA {
+		if counts[g] > 0 {
+			counts[g]--
+			shared++
+		}
+	}
+
+	return 2 * float64(shared) / float64(len(bigramsA)+len(bigramsB))
+}
+
+func bigrams(s string) []string {
+	runes := []rune(s)
+	if len(runes) < 2 {
+		return nil
+	}
+
+	grams := make([]string, 0, len(runes)-1)
+	for i := 0; i < len(runes)-1; i++ {
+		grams = append(grams, string(runes[i:i+2]))
+	}
+	return grams
+}
@@ -4,42 +4,184 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	apperror "github.com/nontypeable/financial-tracker/internal/errors"
 	"github.com/shopspring/decimal"
 )
 
+// TrashRetention is how long a soft-deleted transaction stays restorable.
+const TrashRetention = 30 * 24 * time.Hour
+
 type TransactionType string
 
 const (
-	Income  TransactionType = "income"
-	Expense TransactionType = "expense"
+	Income   TransactionType = "income"
+	Expense  TransactionType = "expense"
+	Transfer TransactionType = "transfer"
+)
+
+type Status string
+
+const (
+	Pending    Status = "pending"
+	Cleared    Status = "cleared"
+	Reconciled Status = "reconciled"
 )
 
+type Split struct {
+	ID         uuid.UUID
+	CategoryID *uuid.UUID
+	Amount     decimal.Decimal
+	Memo       string
+}
+
 type Transaction struct {
 	ID          uuid.UUID
 	AccountID   uuid.UUID
 	Amount      decimal.Decimal
 	Type        TransactionType
 	Description string
-	CreatedAt   time.Time  `db:"created_at"`
-	UpdatedAt   time.Time  `db:"updated_at"`
-	DeletedAt   *time.Time `db:"deleted_at"`
+	Status      Status
+	// ReconciliationID is set once the transaction is reconciled.
+	ReconciliationID *uuid.UUID `db:"reconciliation_id"`
+	CategoryID       *uuid.UUID `db:"category_id"`
+	TransferID       *uuid.UUID `db:"transfer_id"`
+	// RecurringRuleID and OccurrenceDate are set on rows posted by a
+	// recurring rule; together they identify the scheduled occurrence.
+	RecurringRuleID *uuid.UUID `db:"recurring_rule_id"`
+	OccurrenceDate  *time.Time `db:"occurrence_date"`
+	// ExternalID is the bank's identifier for an imported transaction and
+	// is unique per account.
+	ExternalID *string `db:"external_id"`
+	// OccurredAt is the value date of the transaction; CreatedAt and
+	// UpdatedAt only record when the row was written.
+	OccurredAt time.Time  `db:"occurred_at"`
+	Tags       []string   `db:"-"`
+	Splits     []Split    `db:"-"`
+	CreatedAt  time.Time  `db:"created_at"`
+	UpdatedAt  time.Time  `db:"updated_at"`
+	DeletedAt  *time.Time `db:"deleted_at"`
 }
 
-func NewTransaction(accountID uuid.UUID, amount decimal.Decimal, transactionType TransactionType, description string) *Transaction {
+func NewTransaction(accountID uuid.UUID, amount decimal.Decimal, transactionType TransactionType, description string, categoryID *uuid.UUID) *Transaction {
 	return &Transaction{
 		AccountID:   accountID,
 		Amount:      amount,
 		Type:        transactionType,
 		Description: description,
+		CategoryID:  categoryID,
+		Status:      Pending,
+		OccurredAt:  time.Now(),
 	}
 }
 
+// NewTransferLegs builds the two linked rows of a transfer. Unlike income and
+// expense rows, transfer legs carry a signed amount: the outgoing leg is
+// negative and the incoming leg is positive.
+func NewTransferLegs(fromAccountID, toAccountID uuid.UUID, amount decimal.Decimal, description string) (*Transaction, *Transaction) {
+	transferID := uuid.New()
+	occurredAt := time.Now()
+
+	outgoing := &Transaction{
+		AccountID:   fromAccountID,
+		Amount:      amount.Neg(),
+		Type:        Transfer,
+		Description: description,
+		TransferID:  &transferID,
+		Status:      Pending,
+		OccurredAt:  occurredAt,
+	}
+
+	incoming := &Transaction{
+		AccountID:   toAccountID,
+		Amount:      amount,
+		Type:        Transfer,
+		Description: description,
+		TransferID:  &transferID,
+		Status:      Pending,
+		OccurredAt:  occurredAt,
+	}
+
+	return outgoing, incoming
+}
+
+// ValidateSplits checks that split lines, if any, are positive and add up
+// exactly to the transaction amount.
+func (t *Transaction) ValidateSplits() error {
+	if len(t.Splits) == 0 {
+		return nil
+	}
+
+	if t.IsTransfer() || t.CategoryID != nil {
+		return apperror.ErrInvalidInput
+	}
+
+	total := decimal.Zero
+	for _, split := range t.Splits {
+		if !split.Amount.IsPositive() {
+			return apperror.ErrInvalidInput
+		}
+		total = total.Add(split.Amount)
+	}
+
+	if !total.Equal(t.Amount) {
+		return apperror.ErrSplitsMismatch
+	}
+
+	return nil
+}
+
+// IsReconciled reports whether the transaction has been locked by a
+// completed reconciliation.
+func (t *Transaction) IsReconciled() bool {
+	return t.Status == Reconciled
+}
+
+func (t *Transaction) IsTransfer() bool {
+	return t.Type == Transfer
+}
+
+func (t *Transaction) SignedAmount() decimal.Decimal {
+	if t.Type == Expense {
+		return t.Amount.Neg()
+	}
+	return t.Amount
+}
+
+// Absorb fills in what the transaction lacks from a duplicate of it: the
+// category or splits, the description and the cleared status. It reports
+// whether anything changed.
+func (t *Transaction) Absorb(duplicate *Transaction) bool {
+	changed := false
+
+	if t.CategoryID == nil && len(t.Splits) == 0 && (duplicate.CategoryID != nil || len(duplicate.Splits) > 0) {
+		t.CategoryID = duplicate.CategoryID
+		t.Splits = duplicate.Splits
+		changed = true
+	}
+
+	if t.Description == "" && duplicate.Description != "" {
+		t.Description = duplicate.Description
+		changed = true
+	}
+
+	if t.Status == Pending && duplicate.Status == Cleared {
+		t.Status = Cleared
+		changed = true
+	}
+
+	return changed
+}
+
 func (t *Transaction) Delete() {
 	now := time.Now()
 	t.DeletedAt = &now
 	t.UpdatedAt = now
 }
 
+func (t *Transaction) Restorable(now time.Time) bool {
+	return t.DeletedAt != nil && now.Sub(*t.DeletedAt) <= TrashRetention
+}
+
 func (t *Transaction) Restore() {
 	t.DeletedAt = nil
 	t.UpdatedAt = time.Now()
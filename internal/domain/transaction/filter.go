@@ -0,0 +1,102 @@
+package transaction
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+type SortField string
+
+const (
+	SortByDate   SortField = "date"
+	SortByAmount SortField = "amount"
+)
+
+type SortOrder string
+
+const (
+	Ascending  SortOrder = "asc"
+	Descending SortOrder = "desc"
+)
+
+const (
+	DefaultListLimit = 50
+	MaxListLimit     = 200
+)
+
+// Filter selects transactions. CategoryIDs match a transaction when its
+// category, or the category of any of its split lines, is one of them or
+// a subcategory of one, the same way budgets cover their subcategories.
+// AmountMin, AmountMax and sorting by amount use the unsigned amount, so
+// both legs of a transfer match the same bounds.
+type Filter struct {
+	UserID      uuid.UUID
+	AccountIDs  []uuid.UUID
+	CategoryIDs []uuid.UUID
+	TagIDsAny   []uuid.UUID
+	TagIDsAll   []uuid.UUID
+	Type        *TransactionType
+	Statuses    []Status
+	From        *time.Time
+	To          *time.Time
+	AmountMin   *decimal.Decimal
+	AmountMax   *decimal.Decimal
+	Description string
+	SortBy      SortField
+	Order       SortOrder
+	Cursor      *Cursor
+	Limit       int
+}
+
+// Cursor points at the last row of a page: the value of the sort column
+// and the row ID used as a tie-breaker.
+type Cursor struct {
+	Value string    `json:"v"`
+	ID    uuid.UUID `json:"id"`
+}
+
+func NewCursor(t *Transaction, sortBy SortField) *Cursor {
+	if sortBy == SortByAmount {
+		return &Cursor{Value: t.Amount.Abs().String(), ID: t.ID}
+	}
+	return &Cursor{Value: t.OccurredAt.UTC().Format(time.RFC3339Nano), ID: t.ID}
+}
+
+func (c *Cursor) Encode() string {
+	raw, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+func DecodeCursor(s string) (*Cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("decode cursor: %w", err)
+	}
+
+	var c Cursor
+	if err := json.Unmarshal(raw, &c); err != nil {
+		return nil, fmt.Errorf("unmarshal cursor: %w", err)
+	}
+
+	return &c, nil
+}
+
+func (f *Filter) Normalize() {
+	if f.SortBy == "" {
+		f.SortBy = SortByDate
+	}
+	if f.Order == "" {
+		f.Order = Descending
+	}
+	if f.Limit <= 0 {
+		f.Limit = DefaultListLimit
+	}
+	if f.Limit > MaxListLimit {
+		f.Limit = MaxListLimit
+	}
+}
@@ -0,0 +1,77 @@
+package transaction
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// Interval is the length of the periods a report groups transactions by.
+// Weeks start on Monday; all periods are in UTC.
+type Interval string
+
+const (
+	Daily   Interval = "day"
+	Weekly  Interval = "week"
+	Monthly Interval = "month"
+	Yearly  Interval = "year"
+)
+
+// CashFlowPeriod totals the income and expenses of one period. Transfers
+// move money between the user's own accounts and are left out.
+type CashFlowPeriod struct {
+	Start   time.Time
+	Income  decimal.Decimal
+	Expense decimal.Decimal
+	Net     decimal.Decimal
+}
+
+// CashFlow lists every period of a date range, empty ones included, with
+// the totals of the whole range.
+type CashFlow struct {
+	Interval Interval
+	Periods  []CashFlowPeriod
+	Income   decimal.Decimal
+	Expense  decimal.Decimal
+	Net      decimal.Decimal
+}
+
+// CategoryTotal is what a category took in a period and in the period
+// before it, its subcategories included. CategoryID is nil for the
+// uncategorized remainder.
+type CategoryTotal struct {
+	CategoryID *uuid.UUID
+	ParentID   *uuid.UUID
+	Name       string
+	Current    decimal.Decimal
+	Previous   decimal.Decimal
+}
+
+// CategoryBreakdown lists the category totals of a period and the period
+// before it, largest first, with the overall totals. Split lines count
+// towards their own categories.
+type CategoryBreakdown struct {
+	Current    decimal.Decimal
+	Previous   decimal.Decimal
+	Categories []CategoryTotal
+}
+
+// DescriptionTotal groups transactions by description, ignoring case and
+// surrounding spaces. Description is one of the spellings seen.
+type DescriptionTotal struct {
+	Description string
+	Count       int
+	Current     decimal.Decimal
+	Previous    decimal.Decimal
+}
+
+// AccountBalance is an account's balance at AsOf, the end of the period
+// starting at Start: its opening balance netted with every transaction that
+// occurred before AsOf.
+type AccountBalance struct {
+	AccountID uuid.UUID
+	Start     time.Time
+	AsOf      time.Time
+	Balance   decimal.Decimal
+}
@@ -2,14 +2,93 @@ package transaction
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
 )
 
 type Repository interface {
 	Create(ctx context.Context, transaction *Transaction) (uuid.UUID, error)
 	GetByID(ctx context.Context, id uuid.UUID) (*Transaction, error)
+	GetByIDForUpdate(ctx context.Context, id uuid.UUID) (*Transaction, error)
+	GetDeletedByIDForUpdate(ctx context.Context, id uuid.UUID) (*Transaction, error)
+	GetByTransferID(ctx context.Context, transferID uuid.UUID) ([]*Transaction, error)
+	GetByTransferIDForUpdate(ctx context.Context, transferID uuid.UUID) ([]*Transaction, error)
 	GetByAccountID(ctx context.Context, accountID uuid.UUID) ([]*Transaction, error)
+	// List returns up to filter.Limit+1 rows so callers can tell whether
+	// another page follows.
+	List(ctx context.Context, filter *Filter) ([]*Transaction, error)
+	// Stream calls fn for every transaction matching the filter, ignoring
+	// its cursor and limit. Rows are read off the connection as fn consumes
+	// them, so memory use does not grow with the result; an error from fn
+	// stops the query.
+	Stream(ctx context.Context, filter *Filter, fn func(*Transaction) error) error
+	// CashFlow totals the income and expenses matching the filter per
+	// period between its From and To, which must both be set. Sorting,
+	// cursor and limit are ignored.
+	CashFlow(ctx context.Context, filter *Filter, interval Interval) (*CashFlow, error)
+	// CategoryBreakdown totals the transactions matching the filter per
+	// category, rolled up through parent categories, for the period from
+	// the filter's From to its To and for the period from previousFrom to
+	// From. Split lines count towards their own categories and, when the
+	// filter has categories, only lines in them count. Sorting, cursor and
+	// limit are ignored.
+	CategoryBreakdown(ctx context.Context, filter *Filter, previousFrom time.Time) (*CategoryBreakdown, error)
+	// TopDescriptions returns the limit descriptions with the largest total
+	// in the filter's period, with their totals in the period from
+	// previousFrom to the filter's From, counting split lines the same way
+	// as CategoryBreakdown. Blank descriptions are left out.
+	TopDescriptions(ctx context.Context, filter *Filter, previousFrom time.Time, limit int) ([]*DescriptionTotal, error)
+	// BalanceHistory returns the balance of each of the user's accounts at
+	// the end of every period between from and to, the last period ending
+	// at to. It is limited to the given accounts when any are set.
+	BalanceHistory(ctx context.Context, userID uuid.UUID, accountIDs []uuid.UUID, from, to time.Time, interval Interval) ([]*AccountBalance, error)
+	ListDeleted(ctx context.Context, userID uuid.UUID, since time.Time) ([]*Transaction, error)
+	// HasTransfers reports whether the account holds a leg of a transfer
+	// that has not been deleted.
+	HasTransfers(ctx context.Context, accountID uuid.UUID) (bool, error)
+	// SumByAccountID nets the account's transactions, limited to those that
+	// occurred before the given time when it is set.
+	SumByAccountID(ctx context.Context, accountID uuid.UUID, before *time.Time) (decimal.Decimal, error)
+	// SumClearedByAccountIDs nets cleared and reconciled transactions per
+	// account, limited to those that occurred before the given time when it
+	// is set. Accounts without such transactions are absent from the map.
+	SumClearedByAccountIDs(ctx context.Context, accountIDs []uuid.UUID, before *time.Time) (map[uuid.UUID]decimal.Decimal, error)
+	// GetUnreconciled lists the account's pending and cleared transactions
+	// that occurred before the given time, oldest first.
+	GetUnreconciled(ctx context.Context, accountID uuid.UUID, before time.Time) ([]*Transaction, error)
+	// GetFirstDates returns when the earliest transaction of each of the
+	// user's accounts and categories occurred, keyed by account or category
+	// ID. Split lines count towards their own categories.
+	GetFirstDates(ctx context.Context, userID uuid.UUID) (map[uuid.UUID]time.Time, error)
+	// GetExternalIDs returns which of the given external IDs are already
+	// used by transactions of the account, deleted ones included.
+	GetExternalIDs(ctx context.Context, accountID uuid.UUID, externalIDs []string) (map[string]struct{}, error)
+	// GetByAccountIDBetween lists the account's transactions that occurred
+	// in [from, to), oldest first.
+	GetByAccountIDBetween(ctx context.Context, accountID uuid.UUID, from, to time.Time) ([]*Transaction, error)
+	// FindDuplicates pairs up the user's income and expense transactions of
+	// the same account and amount dated at most DuplicateWindow days apart.
+	// Pairs whose external IDs differ are left out; scoring is up to the
+	// caller.
+	FindDuplicates(ctx context.Context, filter *DuplicateFilter) ([]*DuplicatePair, error)
 	Update(ctx context.Context, transaction *Transaction) error
+	SetExternalID(ctx context.Context, id uuid.UUID, externalID *string) error
+	// SetStatus moves unreconciled transactions of the account that
+	// occurred before the given time between pending and cleared and
+	// returns the number of rows changed.
+	SetStatus(ctx context.Context, accountID uuid.UUID, ids []uuid.UUID, before time.Time, status Status) (int64, error)
+	// Reconcile locks every cleared transaction of the account that occurred
+	// before the given time under the reconciliation.
+	Reconcile(ctx context.Context, accountID uuid.UUID, before time.Time, reconciliationID uuid.UUID) (int64, error)
 	Delete(ctx context.Context, accountID, id uuid.UUID) error
+	Restore(ctx context.Context, accountID, id uuid.UUID) error
+	ReplaceSplits(ctx context.Context, transactionID uuid.UUID, splits []Split) error
+	AddTags(ctx context.Context, transactionIDs, tagIDs []uuid.UUID) error
+	RemoveTags(ctx context.Context, transactionID uuid.UUID, tagIDs []uuid.UUID) error
+	// AddTagsByFilter tags every transaction matching the filter, ignoring
+	// its sorting, cursor and limit, and returns the number of new links.
+	AddTagsByFilter(ctx context.Context, filter *Filter, tagIDs []uuid.UUID) (int64, error)
+	ReassignCategory(ctx context.Context, fromCategoryID uuid.UUID, toCategoryID *uuid.UUID) error
 }
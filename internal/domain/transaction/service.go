@@ -2,11 +2,64 @@ package transaction
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/shopspring/decimal"
 )
 
+type CreateParams struct {
+	AccountID   uuid.UUID
+	Amount      decimal.Decimal
+	Type        TransactionType
+	Description string
+	CategoryID  *uuid.UUID
+	Tags        []string
+	Splits      []Split
+	// OccurredAt defaults to the time of creation when nil.
+	OccurredAt *time.Time
+	// Status defaults to pending; reconciled is not accepted.
+	Status Status
+	// RecurringRuleID and OccurrenceDate are set only by the recurring
+	// rule materializer.
+	RecurringRuleID *uuid.UUID
+	OccurrenceDate  *time.Time
+	// ExternalID is set only by statement imports.
+	ExternalID *string
+}
+
+type UpdateParams struct {
+	Amount      *decimal.Decimal
+	Type        *TransactionType
+	Description *string
+	OccurredAt  *time.Time
+	// Status applies to the addressed row only, never to the other leg of
+	// a transfer; reconciled is not accepted.
+	Status        *Status
+	CategoryID    *uuid.UUID
+	ClearCategory bool
+	// Splits replaces the split lines when non-nil; an empty slice removes them.
+	Splits *[]Split
+}
+
 type Service interface {
-	Create(ctx context.Context, accountID uuid.UUID, amount decimal.Decimal, transactionType TransactionType, description string) (uuid.UUID, error)
+	Create(ctx context.Context, userID uuid.UUID, params CreateParams) (uuid.UUID, error)
+	GetByID(ctx context.Context, userID, id uuid.UUID) (*Transaction, error)
+	List(ctx context.Context, userID uuid.UUID, filter Filter) ([]*Transaction, *Cursor, error)
+	Update(ctx context.Context, userID, id uuid.UUID, params UpdateParams) error
+	Delete(ctx context.Context, userID, id uuid.UUID) error
+	// Restore brings back a deleted transaction within TrashRetention. It
+	// is refused for transactions dated within a completed reconciliation.
+	Restore(ctx context.Context, userID, id uuid.UUID) error
+	ListDeleted(ctx context.Context, userID uuid.UUID) ([]*Transaction, error)
+	AddTags(ctx context.Context, userID, id uuid.UUID, names []string) error
+	RemoveTag(ctx context.Context, userID, id, tagID uuid.UUID) error
+	BulkTag(ctx context.Context, userID uuid.UUID, filter Filter, names []string) (int64, error)
+	// ListDuplicates returns suspected duplicates scoring at least
+	// DuplicateThreshold, most likely first.
+	ListDuplicates(ctx context.Context, userID uuid.UUID, filter DuplicateFilter) ([]*DuplicatePair, error)
+	// Merge folds the duplicate into the transaction and deletes it. The
+	// kept row takes over the duplicate's external ID, tags, and its
+	// category or splits when it has none of its own.
+	Merge(ctx context.Context, userID, id, duplicateID uuid.UUID) (*Transaction, error)
 }
@@ -0,0 +1,38 @@
+package transfer
+
+import (
+	"github.com/google/uuid"
+	"github.com/nontypeable/financial-tracker/internal/domain/transaction"
+	"github.com/shopspring/decimal"
+)
+
+type Transfer struct {
+	ID       uuid.UUID
+	Outgoing *transaction.Transaction
+	Incoming *transaction.Transaction
+}
+
+func NewTransfer(legs []*transaction.Transaction) (*Transfer, bool) {
+	if len(legs) != 2 || legs[0].TransferID == nil {
+		return nil, false
+	}
+
+	t := &Transfer{ID: *legs[0].TransferID}
+	for _, leg := range legs {
+		if leg.Amount.IsNegative() {
+			t.Outgoing = leg
+		} else {
+			t.Incoming = leg
+		}
+	}
+
+	if t.Outgoing == nil || t.Incoming == nil {
+		return nil, false
+	}
+
+	return t, true
+}
+
+func (t *Transfer) Amount() decimal.Decimal {
+	return t.Incoming.Amount
+}
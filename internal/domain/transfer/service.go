@@ -0,0 +1,26 @@
+package transfer
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nontypeable/financial-tracker/internal/domain/transaction"
+	"github.com/shopspring/decimal"
+)
+
+type CreateParams struct {
+	FromAccountID uuid.UUID
+	ToAccountID   uuid.UUID
+	Amount        decimal.Decimal
+	Description   string
+	// OccurredAt defaults to the time of creation when nil.
+	OccurredAt *time.Time
+	// Status applies to both legs and defaults to pending.
+	Status transaction.Status
+}
+
+type Service interface {
+	Create(ctx context.Context, userID uuid.UUID, params CreateParams) (uuid.UUID, error)
+	GetByID(ctx context.Context, userID, id uuid.UUID) (*Transfer, error)
+}
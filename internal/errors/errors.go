@@ -13,6 +13,9 @@ var (
 	ErrUserNotFound       = errors.New("user not found")
 	ErrInvalidCredentials = errors.New("invalid credentials")
 
+	// Access-related errors
+	ErrForbidden = errors.New("access is forbidden")
+
 	// Token-related errors
 	ErrTokenIsEmpty         = errors.New("token is empty")
 	ErrInvalidToken         = errors.New("invalid token")
@@ -21,10 +24,58 @@ var (
 	ErrInvalidTokenLifetime = errors.New("token TTL must be positive")
 
 	// Account-related errors
-	ErrAccountNotFound = errors.New("account is not found")
+	ErrAccountNotFound     = errors.New("account is not found")
+	ErrAccountHasTransfers = errors.New("account has transfers to or from other accounts")
 
 	// Transaction-related errors
-	ErrTransactionNotFound = errors.New("transaction is not found")
+	ErrTransactionNotFound      = errors.New("transaction is not found")
+	ErrTransactionNotRestorable = errors.New("transaction is past its restore window")
+	ErrSplitsMismatch           = errors.New("splits do not add up to the transaction amount")
+	ErrTransactionLocked        = errors.New("transaction is reconciled and locked")
+	ErrTransactionImported      = errors.New("transaction is already imported")
+	ErrTransactionsNotMergeable = errors.New("transactions differ in account, type or amount")
+
+	// Transfer-related errors
+	ErrTransferNotFound    = errors.New("transfer is not found")
+	ErrTransferSameAccount = errors.New("transfer source and destination must differ")
+
+	// Category-related errors
+	ErrCategoryNotFound      = errors.New("category is not found")
+	ErrCategoryAlreadyExists = errors.New("category already exists")
+	ErrCategoryArchived      = errors.New("category is archived")
+	ErrCategoryTypeMismatch  = errors.New("category type does not match")
+	ErrCategoryCycle         = errors.New("category cannot be nested under itself")
+
+	// Tag-related errors
+	ErrTagNotFound      = errors.New("tag is not found")
+	ErrTagAlreadyExists = errors.New("tag already exists")
+
+	// Reconciliation-related errors
+	ErrReconciliationNotFound   = errors.New("reconciliation is not found")
+	ErrReconciliationInProgress = errors.New("account already has a reconciliation in progress")
+	ErrReconciliationCompleted  = errors.New("reconciliation is already completed")
+	ErrReconciliationUnbalanced = errors.New("cleared balance does not match the statement balance")
+
+	// Budget-related errors
+	ErrBudgetNotFound      = errors.New("budget is not found")
+	ErrBudgetAlreadyExists = errors.New("category is already budgeted for this month")
+	ErrAssignmentNotFound  = errors.New("envelope assignment is not found")
+	ErrEnvelopeSameSource  = errors.New("money must move between two different envelopes")
+
+	// Goal-related errors
+	ErrGoalNotFound = errors.New("goal is not found")
+
+	// Import-related errors
+	ErrImportProfileNotFound      = errors.New("import profile is not found")
+	ErrImportProfileAlreadyExists = errors.New("import profile already exists")
+	ErrImportBatchNotFound        = errors.New("import batch is not found")
+	ErrImportAlreadyCommitted     = errors.New("import batch is already committed")
+	ErrStatementMalformed         = errors.New("statement file is malformed")
+
+	// Recurring-related errors
+	ErrRecurringRuleNotFound   = errors.New("recurring rule is not found")
+	ErrOccurrenceNotScheduled  = errors.New("date is not an upcoming occurrence of the rule")
+	ErrOccurrenceAlreadyPosted = errors.New("occurrence is already posted")
 
 	// Request-related errors
 	ErrNilResponseWriter      = errors.New("response writer is nil")
@@ -20,6 +20,10 @@ func MapAppErrorToHTTP(err error) (status int, message string) {
 	case errors.Is(err, apperror.ErrInvalidCredentials):
 		return http.StatusUnauthorized, "invalid credentials"
 
+	// Access
+	case errors.Is(err, apperror.ErrForbidden):
+		return http.StatusForbidden, "access forbidden"
+
 	// Validation
 	case errors.Is(err, apperror.ErrInvalidInput), errors.Is(err, apperror.ErrValidationFailed):
 		return http.StatusBadRequest, "invalid input"
@@ -33,10 +37,90 @@ func MapAppErrorToHTTP(err error) (status int, message string) {
 	// Account
 	case errors.Is(err, apperror.ErrAccountNotFound):
 		return http.StatusNotFound, "account not found"
+	case errors.Is(err, apperror.ErrAccountHasTransfers):
+		return http.StatusConflict, "account has transfers; delete them first"
 
 	// Transactions
 	case errors.Is(err, apperror.ErrTransactionNotFound):
 		return http.StatusNotFound, "transaction not found"
+	case errors.Is(err, apperror.ErrTransactionNotRestorable):
+		return http.StatusConflict, "transaction can no longer be restored"
+	case errors.Is(err, apperror.ErrSplitsMismatch):
+		return http.StatusBadRequest, "splits must add up to the transaction amount"
+	case errors.Is(err, apperror.ErrTransactionLocked):
+		return http.StatusConflict, "transaction is reconciled and locked"
+	case errors.Is(err, apperror.ErrTransactionImported):
+		return http.StatusConflict, "transaction is already imported"
+	case errors.Is(err, apperror.ErrTransactionsNotMergeable):
+		return http.StatusBadRequest, "only transactions of the same account, type and amount can be merged"
+
+	// Transfers
+	case errors.Is(err, apperror.ErrTransferNotFound):
+		return http.StatusNotFound, "transfer not found"
+	case errors.Is(err, apperror.ErrTransferSameAccount):
+		return http.StatusBadRequest, "transfer source and destination must differ"
+
+	// Categories
+	case errors.Is(err, apperror.ErrCategoryNotFound):
+		return http.StatusNotFound, "category not found"
+	case errors.Is(err, apperror.ErrCategoryAlreadyExists):
+		return http.StatusConflict, "category already exists"
+	case errors.Is(err, apperror.ErrCategoryArchived):
+		return http.StatusConflict, "category is archived"
+	case errors.Is(err, apperror.ErrCategoryTypeMismatch):
+		return http.StatusBadRequest, "category type does not match"
+	case errors.Is(err, apperror.ErrCategoryCycle):
+		return http.StatusBadRequest, "category cannot be nested under itself"
+
+	// Tags
+	case errors.Is(err, apperror.ErrTagNotFound):
+		return http.StatusNotFound, "tag not found"
+	case errors.Is(err, apperror.ErrTagAlreadyExists):
+		return http.StatusConflict, "tag already exists"
+
+	// Reconciliations
+	case errors.Is(err, apperror.ErrReconciliationNotFound):
+		return http.StatusNotFound, "reconciliation not found"
+	case errors.Is(err, apperror.ErrReconciliationInProgress):
+		return http.StatusConflict, "account already has a reconciliation in progress"
+	case errors.Is(err, apperror.ErrReconciliationCompleted):
+		return http.StatusConflict, "reconciliation is already completed"
+	case errors.Is(err, apperror.ErrReconciliationUnbalanced):
+		return http.StatusConflict, "cleared balance does not match the statement balance"
+
+	// Budgets
+	case errors.Is(err, apperror.ErrBudgetNotFound):
+		return http.StatusNotFound, "budget not found"
+	case errors.Is(err, apperror.ErrBudgetAlreadyExists):
+		return http.StatusConflict, "category is already budgeted for this month"
+	case errors.Is(err, apperror.ErrAssignmentNotFound):
+		return http.StatusNotFound, "envelope assignment not found"
+	case errors.Is(err, apperror.ErrEnvelopeSameSource):
+		return http.StatusBadRequest, "money must move between two different envelopes"
+
+	// Goals
+	case errors.Is(err, apperror.ErrGoalNotFound):
+		return http.StatusNotFound, "goal not found"
+
+	// Imports
+	case errors.Is(err, apperror.ErrImportProfileNotFound):
+		return http.StatusNotFound, "import profile not found"
+	case errors.Is(err, apperror.ErrImportProfileAlreadyExists):
+		return http.StatusConflict, "import profile already exists"
+	case errors.Is(err, apperror.ErrImportBatchNotFound):
+		return http.StatusNotFound, "import batch not found"
+	case errors.Is(err, apperror.ErrImportAlreadyCommitted):
+		return http.StatusConflict, "import batch is already committed"
+	case errors.Is(err, apperror.ErrStatementMalformed):
+		return http.StatusBadRequest, "statement file could not be parsed"
+
+	// Recurring rules
+	case errors.Is(err, apperror.ErrRecurringRuleNotFound):
+		return http.StatusNotFound, "recurring rule not found"
+	case errors.Is(err, apperror.ErrOccurrenceNotScheduled):
+		return http.StatusBadRequest, "date is not an upcoming occurrence of the rule"
+	case errors.Is(err, apperror.ErrOccurrenceAlreadyPosted):
+		return http.StatusConflict, "occurrence is already posted"
 
 	// Request or Technical
 	case errors.Is(err, apperror.ErrNilRequest),
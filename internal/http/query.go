@@ -0,0 +1,130 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	apperror "github.com/nontypeable/financial-tracker/internal/errors"
+	"github.com/shopspring/decimal"
+)
+
+type QueryBindable interface {
+	BindQuery(values url.Values) error
+}
+
+func DecodeQueryAndValidate[T any](r *http.Request, dest *T) error {
+	if r == nil {
+		return apperror.ErrNilRequest
+	}
+
+	if dest == nil {
+		return apperror.ErrNilDestination
+	}
+
+	if b, ok := any(dest).(QueryBindable); ok {
+		if err := b.BindQuery(r.URL.Query()); err != nil {
+			return fmt.Errorf("%w: %v", apperror.ErrInvalidInput, err)
+		}
+	}
+
+	if v, ok := any(dest).(Validable); ok {
+		if err := v.Validate(); err != nil {
+			return fmt.Errorf("%w: %v", apperror.ErrValidationFailed, err)
+		}
+	}
+
+	return nil
+}
+
+// QueryUUIDs accepts both repeated keys (?id=a&id=b) and comma-separated values.
+func QueryUUIDs(values url.Values, key string) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+
+	for _, raw := range values[key] {
+		for _, part := range strings.Split(raw, ",") {
+			part = strings.TrimSpace(part)
+			if part == "" {
+				continue
+			}
+
+			id, err := uuid.Parse(part)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", key, err)
+			}
+			ids = append(ids, id)
+		}
+	}
+
+	return ids, nil
+}
+
+// QueryTime accepts RFC 3339 timestamps and plain dates. A plain date used as
+// an upper bound is moved to the start of the next day so the range includes it.
+func QueryTime(values url.Values, key string, upperBound bool) (*time.Time, error) {
+	raw := values.Get(key)
+	if raw == "" {
+		return nil, nil
+	}
+
+	if t, err := time.Parse(time.RFC3339, raw); err == nil {
+		return &t, nil
+	}
+
+	t, err := time.Parse(time.DateOnly, raw)
+	if err != nil {
+		return nil, fmt.Errorf("%s: expected RFC 3339 timestamp or YYYY-MM-DD date", key)
+	}
+
+	if upperBound {
+		t = t.AddDate(0, 0, 1)
+	}
+
+	return &t, nil
+}
+
+func QueryDecimal(values url.Values, key string) (*decimal.Decimal, error) {
+	raw := values.Get(key)
+	if raw == "" {
+		return nil, nil
+	}
+
+	d, err := decimal.NewFromString(raw)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", key, err)
+	}
+
+	return &d, nil
+}
+
+func QueryInt(values url.Values, key string) (int, error) {
+	raw := values.Get(key)
+	if raw == "" {
+		return 0, nil
+	}
+
+	n, err := strconv.Atoi(raw)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", key, err)
+	}
+
+	return n, nil
+}
+
+func QueryBool(values url.Values, key string) (bool, error) {
+	raw := values.Get(key)
+	if raw == "" {
+		return false, nil
+	}
+
+	b, err := strconv.ParseBool(raw)
+	if err != nil {
+		return false, fmt.Errorf("%s: %w", key, err)
+	}
+
+	return b, nil
+}
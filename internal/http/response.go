@@ -9,8 +9,9 @@ import (
 )
 
 type SuccessResponse struct {
-	StatusCode int `json:"status_code"`
-	Data       any `json:"data,omitempty"`
+	StatusCode int    `json:"status_code"`
+	Data       any    `json:"data,omitempty"`
+	NextCursor string `json:"next_cursor,omitempty"`
 }
 
 type ErrorResponse struct {
@@ -25,6 +26,14 @@ func JSON(w http.ResponseWriter, statusCode int, data any) error {
 	})
 }
 
+func Paginated(w http.ResponseWriter, statusCode int, data any, nextCursor string) error {
+	return writeJSON(w, statusCode, SuccessResponse{
+		StatusCode: statusCode,
+		Data:       data,
+		NextCursor: nextCursor,
+	})
+}
+
 func Error(w http.ResponseWriter, statusCode int, msg string) error {
 	return writeJSON(w, statusCode, ErrorResponse{
 		StatusCode: statusCode,
@@ -12,6 +12,7 @@ import (
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/nontypeable/financial-tracker/internal/domain/account"
 	apperror "github.com/nontypeable/financial-tracker/internal/errors"
+	"github.com/nontypeable/financial-tracker/internal/transactor"
 )
 
 type repository struct {
@@ -24,17 +25,19 @@ func NewRepository(pool *pgxpool.Pool) account.Repository {
 
 func (r *repository) Create(ctx context.Context, account *account.Account) (uuid.UUID, error) {
 	query := `
-		INSERT INTO accounts (user_id, name, balance)
-		VALUES ($1, $2, $3)
+		INSERT INTO accounts (user_id, name, kind, balance, opening_balance)
+		VALUES ($1, $2, $3, $4, $5)
 		RETURNING id;
 	`
 
 	var id uuid.UUID
 
-	err := r.pool.QueryRow(ctx, query,
+	err := transactor.Conn(ctx, r.pool).QueryRow(ctx, query,
 		account.UserID,
 		account.Name,
+		account.Kind,
 		account.Balance,
+		account.OpeningBalance,
 	).Scan(&id)
 
 	if err != nil {
@@ -53,41 +56,82 @@ func (r *repository) Create(ctx context.Context, account *account.Account) (uuid
 
 func (r *repository) GetByID(ctx context.Context, id uuid.UUID) (*account.Account, error) {
 	query := `
-		SELECT id, user_id, name, balance, created_at, updated_at, deleted_at
+		SELECT id, user_id, name, kind, balance, opening_balance, created_at, updated_at, deleted_at
 		FROM accounts
 		WHERE id = $1 AND deleted_at IS NULL
 	`
 
-	var a account.Account
+	a, err := scanAccount(transactor.Conn(ctx, r.pool).QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperror.ErrAccountNotFound
+		}
+		return nil, fmt.Errorf("get account by id: %w", err)
+	}
 
-	err := r.pool.QueryRow(ctx, query, id).Scan(
-		&a.ID,
-		&a.UserID,
-		&a.Name,
-		&a.Balance,
-		&a.CreatedAt,
-		&a.UpdatedAt,
-		&a.DeletedAt,
-	)
+	return a, nil
+}
 
+func (r *repository) GetByIDForUpdate(ctx context.Context, id uuid.UUID) (*account.Account, error) {
+	query := `
+		SELECT id, user_id, name, kind, balance, opening_balance, created_at, updated_at, deleted_at
+		FROM accounts
+		WHERE id = $1 AND deleted_at IS NULL
+		FOR UPDATE
+	`
+
+	a, err := scanAccount(transactor.Conn(ctx, r.pool).QueryRow(ctx, query, id))
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, apperror.ErrAccountNotFound
 		}
-		return nil, fmt.Errorf("get account by id: %w", err)
+		return nil, fmt.Errorf("get account by id for update: %w", err)
 	}
 
-	return &a, nil
+	return a, nil
+}
+
+func (r *repository) GetByIDsForUpdate(ctx context.Context, ids []uuid.UUID) ([]*account.Account, error) {
+	query := `
+		SELECT id, user_id, name, kind, balance, opening_balance, created_at, updated_at, deleted_at
+		FROM accounts
+		WHERE id = ANY($1) AND deleted_at IS NULL
+		ORDER BY id
+		FOR UPDATE
+	`
+
+	rows, err := transactor.Conn(ctx, r.pool).Query(ctx, query, ids)
+	if err != nil {
+		return nil, fmt.Errorf("get accounts by ids for update: %w", err)
+	}
+	defer rows.Close()
+
+	var accounts []*account.Account
+
+	for rows.Next() {
+		a, err := scanAccount(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan account row: %w", err)
+		}
+		accounts = append(accounts, a)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate account rows: %w", err)
+	}
+
+	return accounts, nil
 }
 
 func (r *repository) GetByUserID(ctx context.Context, userID uuid.UUID) ([]*account.Account, error) {
 	query := `
-		SELECT id, user_id, name, balance, created_at, updated_at, deleted_at
+		SELECT id, user_id, name, kind, balance, opening_balance, created_at, updated_at, deleted_at
 		FROM accounts
 		WHERE user_id = $1 AND deleted_at IS NULL
+		ORDER BY created_at
 	`
 
-	rows, err := r.pool.Query(ctx, query, userID)
+	rows, err := transactor.Conn(ctx, r.pool).Query(ctx, query, userID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get accounts by user id: %w", err)
 	}
@@ -96,20 +140,15 @@ func (r *repository) GetByUserID(ctx context.Context, userID uuid.UUID) ([]*acco
 	var accounts []*account.Account
 
 	for rows.Next() {
-		var a account.Account
-		err := rows.Scan(
-			&a.ID,
-			&a.UserID,
-			&a.Name,
-			&a.Balance,
-			&a.CreatedAt,
-			&a.UpdatedAt,
-			&a.DeletedAt,
-		)
+		a, err := scanAccount(rows)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan account row: %w", err)
 		}
-		accounts = append(accounts, &a)
+		accounts = append(accounts, a)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate account rows: %w", err)
 	}
 
 	return accounts, nil
@@ -119,21 +158,23 @@ func (r *repository) Update(ctx context.Context, account *account.Account) error
 	query := `
 		UPDATE accounts
 		SET name = $1,
-		    balance = $2,
+		    kind = $2,
+		    balance = $3,
 		    updated_at = NOW()
-		WHERE id = $3 AND deleted_at IS NULL
+		WHERE id = $4 AND deleted_at IS NULL
 		RETURNING updated_at
 	`
 
-	err := r.pool.QueryRow(ctx, query,
+	err := transactor.Conn(ctx, r.pool).QueryRow(ctx, query,
 		account.Name,
+		account.Kind,
 		account.Balance,
 		account.ID,
 	).Scan(&account.UpdatedAt)
 
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			return apperror.ErrInvalidInput
+			return apperror.ErrAccountNotFound
 		}
 
 		var pgErr *pgconn.PgError
@@ -157,14 +198,35 @@ func (r *repository) Delete(ctx context.Context, userID, id uuid.UUID) error {
 		WHERE id = $1 AND user_id = $2 AND deleted_at IS NULL
 	`
 
-	ct, err := r.pool.Exec(ctx, query, id, userID)
+	ct, err := transactor.Conn(ctx, r.pool).Exec(ctx, query, id, userID)
 	if err != nil {
 		return fmt.Errorf("failed to soft-delete account: %w", err)
 	}
 
 	if ct.RowsAffected() == 0 {
-		return errors.New("no account found to delete")
+		return apperror.ErrAccountNotFound
 	}
 
 	return nil
 }
+
+func scanAccount(row pgx.Row) (*account.Account, error) {
+	var a account.Account
+
+	err := row.Scan(
+		&a.ID,
+		&a.UserID,
+		&a.Name,
+		&a.Kind,
+		&a.Balance,
+		&a.OpeningBalance,
+		&a.CreatedAt,
+		&a.UpdatedAt,
+		&a.DeletedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &a, nil
+}
@@ -0,0 +1,480 @@
+package budget
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/nontypeable/financial-tracker/internal/domain/budget"
+	apperror "github.com/nontypeable/financial-tracker/internal/errors"
+	"github.com/nontypeable/financial-tracker/internal/transactor"
+)
+
+type repository struct {
+	pool *pgxpool.Pool
+}
+
+func NewRepository(pool *pgxpool.Pool) budget.Repository {
+	return &repository{pool: pool}
+}
+
+func (r *repository) Create(ctx context.Context, budget *budget.Budget) (uuid.UUID, error) {
+	query := `
+		INSERT INTO budgets (user_id, category_id, month, amount, rollover)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id;
+	`
+
+	var id uuid.UUID
+	err := transactor.Conn(ctx, r.pool).QueryRow(ctx, query,
+		budget.UserID,
+		budget.CategoryID,
+		budget.Month,
+		budget.Amount,
+		budget.Rollover,
+	).Scan(&id)
+
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			switch pgErr.Code {
+			case pgerrcode.UniqueViolation:
+				return uuid.Nil, apperror.ErrBudgetAlreadyExists
+			case pgerrcode.NotNullViolation, pgerrcode.CheckViolation:
+				return uuid.Nil, apperror.ErrInvalidInput
+			case pgerrcode.ForeignKeyViolation:
+				return uuid.Nil, apperror.ErrCategoryNotFound
+			}
+		}
+		return uuid.Nil, fmt.Errorf("create budget: %w", err)
+	}
+
+	return id, nil
+}
+
+func (r *repository) GetByID(ctx context.Context, id uuid.UUID) (*budget.Budget, error) {
+	query := `
+		SELECT id, user_id, category_id, month, amount, rollover, created_at, updated_at
+		FROM budgets
+		WHERE id = $1
+	`
+
+	b, err := scanBudget(transactor.Conn(ctx, r.pool).QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperror.ErrBudgetNotFound
+		}
+		return nil, fmt.Errorf("get budget by id: %w", err)
+	}
+
+	return b, nil
+}
+
+func (r *repository) Update(ctx context.Context, budget *budget.Budget) error {
+	query := `
+		UPDATE budgets
+		SET amount = $1, rollover = $2, updated_at = NOW()
+		WHERE id = $3
+		RETURNING updated_at
+	`
+
+	err := transactor.Conn(ctx, r.pool).QueryRow(ctx, query,
+		budget.Amount,
+		budget.Rollover,
+		budget.ID,
+	).Scan(&budget.UpdatedAt)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return apperror.ErrBudgetNotFound
+		}
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.CheckViolation {
+			return apperror.ErrInvalidInput
+		}
+		return fmt.Errorf("update budget: %w", err)
+	}
+
+	return nil
+}
+
+func (r *repository) Delete(ctx context.Context, id uuid.UUID) error {
+	query := `
+		DELETE FROM budgets
+		WHERE id = $1
+	`
+
+	result, err := transactor.Conn(ctx, r.pool).Exec(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("delete budget: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return apperror.ErrBudgetNotFound
+	}
+
+	return nil
+}
+
+func (r *repository) GetActuals(ctx context.Context, userID uuid.UUID, month time.Time) ([]*budget.Actual, error) {
+	// covered walks down from each budget's category, stopping at
+	// subcategories budgeted in the same month so no expense counts twice.
+	query := `
+		WITH RECURSIVE budgeted AS (
+			SELECT b.id, b.user_id, b.category_id, b.month, b.amount, b.rollover, b.created_at, b.updated_at
+			FROM budgets b
+			JOIN categories c ON c.id = b.category_id
+			WHERE b.user_id = $1 AND b.month <= $2 AND c.deleted_at IS NULL
+			  AND b.category_id IN (SELECT category_id FROM budgets WHERE user_id = $1 AND month = $2)
+		),
+		covered AS (
+			SELECT b.id AS budget_id, b.month, b.category_id
+			FROM budgeted b
+			UNION ALL
+			SELECT cv.budget_id, cv.month, c.id
+			FROM covered cv
+			JOIN categories c ON c.parent_id = cv.category_id
+			WHERE NOT EXISTS (
+				SELECT 1 FROM budgets o WHERE o.category_id = c.id AND o.month = cv.month
+			)
+		),
+		lines AS (
+			SELECT COALESCE(s.category_id, t.category_id) AS category_id,
+			       COALESCE(s.amount, t.amount) AS amount,
+			       t.occurred_at
+			FROM transactions t
+			JOIN accounts a ON a.id = t.account_id
+			LEFT JOIN transaction_splits s ON s.transaction_id = t.id
+			WHERE a.user_id = $1 AND a.deleted_at IS NULL AND t.deleted_at IS NULL
+			  AND t.type = 'expense'
+			  AND t.occurred_at < ($2::date + INTERVAL '1 month')::timestamp AT TIME ZONE 'UTC'
+		)
+		SELECT b.id, b.user_id, b.category_id, b.month, b.amount, b.rollover, b.created_at, b.updated_at,
+		       COALESCE(SUM(l.amount), 0)
+		FROM budgeted b
+		JOIN covered cv ON cv.budget_id = b.id
+		LEFT JOIN lines l ON l.category_id = cv.category_id
+		  AND l.occurred_at >= b.month::timestamp AT TIME ZONE 'UTC'
+		  AND l.occurred_at < (b.month + INTERVAL '1 month')::timestamp AT TIME ZONE 'UTC'
+		GROUP BY b.id, b.user_id, b.category_id, b.month, b.amount, b.rollover, b.created_at, b.updated_at
+		ORDER BY b.category_id, b.month
+	`
+
+	rows, err := transactor.Conn(ctx, r.pool).Query(ctx, query, userID, month)
+	if err != nil {
+		return nil, fmt.Errorf("get budget actuals: %w", err)
+	}
+	defer rows.Close()
+
+	var actuals []*budget.Actual
+	for rows.Next() {
+		var actual budget.Actual
+		actual.Budget = &budget.Budget{}
+
+		err := rows.Scan(
+			&actual.ID,
+			&actual.UserID,
+			&actual.CategoryID,
+			&actual.Month,
+			&actual.Amount,
+			&actual.Rollover,
+			&actual.CreatedAt,
+			&actual.UpdatedAt,
+			&actual.Spent,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("scan budget row: %w", err)
+		}
+		actuals = append(actuals, &actual)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate budget rows: %w", err)
+	}
+
+	return actuals, nil
+}
+
+func (r *repository) CopyMonth(ctx context.Context, userID uuid.UUID, from, to time.Time) (int64, error) {
+	query := `
+		INSERT INTO budgets (user_id, category_id, month, amount, rollover)
+		SELECT b.user_id, b.category_id, $3, b.amount, b.rollover
+		FROM budgets b
+		JOIN categories c ON c.id = b.category_id
+		WHERE b.user_id = $1 AND b.month = $2
+		  AND c.archived_at IS NULL AND c.deleted_at IS NULL
+		ON CONFLICT (category_id, month) DO NOTHING
+	`
+
+	result, err := transactor.Conn(ctx, r.pool).Exec(ctx, query, userID, from, to)
+	if err != nil {
+		return 0, fmt.Errorf("copy budgets: %w", err)
+	}
+
+	return result.RowsAffected(), nil
+}
+
+func (r *repository) CreateAssignment(ctx context.Context, assignment *budget.Assignment) (uuid.UUID, error) {
+	query := `
+		INSERT INTO envelope_assignments (user_id, category_id, month, amount, move_id, note)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id;
+	`
+
+	var id uuid.UUID
+	err := transactor.Conn(ctx, r.pool).QueryRow(ctx, query,
+		assignment.UserID,
+		assignment.CategoryID,
+		assignment.Month,
+		assignment.Amount,
+		assignment.MoveID,
+		assignment.Note,
+	).Scan(&id)
+
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			switch pgErr.Code {
+			case pgerrcode.NotNullViolation, pgerrcode.CheckViolation:
+				return uuid.Nil, apperror.ErrInvalidInput
+			case pgerrcode.ForeignKeyViolation:
+				return uuid.Nil, apperror.ErrCategoryNotFound
+			}
+		}
+		return uuid.Nil, fmt.Errorf("create envelope assignment: %w", err)
+	}
+
+	return id, nil
+}
+
+func (r *repository) GetAssignmentByID(ctx context.Context, id uuid.UUID) (*budget.Assignment, error) {
+	query := `
+		SELECT id, user_id, category_id, month, amount, move_id, note, created_at
+		FROM envelope_assignments
+		WHERE id = $1
+	`
+
+	a, err := scanAssignment(transactor.Conn(ctx, r.pool).QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperror.ErrAssignmentNotFound
+		}
+		return nil, fmt.Errorf("get envelope assignment by id: %w", err)
+	}
+
+	return a, nil
+}
+
+func (r *repository) GetAssignments(ctx context.Context, userID uuid.UUID, month time.Time) ([]*budget.Assignment, error) {
+	query := `
+		SELECT id, user_id, category_id, month, amount, move_id, note, created_at
+		FROM envelope_assignments
+		WHERE user_id = $1 AND month = $2
+		ORDER BY created_at, id
+	`
+
+	rows, err := transactor.Conn(ctx, r.pool).Query(ctx, query, userID, month)
+	if err != nil {
+		return nil, fmt.Errorf("get envelope assignments: %w", err)
+	}
+	defer rows.Close()
+
+	var assignments []*budget.Assignment
+	for rows.Next() {
+		a, err := scanAssignment(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan envelope assignment row: %w", err)
+		}
+		assignments = append(assignments, a)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate envelope assignment rows: %w", err)
+	}
+
+	return assignments, nil
+}
+
+func (r *repository) DeleteAssignment(ctx context.Context, id uuid.UUID) error {
+	query := `
+		DELETE FROM envelope_assignments
+		WHERE id = $1
+		   OR move_id = (SELECT move_id FROM envelope_assignments WHERE id = $1)
+	`
+
+	result, err := transactor.Conn(ctx, r.pool).Exec(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("delete envelope assignment: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return apperror.ErrAssignmentNotFound
+	}
+
+	return nil
+}
+
+func (r *repository) GetEnvelopeActivity(ctx context.Context, userID uuid.UUID, month time.Time) ([]*budget.EnvelopeActivity, error) {
+	query := `
+		WITH RECURSIVE envelopes AS (
+			SELECT e.category_id, MIN(e.month) AS start
+			FROM envelope_assignments e
+			JOIN categories c ON c.id = e.category_id
+			WHERE e.user_id = $1 AND e.month <= $2 AND c.deleted_at IS NULL
+			GROUP BY e.category_id
+		),
+		covered AS (
+			SELECT e.category_id AS envelope_id, e.category_id, e.start
+			FROM envelopes e
+			UNION ALL
+			SELECT cv.envelope_id, c.id, cv.start
+			FROM covered cv
+			JOIN categories c ON c.parent_id = cv.category_id
+			WHERE c.id NOT IN (SELECT category_id FROM envelopes)
+		),
+		lines AS (
+			SELECT COALESCE(s.category_id, t.category_id) AS category_id,
+			       COALESCE(s.amount, t.amount) AS amount,
+			       t.occurred_at
+			FROM transactions t
+			JOIN accounts a ON a.id = t.account_id
+			LEFT JOIN transaction_splits s ON s.transaction_id = t.id
+			WHERE a.user_id = $1 AND a.deleted_at IS NULL AND t.deleted_at IS NULL
+			  AND t.type = 'expense'
+			  AND t.occurred_at < ($2::date + INTERVAL '1 month')::timestamp AT TIME ZONE 'UTC'
+		),
+		spent AS (
+			SELECT cv.envelope_id,
+			       SUM(l.amount) FILTER (WHERE l.occurred_at < $2::timestamp AT TIME ZONE 'UTC') AS before,
+			       SUM(l.amount) FILTER (WHERE l.occurred_at >= $2::timestamp AT TIME ZONE 'UTC') AS during
+			FROM covered cv
+			JOIN lines l ON l.category_id = cv.category_id
+			  AND l.occurred_at >= cv.start::timestamp AT TIME ZONE 'UTC'
+			GROUP BY cv.envelope_id
+		),
+		assigned AS (
+			SELECT category_id,
+			       SUM(amount) FILTER (WHERE month < $2) AS before,
+			       SUM(amount) FILTER (WHERE month = $2) AS during
+			FROM envelope_assignments
+			WHERE user_id = $1 AND month <= $2
+			GROUP BY category_id
+		)
+		SELECT e.category_id,
+		       COALESCE(a.before, 0), COALESCE(a.during, 0),
+		       COALESCE(s.before, 0), COALESCE(s.during, 0)
+		FROM envelopes e
+		LEFT JOIN assigned a ON a.category_id = e.category_id
+		LEFT JOIN spent s ON s.envelope_id = e.category_id
+		ORDER BY e.start, e.category_id
+	`
+
+	rows, err := transactor.Conn(ctx, r.pool).Query(ctx, query, userID, month)
+	if err != nil {
+		return nil, fmt.Errorf("get envelope activity: %w", err)
+	}
+	defer rows.Close()
+
+	var activity []*budget.EnvelopeActivity
+	for rows.Next() {
+		var a budget.EnvelopeActivity
+		if err := rows.Scan(&a.CategoryID, &a.AssignedBefore, &a.Assigned, &a.SpentBefore, &a.Spent); err != nil {
+			return nil, fmt.Errorf("scan envelope activity row: %w", err)
+		}
+		activity = append(activity, &a)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate envelope activity rows: %w", err)
+	}
+
+	return activity, nil
+}
+
+func (r *repository) GetPoolActivity(ctx context.Context, userID uuid.UUID, month time.Time) (*budget.PoolActivity, error) {
+	query := `
+		WITH start AS (
+			SELECT LEAST(COALESCE(MIN(month), $2::date), $2::date) AS month
+			FROM envelope_assignments
+			WHERE user_id = $1
+		),
+		income AS (
+			SELECT COALESCE(SUM(t.amount) FILTER (WHERE t.occurred_at < $2::timestamp AT TIME ZONE 'UTC'), 0) AS before,
+			       COALESCE(SUM(t.amount) FILTER (WHERE t.occurred_at >= $2::timestamp AT TIME ZONE 'UTC'), 0) AS during
+			FROM transactions t
+			JOIN accounts a ON a.id = t.account_id
+			CROSS JOIN start
+			WHERE a.user_id = $1 AND a.deleted_at IS NULL AND t.deleted_at IS NULL
+			  AND t.type = 'income'
+			  AND t.occurred_at >= start.month::timestamp AT TIME ZONE 'UTC'
+			  AND t.occurred_at < ($2::date + INTERVAL '1 month')::timestamp AT TIME ZONE 'UTC'
+		),
+		assigned AS (
+			SELECT COALESCE(SUM(amount) FILTER (WHERE month < $2), 0) AS before,
+			       COALESCE(SUM(amount) FILTER (WHERE month = $2), 0) AS during
+			FROM envelope_assignments
+			WHERE user_id = $1 AND month <= $2
+		)
+		SELECT income.before, income.during, assigned.before, assigned.during
+		FROM income, assigned
+	`
+
+	var pool budget.PoolActivity
+	err := transactor.Conn(ctx, r.pool).QueryRow(ctx, query, userID, month).Scan(
+		&pool.IncomeBefore,
+		&pool.Income,
+		&pool.AssignedBefore,
+		&pool.Assigned,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("get pool activity: %w", err)
+	}
+
+	return &pool, nil
+}
+
+func scanBudget(row pgx.Row) (*budget.Budget, error) {
+	var b budget.Budget
+
+	err := row.Scan(
+		&b.ID,
+		&b.UserID,
+		&b.CategoryID,
+		&b.Month,
+		&b.Amount,
+		&b.Rollover,
+		&b.CreatedAt,
+		&b.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &b, nil
+}
+
+func scanAssignment(row pgx.Row) (*budget.Assignment, error) {
+	var a budget.Assignment
+
+	err := row.Scan(
+		&a.ID,
+		&a.UserID,
+		&a.CategoryID,
+		&a.Month,
+		&a.Amount,
+		&a.MoveID,
+		&a.Note,
+		&a.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &a, nil
+}
@@ -0,0 +1,203 @@
+package category
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/nontypeable/financial-tracker/internal/domain/category"
+	apperror "github.com/nontypeable/financial-tracker/internal/errors"
+	"github.com/nontypeable/financial-tracker/internal/transactor"
+)
+
+type repository struct {
+	pool *pgxpool.Pool
+}
+
+func NewRepository(pool *pgxpool.Pool) category.Repository {
+	return &repository{pool: pool}
+}
+
+func (r *repository) Create(ctx context.Context, category *category.Category) (uuid.UUID, error) {
+	query := `
+		INSERT INTO categories (user_id, parent_id, name, type)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id;
+	`
+
+	var id uuid.UUID
+
+	err := transactor.Conn(ctx, r.pool).QueryRow(ctx, query,
+		category.UserID,
+		category.ParentID,
+		category.Name,
+		category.Type,
+	).Scan(&id)
+
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			switch pgErr.Code {
+			case pgerrcode.UniqueViolation:
+				return uuid.Nil, apperror.ErrCategoryAlreadyExists
+			case pgerrcode.NotNullViolation, pgerrcode.CheckViolation:
+				return uuid.Nil, apperror.ErrInvalidInput
+			case pgerrcode.ForeignKeyViolation:
+				return uuid.Nil, apperror.ErrCategoryNotFound
+			}
+		}
+		return uuid.Nil, fmt.Errorf("create category: %w", err)
+	}
+
+	return id, nil
+}
+
+func (r *repository) GetByID(ctx context.Context, id uuid.UUID) (*category.Category, error) {
+	query := `
+		SELECT id, user_id, parent_id, name, type, archived_at, created_at, updated_at, deleted_at
+		FROM categories
+		WHERE id = $1 AND deleted_at IS NULL
+	`
+
+	c, err := scanCategory(transactor.Conn(ctx, r.pool).QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperror.ErrCategoryNotFound
+		}
+		return nil, fmt.Errorf("get category by id: %w", err)
+	}
+
+	return c, nil
+}
+
+func (r *repository) GetByUserID(ctx context.Context, userID uuid.UUID, includeArchived bool) ([]*category.Category, error) {
+	query := `
+		SELECT id, user_id, parent_id, name, type, archived_at, created_at, updated_at, deleted_at
+		FROM categories
+		WHERE user_id = $1 AND deleted_at IS NULL AND ($2 OR archived_at IS NULL)
+		ORDER BY type, name
+	`
+
+	rows, err := transactor.Conn(ctx, r.pool).Query(ctx, query, userID, includeArchived)
+	if err != nil {
+		return nil, fmt.Errorf("get categories by user id: %w", err)
+	}
+	defer rows.Close()
+
+	var categories []*category.Category
+	for rows.Next() {
+		c, err := scanCategory(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan category row: %w", err)
+		}
+		categories = append(categories, c)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate category rows: %w", err)
+	}
+
+	return categories, nil
+}
+
+func (r *repository) Update(ctx context.Context, category *category.Category) error {
+	query := `
+		UPDATE categories
+		SET parent_id = $1,
+		    name = $2,
+		    archived_at = $3,
+		    updated_at = NOW()
+		WHERE id = $4 AND deleted_at IS NULL
+		RETURNING updated_at
+	`
+
+	err := transactor.Conn(ctx, r.pool).QueryRow(ctx, query,
+		category.ParentID,
+		category.Name,
+		category.ArchivedAt,
+		category.ID,
+	).Scan(&category.UpdatedAt)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return apperror.ErrCategoryNotFound
+		}
+
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			switch pgErr.Code {
+			case pgerrcode.UniqueViolation:
+				return apperror.ErrCategoryAlreadyExists
+			case pgerrcode.NotNullViolation, pgerrcode.CheckViolation:
+				return apperror.ErrInvalidInput
+			}
+		}
+
+		return fmt.Errorf("update category: %w", err)
+	}
+
+	return nil
+}
+
+func (r *repository) Delete(ctx context.Context, userID, id uuid.UUID) error {
+	query := `
+		UPDATE categories
+		SET deleted_at = NOW(), updated_at = NOW()
+		WHERE id = $1 AND user_id = $2 AND deleted_at IS NULL
+	`
+
+	result, err := transactor.Conn(ctx, r.pool).Exec(ctx, query, id, userID)
+	if err != nil {
+		return fmt.Errorf("delete category: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return apperror.ErrCategoryNotFound
+	}
+
+	return nil
+}
+
+func (r *repository) Reparent(ctx context.Context, fromParentID uuid.UUID, toParentID *uuid.UUID) error {
+	query := `
+		UPDATE categories
+		SET parent_id = $2, updated_at = NOW()
+		WHERE parent_id = $1 AND deleted_at IS NULL
+	`
+
+	if _, err := transactor.Conn(ctx, r.pool).Exec(ctx, query, fromParentID, toParentID); err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.UniqueViolation {
+			return apperror.ErrCategoryAlreadyExists
+		}
+		return fmt.Errorf("reparent categories: %w", err)
+	}
+
+	return nil
+}
+
+func scanCategory(row pgx.Row) (*category.Category, error) {
+	var c category.Category
+
+	err := row.Scan(
+		&c.ID,
+		&c.UserID,
+		&c.ParentID,
+		&c.Name,
+		&c.Type,
+		&c.ArchivedAt,
+		&c.CreatedAt,
+		&c.UpdatedAt,
+		&c.DeletedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &c, nil
+}
@@ -0,0 +1,245 @@
+package goal
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/nontypeable/financial-tracker/internal/domain/goal"
+	apperror "github.com/nontypeable/financial-tracker/internal/errors"
+	"github.com/nontypeable/financial-tracker/internal/transactor"
+	"github.com/shopspring/decimal"
+)
+
+const selectColumns = `g.id, g.user_id, g.name, g.target_amount, g.target_date,
+		g.archived_at, g.created_at, g.updated_at,
+		COALESCE((
+			SELECT json_agg(json_build_object(
+				'account_id', ga.account_id, 'share', ga.share::text
+			) ORDER BY ga.account_id)
+			FROM goal_accounts ga WHERE ga.goal_id = g.id
+		), '[]')`
+
+type linkRow struct {
+	AccountID uuid.UUID       `json:"account_id"`
+	Share     decimal.Decimal `json:"share"`
+}
+
+type repository struct {
+	pool *pgxpool.Pool
+}
+
+func NewRepository(pool *pgxpool.Pool) goal.Repository {
+	return &repository{pool: pool}
+}
+
+func (r *repository) Create(ctx context.Context, goal *goal.Goal) (uuid.UUID, error) {
+	query := `
+		INSERT INTO goals (user_id, name, target_amount, target_date)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id;
+	`
+
+	var id uuid.UUID
+	err := transactor.Conn(ctx, r.pool).QueryRow(ctx, query,
+		goal.UserID,
+		goal.Name,
+		goal.TargetAmount,
+		goal.TargetDate,
+	).Scan(&id)
+
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			switch pgErr.Code {
+			case pgerrcode.NotNullViolation, pgerrcode.CheckViolation:
+				return uuid.Nil, apperror.ErrInvalidInput
+			}
+		}
+		return uuid.Nil, fmt.Errorf("create goal: %w", err)
+	}
+
+	return id, nil
+}
+
+func (r *repository) GetByID(ctx context.Context, id uuid.UUID) (*goal.Goal, error) {
+	query := `
+		SELECT ` + selectColumns + `
+		FROM goals g
+		WHERE g.id = $1
+	`
+
+	g, err := scanGoal(transactor.Conn(ctx, r.pool).QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperror.ErrGoalNotFound
+		}
+		return nil, fmt.Errorf("get goal by id: %w", err)
+	}
+
+	return g, nil
+}
+
+func (r *repository) GetByUserID(ctx context.Context, userID uuid.UUID, includeArchived bool) ([]*goal.Goal, error) {
+	query := `
+		SELECT ` + selectColumns + `
+		FROM goals g
+		WHERE g.user_id = $1 AND ($2 OR g.archived_at IS NULL)
+		ORDER BY g.target_date, g.created_at
+	`
+
+	rows, err := transactor.Conn(ctx, r.pool).Query(ctx, query, userID, includeArchived)
+	if err != nil {
+		return nil, fmt.Errorf("get goals by user id: %w", err)
+	}
+	defer rows.Close()
+
+	var goals []*goal.Goal
+	for rows.Next() {
+		g, err := scanGoal(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan goal row: %w", err)
+		}
+		goals = append(goals, g)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate goal rows: %w", err)
+	}
+
+	return goals, nil
+}
+
+func (r *repository) Update(ctx context.Context, goal *goal.Goal) error {
+	query := `
+		UPDATE goals
+		SET name = $1, target_amount = $2, target_date = $3, archived_at = $4, updated_at = NOW()
+		WHERE id = $5
+		RETURNING updated_at
+	`
+
+	err := transactor.Conn(ctx, r.pool).QueryRow(ctx, query,
+		goal.Name,
+		goal.TargetAmount,
+		goal.TargetDate,
+		goal.ArchivedAt,
+		goal.ID,
+	).Scan(&goal.UpdatedAt)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return apperror.ErrGoalNotFound
+		}
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.CheckViolation {
+			return apperror.ErrInvalidInput
+		}
+		return fmt.Errorf("update goal: %w", err)
+	}
+
+	return nil
+}
+
+func (r *repository) ReplaceAccounts(ctx context.Context, goalID uuid.UUID, accounts []goal.Link) error {
+	conn := transactor.Conn(ctx, r.pool)
+
+	if _, err := conn.Exec(ctx, `DELETE FROM goal_accounts WHERE goal_id = $1`, goalID); err != nil {
+		return fmt.Errorf("delete goal accounts: %w", err)
+	}
+
+	query := `
+		INSERT INTO goal_accounts (goal_id, account_id, share)
+		VALUES ($1, $2, $3)
+	`
+
+	for _, link := range accounts {
+		if _, err := conn.Exec(ctx, query, goalID, link.AccountID, link.Share); err != nil {
+			var pgErr *pgconn.PgError
+			if errors.As(err, &pgErr) {
+				switch pgErr.Code {
+				case pgerrcode.UniqueViolation, pgerrcode.CheckViolation, pgerrcode.NumericValueOutOfRange:
+					return apperror.ErrInvalidInput
+				case pgerrcode.ForeignKeyViolation:
+					return apperror.ErrAccountNotFound
+				}
+			}
+			return fmt.Errorf("insert goal account: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (r *repository) SumContributions(ctx context.Context, goalIDs []uuid.UUID, from, to time.Time) (map[uuid.UUID]decimal.Decimal, error) {
+	query := `
+		SELECT ga.goal_id, SUM(ga.share * CASE WHEN t.type = 'expense' THEN -t.amount ELSE t.amount END)
+		FROM goal_accounts ga
+		JOIN transactions t ON t.account_id = ga.account_id
+		WHERE ga.goal_id = ANY($1) AND t.deleted_at IS NULL
+		  AND t.occurred_at >= $2 AND t.occurred_at < $3
+		GROUP BY ga.goal_id
+	`
+
+	rows, err := transactor.Conn(ctx, r.pool).Query(ctx, query, goalIDs, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("sum goal contributions: %w", err)
+	}
+	defer rows.Close()
+
+	sums := make(map[uuid.UUID]decimal.Decimal)
+	for rows.Next() {
+		var id uuid.UUID
+		var sum decimal.Decimal
+		if err := rows.Scan(&id, &sum); err != nil {
+			return nil, fmt.Errorf("scan goal contribution row: %w", err)
+		}
+		sums[id] = sum
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate goal contribution rows: %w", err)
+	}
+
+	return sums, nil
+}
+
+func scanGoal(row pgx.Row) (*goal.Goal, error) {
+	var g goal.Goal
+	var accounts []byte
+
+	err := row.Scan(
+		&g.ID,
+		&g.UserID,
+		&g.Name,
+		&g.TargetAmount,
+		&g.TargetDate,
+		&g.ArchivedAt,
+		&g.CreatedAt,
+		&g.UpdatedAt,
+		&accounts,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []linkRow
+	if err := json.Unmarshal(accounts, &rows); err != nil {
+		return nil, fmt.Errorf("unmarshal goal accounts: %w", err)
+	}
+
+	for _, row := range rows {
+		g.Accounts = append(g.Accounts, goal.Link{
+			AccountID: row.AccountID,
+			Share:     row.Share,
+		})
+	}
+
+	return &g, nil
+}
@@ -0,0 +1,415 @@
+package importer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/nontypeable/financial-tracker/internal/domain/importer"
+	apperror "github.com/nontypeable/financial-tracker/internal/errors"
+	"github.com/nontypeable/financial-tracker/internal/transactor"
+	"github.com/shopspring/decimal"
+)
+
+type repository struct {
+	pool *pgxpool.Pool
+}
+
+func NewRepository(pool *pgxpool.Pool) importer.Repository {
+	return &repository{pool: pool}
+}
+
+func (r *repository) CreateProfile(ctx context.Context, profile *importer.Profile) (uuid.UUID, error) {
+	query := `
+		INSERT INTO import_profiles (user_id, name, format, settings)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id;
+	`
+
+	settings, err := json.Marshal(profile.Settings)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("marshal import profile settings: %w", err)
+	}
+
+	var id uuid.UUID
+	err = transactor.Conn(ctx, r.pool).QueryRow(ctx, query,
+		profile.UserID,
+		profile.Name,
+		profile.Format,
+		settings,
+	).Scan(&id)
+
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			switch pgErr.Code {
+			case pgerrcode.UniqueViolation:
+				return uuid.Nil, apperror.ErrImportProfileAlreadyExists
+			case pgerrcode.NotNullViolation, pgerrcode.CheckViolation:
+				return uuid.Nil, apperror.ErrInvalidInput
+			}
+		}
+		return uuid.Nil, fmt.Errorf("create import profile: %w", err)
+	}
+
+	return id, nil
+}
+
+func (r *repository) GetProfileByID(ctx context.Context, id uuid.UUID) (*importer.Profile, error) {
+	query := `
+		SELECT id, user_id, name, format, settings, created_at, updated_at
+		FROM import_profiles
+		WHERE id = $1
+	`
+
+	profile, err := scanProfile(transactor.Conn(ctx, r.pool).QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperror.ErrImportProfileNotFound
+		}
+		return nil, fmt.Errorf("get import profile by id: %w", err)
+	}
+
+	return profile, nil
+}
+
+func (r *repository) GetProfilesByUserID(ctx context.Context, userID uuid.UUID) ([]*importer.Profile, error) {
+	query := `
+		SELECT id, user_id, name, format, settings, created_at, updated_at
+		FROM import_profiles
+		WHERE user_id = $1
+		ORDER BY name
+	`
+
+	rows, err := transactor.Conn(ctx, r.pool).Query(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("get import profiles by user id: %w", err)
+	}
+	defer rows.Close()
+
+	var profiles []*importer.Profile
+	for rows.Next() {
+		profile, err := scanProfile(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan import profile row: %w", err)
+		}
+		profiles = append(profiles, profile)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate import profile rows: %w", err)
+	}
+
+	return profiles, nil
+}
+
+func (r *repository) UpdateProfile(ctx context.Context, profile *importer.Profile) error {
+	query := `
+		UPDATE import_profiles
+		SET name = $1, settings = $2, updated_at = NOW()
+		WHERE id = $3
+		RETURNING updated_at
+	`
+
+	settings, err := json.Marshal(profile.Settings)
+	if err != nil {
+		return fmt.Errorf("marshal import profile settings: %w", err)
+	}
+
+	err = transactor.Conn(ctx, r.pool).QueryRow(ctx, query,
+		profile.Name,
+		settings,
+		profile.ID,
+	).Scan(&profile.UpdatedAt)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return apperror.ErrImportProfileNotFound
+		}
+
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.UniqueViolation {
+			return apperror.ErrImportProfileAlreadyExists
+		}
+
+		return fmt.Errorf("update import profile: %w", err)
+	}
+
+	return nil
+}
+
+func (r *repository) DeleteProfile(ctx context.Context, userID, id uuid.UUID) error {
+	query := `
+		DELETE FROM import_profiles
+		WHERE id = $1 AND user_id = $2
+	`
+
+	result, err := transactor.Conn(ctx, r.pool).Exec(ctx, query, id, userID)
+	if err != nil {
+		return fmt.Errorf("delete import profile: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return apperror.ErrImportProfileNotFound
+	}
+
+	return nil
+}
+
+func (r *repository) CreateBatch(ctx context.Context, batch *importer.Batch) (uuid.UUID, error) {
+	query := `
+		INSERT INTO import_batches (user_id, account_id, format, filename, status, opening_balance, opening_date, statement_balance, statement_date)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING id;
+	`
+
+	conn := transactor.Conn(ctx, r.pool)
+
+	var id uuid.UUID
+	err := conn.QueryRow(ctx, query,
+		batch.UserID,
+		batch.AccountID,
+		batch.Format,
+		batch.Filename,
+		batch.Status,
+		batch.OpeningBalance,
+		batch.OpeningDate,
+		batch.StatementBalance,
+		batch.StatementDate,
+	).Scan(&id)
+
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			switch pgErr.Code {
+			case pgerrcode.NotNullViolation, pgerrcode.CheckViolation:
+				return uuid.Nil, apperror.ErrInvalidInput
+			case pgerrcode.ForeignKeyViolation:
+				return uuid.Nil, apperror.ErrAccountNotFound
+			}
+		}
+		return uuid.Nil, fmt.Errorf("create import batch: %w", err)
+	}
+
+	rows := make([][]any, 0, len(batch.Rows))
+	for i := range batch.Rows {
+		row := &batch.Rows[i]
+		row.ID = uuid.New()
+
+		var amount any
+		if row.IsValid() {
+			amount = row.Amount
+		}
+
+		rows = append(rows, []any{
+			row.ID, id, row.Line, row.OccurredAt, row.BookingDate, amount, row.Description,
+			nullable(row.ExternalID), nullable(row.Counterparty), nullable(row.CounterpartyAccount), nullable(row.Error),
+		})
+	}
+
+	_, err = conn.CopyFrom(ctx,
+		pgx.Identifier{"import_rows"},
+		[]string{
+			"id", "batch_id", "line", "occurred_at", "booking_date", "amount", "description",
+			"external_id", "counterparty", "counterparty_account", "error",
+		},
+		pgx.CopyFromRows(rows),
+	)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("create import rows: %w", err)
+	}
+
+	return id, nil
+}
+
+func (r *repository) GetBatchByID(ctx context.Context, id uuid.UUID) (*importer.Batch, error) {
+	return r.getBatch(ctx, id, false)
+}
+
+func (r *repository) GetBatchByIDForUpdate(ctx context.Context, id uuid.UUID) (*importer.Batch, error) {
+	return r.getBatch(ctx, id, true)
+}
+
+func (r *repository) getBatch(ctx context.Context, id uuid.UUID, forUpdate bool) (*importer.Batch, error) {
+	query := `
+		SELECT id, user_id, account_id, format, COALESCE(filename, ''), status,
+			opening_balance, opening_date, statement_balance, statement_date, committed_at, created_at, updated_at
+		FROM import_batches
+		WHERE id = $1
+	`
+	if forUpdate {
+		query += " FOR UPDATE"
+	}
+
+	conn := transactor.Conn(ctx, r.pool)
+
+	var batch importer.Batch
+	err := conn.QueryRow(ctx, query, id).Scan(
+		&batch.ID,
+		&batch.UserID,
+		&batch.AccountID,
+		&batch.Format,
+		&batch.Filename,
+		&batch.Status,
+		&batch.OpeningBalance,
+		&batch.OpeningDate,
+		&batch.StatementBalance,
+		&batch.StatementDate,
+		&batch.CommittedAt,
+		&batch.CreatedAt,
+		&batch.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperror.ErrImportBatchNotFound
+		}
+		return nil, fmt.Errorf("get import batch by id: %w", err)
+	}
+
+	rowsQuery := `
+		SELECT id, line, occurred_at, booking_date, amount, description, external_id,
+			counterparty, counterparty_account, error, transaction_id
+		FROM import_rows
+		WHERE batch_id = $1
+		ORDER BY line
+	`
+
+	rows, err := conn.Query(ctx, rowsQuery, id)
+	if err != nil {
+		return nil, fmt.Errorf("get import rows: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var row importer.Row
+		var amount decimal.NullDecimal
+		var description, externalID, counterparty, counterpartyAccount, rowErr pgtype.Text
+
+		err := rows.Scan(
+			&row.ID,
+			&row.Line,
+			&row.OccurredAt,
+			&row.BookingDate,
+			&amount,
+			&description,
+			&externalID,
+			&counterparty,
+			&counterpartyAccount,
+			&rowErr,
+			&row.TransactionID,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("scan import row: %w", err)
+		}
+
+		row.Amount = amount.Decimal
+		row.Description = description.String
+		row.ExternalID = externalID.String
+		row.Counterparty = counterparty.String
+		row.CounterpartyAccount = counterpartyAccount.String
+		row.Error = rowErr.String
+
+		batch.Rows = append(batch.Rows, row)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate import rows: %w", err)
+	}
+
+	return &batch, nil
+}
+
+func (r *repository) UpdateBatch(ctx context.Context, batch *importer.Batch) error {
+	query := `
+		UPDATE import_batches
+		SET status = $1, committed_at = $2, updated_at = NOW()
+		WHERE id = $3
+		RETURNING updated_at
+	`
+
+	err := transactor.Conn(ctx, r.pool).QueryRow(ctx, query,
+		batch.Status,
+		batch.CommittedAt,
+		batch.ID,
+	).Scan(&batch.UpdatedAt)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return apperror.ErrImportBatchNotFound
+		}
+		return fmt.Errorf("update import batch: %w", err)
+	}
+
+	return nil
+}
+
+func (r *repository) DeleteBatch(ctx context.Context, id uuid.UUID) error {
+	query := `
+		DELETE FROM import_batches
+		WHERE id = $1 AND status = 'preview'
+	`
+
+	result, err := transactor.Conn(ctx, r.pool).Exec(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("delete import batch: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return apperror.ErrImportBatchNotFound
+	}
+
+	return nil
+}
+
+func (r *repository) UpdateRow(ctx context.Context, row *importer.Row) error {
+	query := `
+		UPDATE import_rows
+		SET transaction_id = $1, error = NULLIF($2, '')
+		WHERE id = $3
+	`
+
+	if _, err := transactor.Conn(ctx, r.pool).Exec(ctx, query, row.TransactionID, row.Error, row.ID); err != nil {
+		return fmt.Errorf("update import row: %w", err)
+	}
+
+	return nil
+}
+
+func scanProfile(row pgx.Row) (*importer.Profile, error) {
+	var profile importer.Profile
+	var settings []byte
+
+	err := row.Scan(
+		&profile.ID,
+		&profile.UserID,
+		&profile.Name,
+		&profile.Format,
+		&settings,
+		&profile.CreatedAt,
+		&profile.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(settings, &profile.Settings); err != nil {
+		return nil, fmt.Errorf("unmarshal import profile settings: %w", err)
+	}
+
+	return &profile, nil
+}
+
+// nullable stores empty strings as NULL.
+func nullable(s string) any {
+	if s == "" {
+		return nil
+	}
+	return s
+}
@@ -0,0 +1,183 @@
+package reconciliation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/nontypeable/financial-tracker/internal/domain/reconciliation"
+	apperror "github.com/nontypeable/financial-tracker/internal/errors"
+	"github.com/nontypeable/financial-tracker/internal/transactor"
+)
+
+type repository struct {
+	pool *pgxpool.Pool
+}
+
+func NewRepository(pool *pgxpool.Pool) reconciliation.Repository {
+	return &repository{pool: pool}
+}
+
+func (r *repository) Create(ctx context.Context, reconciliation *reconciliation.Reconciliation) (uuid.UUID, error) {
+	query := `
+		INSERT INTO reconciliations (account_id, statement_date, statement_balance)
+		VALUES ($1, $2, $3)
+		RETURNING id;
+	`
+
+	var id uuid.UUID
+	err := transactor.Conn(ctx, r.pool).QueryRow(ctx, query,
+		reconciliation.AccountID,
+		reconciliation.StatementDate,
+		reconciliation.StatementBalance,
+	).Scan(&id)
+
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			switch pgErr.Code {
+			case pgerrcode.UniqueViolation:
+				return uuid.Nil, apperror.ErrReconciliationInProgress
+			case pgerrcode.NotNullViolation, pgerrcode.CheckViolation:
+				return uuid.Nil, apperror.ErrInvalidInput
+			case pgerrcode.ForeignKeyViolation:
+				return uuid.Nil, apperror.ErrAccountNotFound
+			}
+		}
+		return uuid.Nil, fmt.Errorf("create reconciliation: %w", err)
+	}
+
+	return id, nil
+}
+
+func (r *repository) GetByID(ctx context.Context, id uuid.UUID) (*reconciliation.Reconciliation, error) {
+	query := `
+		SELECT id, account_id, statement_date, statement_balance, completed_at, created_at, updated_at
+		FROM reconciliations
+		WHERE id = $1
+	`
+
+	rec, err := scanReconciliation(transactor.Conn(ctx, r.pool).QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperror.ErrReconciliationNotFound
+		}
+		return nil, fmt.Errorf("get reconciliation by id: %w", err)
+	}
+
+	return rec, nil
+}
+
+func (r *repository) GetByIDForUpdate(ctx context.Context, id uuid.UUID) (*reconciliation.Reconciliation, error) {
+	query := `
+		SELECT id, account_id, statement_date, statement_balance, completed_at, created_at, updated_at
+		FROM reconciliations
+		WHERE id = $1
+		FOR UPDATE
+	`
+
+	rec, err := scanReconciliation(transactor.Conn(ctx, r.pool).QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperror.ErrReconciliationNotFound
+		}
+		return nil, fmt.Errorf("lock reconciliation: %w", err)
+	}
+
+	return rec, nil
+}
+
+func (r *repository) GetByAccountID(ctx context.Context, accountID uuid.UUID) ([]*reconciliation.Reconciliation, error) {
+	query := `
+		SELECT id, account_id, statement_date, statement_balance, completed_at, created_at, updated_at
+		FROM reconciliations
+		WHERE account_id = $1
+		ORDER BY statement_date DESC, created_at DESC
+	`
+
+	rows, err := transactor.Conn(ctx, r.pool).Query(ctx, query, accountID)
+	if err != nil {
+		return nil, fmt.Errorf("get reconciliations by account id: %w", err)
+	}
+	defer rows.Close()
+
+	var reconciliations []*reconciliation.Reconciliation
+	for rows.Next() {
+		rec, err := scanReconciliation(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan reconciliation row: %w", err)
+		}
+		reconciliations = append(reconciliations, rec)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate reconciliation rows: %w", err)
+	}
+
+	return reconciliations, nil
+}
+
+func (r *repository) Update(ctx context.Context, reconciliation *reconciliation.Reconciliation) error {
+	query := `
+		UPDATE reconciliations
+		SET completed_at = $1, updated_at = NOW()
+		WHERE id = $2
+		RETURNING updated_at
+	`
+
+	err := transactor.Conn(ctx, r.pool).QueryRow(ctx, query,
+		reconciliation.CompletedAt,
+		reconciliation.ID,
+	).Scan(&reconciliation.UpdatedAt)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return apperror.ErrReconciliationNotFound
+		}
+		return fmt.Errorf("update reconciliation: %w", err)
+	}
+
+	return nil
+}
+
+func (r *repository) Delete(ctx context.Context, id uuid.UUID) error {
+	query := `
+		DELETE FROM reconciliations
+		WHERE id = $1 AND completed_at IS NULL
+	`
+
+	result, err := transactor.Conn(ctx, r.pool).Exec(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("delete reconciliation: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return apperror.ErrReconciliationNotFound
+	}
+
+	return nil
+}
+
+func scanReconciliation(row pgx.Row) (*reconciliation.Reconciliation, error) {
+	var rec reconciliation.Reconciliation
+
+	err := row.Scan(
+		&rec.ID,
+		&rec.AccountID,
+		&rec.StatementDate,
+		&rec.StatementBalance,
+		&rec.CompletedAt,
+		&rec.CreatedAt,
+		&rec.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &rec, nil
+}
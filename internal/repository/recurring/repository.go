@@ -0,0 +1,301 @@
+package recurring
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/nontypeable/financial-tracker/internal/domain/recurring"
+	apperror "github.com/nontypeable/financial-tracker/internal/errors"
+	"github.com/nontypeable/financial-tracker/internal/transactor"
+)
+
+const selectColumns = `id, user_id, account_id, amount, type, description, category_id,
+		frequency, interval_count, by_month_day, start_date, end_date, max_occurrences,
+		last_occurrence, next_occurrence, paused_at, created_at, updated_at, deleted_at`
+
+type repository struct {
+	pool *pgxpool.Pool
+}
+
+func NewRepository(pool *pgxpool.Pool) recurring.Repository {
+	return &repository{pool: pool}
+}
+
+func (r *repository) Create(ctx context.Context, rule *recurring.Rule) (uuid.UUID, error) {
+	query := `
+		INSERT INTO recurring_rules (
+			user_id, account_id, amount, type, description, category_id,
+			frequency, interval_count, by_month_day, start_date, end_date, max_occurrences,
+			next_occurrence
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+		RETURNING id;
+	`
+
+	var id uuid.UUID
+	err := transactor.Conn(ctx, r.pool).QueryRow(ctx, query,
+		rule.UserID,
+		rule.AccountID,
+		rule.Amount,
+		rule.Type,
+		rule.Description,
+		rule.CategoryID,
+		rule.Frequency,
+		rule.Interval,
+		rule.ByMonthDay,
+		rule.StartDate,
+		rule.EndDate,
+		rule.Count,
+		rule.NextOccurrence,
+	).Scan(&id)
+
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			switch pgErr.Code {
+			case pgerrcode.NotNullViolation, pgerrcode.CheckViolation:
+				return uuid.Nil, apperror.ErrInvalidInput
+			case pgerrcode.ForeignKeyViolation:
+				if pgErr.ConstraintName == "recurring_rules_category_id_fkey" {
+					return uuid.Nil, apperror.ErrCategoryNotFound
+				}
+				return uuid.Nil, apperror.ErrAccountNotFound
+			}
+		}
+		return uuid.Nil, fmt.Errorf("create recurring rule: %w", err)
+	}
+
+	return id, nil
+}
+
+func (r *repository) GetByID(ctx context.Context, id uuid.UUID) (*recurring.Rule, error) {
+	query := `
+		SELECT ` + selectColumns + `
+		FROM recurring_rules
+		WHERE id = $1 AND deleted_at IS NULL
+	`
+
+	rule, err := scanRule(transactor.Conn(ctx, r.pool).QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperror.ErrRecurringRuleNotFound
+		}
+		return nil, fmt.Errorf("get recurring rule by id: %w", err)
+	}
+
+	return rule, nil
+}
+
+func (r *repository) GetByIDForUpdate(ctx context.Context, id uuid.UUID) (*recurring.Rule, error) {
+	query := `
+		SELECT ` + selectColumns + `
+		FROM recurring_rules
+		WHERE id = $1 AND deleted_at IS NULL
+		FOR UPDATE
+	`
+
+	rule, err := scanRule(transactor.Conn(ctx, r.pool).QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperror.ErrRecurringRuleNotFound
+		}
+		return nil, fmt.Errorf("lock recurring rule: %w", err)
+	}
+
+	return rule, nil
+}
+
+func (r *repository) GetByUserID(ctx context.Context, userID uuid.UUID) ([]*recurring.Rule, error) {
+	query := `
+		SELECT ` + selectColumns + `
+		FROM recurring_rules
+		WHERE user_id = $1 AND deleted_at IS NULL
+		ORDER BY created_at
+	`
+
+	rows, err := transactor.Conn(ctx, r.pool).Query(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("get recurring rules by user id: %w", err)
+	}
+	defer rows.Close()
+
+	var rules []*recurring.Rule
+	for rows.Next() {
+		rule, err := scanRule(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan recurring rule row: %w", err)
+		}
+		rules = append(rules, rule)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate recurring rule rows: %w", err)
+	}
+
+	return rules, nil
+}
+
+func (r *repository) Update(ctx context.Context, rule *recurring.Rule) error {
+	query := `
+		UPDATE recurring_rules
+		SET last_occurrence = $1,
+		    next_occurrence = $2,
+		    paused_at = $3,
+		    updated_at = NOW()
+		WHERE id = $4 AND deleted_at IS NULL
+		RETURNING updated_at
+	`
+
+	err := transactor.Conn(ctx, r.pool).QueryRow(ctx, query,
+		rule.LastOccurrence,
+		rule.NextOccurrence,
+		rule.PausedAt,
+		rule.ID,
+	).Scan(&rule.UpdatedAt)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return apperror.ErrRecurringRuleNotFound
+		}
+		return fmt.Errorf("update recurring rule: %w", err)
+	}
+
+	return nil
+}
+
+func (r *repository) Delete(ctx context.Context, userID, id uuid.UUID) error {
+	query := `
+		UPDATE recurring_rules
+		SET deleted_at = NOW(), updated_at = NOW()
+		WHERE id = $1 AND user_id = $2 AND deleted_at IS NULL
+	`
+
+	result, err := transactor.Conn(ctx, r.pool).Exec(ctx, query, id, userID)
+	if err != nil {
+		return fmt.Errorf("delete recurring rule: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return apperror.ErrRecurringRuleNotFound
+	}
+
+	return nil
+}
+
+func (r *repository) ListDue(ctx context.Context, date time.Time) ([]uuid.UUID, error) {
+	query := `
+		SELECT id
+		FROM recurring_rules
+		WHERE next_occurrence <= $1 AND paused_at IS NULL AND deleted_at IS NULL
+		ORDER BY next_occurrence, id
+	`
+
+	rows, err := transactor.Conn(ctx, r.pool).Query(ctx, query, date)
+	if err != nil {
+		return nil, fmt.Errorf("list due recurring rules: %w", err)
+	}
+
+	defer rows.Close()
+
+	var ids []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("scan due recurring rule row: %w", err)
+		}
+		ids = append(ids, id)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate due recurring rule rows: %w", err)
+	}
+
+	return ids, nil
+}
+
+func (r *repository) AddSkip(ctx context.Context, ruleID uuid.UUID, date time.Time) error {
+	query := `
+		INSERT INTO recurring_rule_skips (rule_id, occurrence_date)
+		VALUES ($1, $2)
+		ON CONFLICT DO NOTHING
+	`
+
+	if _, err := transactor.Conn(ctx, r.pool).Exec(ctx, query, ruleID, date); err != nil {
+		return fmt.Errorf("add recurring rule skip: %w", err)
+	}
+
+	return nil
+}
+
+func (r *repository) GetSkips(ctx context.Context, ruleID uuid.UUID) ([]time.Time, error) {
+	query := `
+		SELECT occurrence_date
+		FROM recurring_rule_skips
+		WHERE rule_id = $1
+		ORDER BY occurrence_date
+	`
+
+	rows, err := transactor.Conn(ctx, r.pool).Query(ctx, query, ruleID)
+	if err != nil {
+		return nil, fmt.Errorf("get recurring rule skips: %w", err)
+	}
+
+	defer rows.Close()
+
+	var dates []time.Time
+	for rows.Next() {
+		var date time.Time
+		if err := rows.Scan(&date); err != nil {
+			return nil, fmt.Errorf("scan recurring rule skip row: %w", err)
+		}
+		dates = append(dates, date)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate recurring rule skip rows: %w", err)
+	}
+
+	return dates, nil
+}
+
+func scanRule(row pgx.Row) (*recurring.Rule, error) {
+	var rule recurring.Rule
+	var description pgtype.Text
+
+	err := row.Scan(
+		&rule.ID,
+		&rule.UserID,
+		&rule.AccountID,
+		&rule.Amount,
+		&rule.Type,
+		&description,
+		&rule.CategoryID,
+		&rule.Frequency,
+		&rule.Interval,
+		&rule.ByMonthDay,
+		&rule.StartDate,
+		&rule.EndDate,
+		&rule.Count,
+		&rule.LastOccurrence,
+		&rule.NextOccurrence,
+		&rule.PausedAt,
+		&rule.CreatedAt,
+		&rule.UpdatedAt,
+		&rule.DeletedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	rule.Description = description.String
+
+	return &rule, nil
+}
@@ -0,0 +1,172 @@
+package tag
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/nontypeable/financial-tracker/internal/domain/tag"
+	apperror "github.com/nontypeable/financial-tracker/internal/errors"
+	"github.com/nontypeable/financial-tracker/internal/transactor"
+)
+
+type repository struct {
+	pool *pgxpool.Pool
+}
+
+func NewRepository(pool *pgxpool.Pool) tag.Repository {
+	return &repository{pool: pool}
+}
+
+func (r *repository) Ensure(ctx context.Context, userID uuid.UUID, names []string) ([]*tag.Tag, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+
+	insert := `
+		INSERT INTO tags (user_id, name)
+		SELECT $1, name FROM UNNEST($2::text[]) AS name
+		ON CONFLICT (user_id, name) DO NOTHING
+	`
+
+	if _, err := transactor.Conn(ctx, r.pool).Exec(ctx, insert, userID, names); err != nil {
+		return nil, fmt.Errorf("insert tags: %w", err)
+	}
+
+	query := `
+		SELECT id, user_id, name, created_at, updated_at
+		FROM tags
+		WHERE user_id = $1 AND name = ANY($2)
+		ORDER BY name
+	`
+
+	return r.query(ctx, query, userID, names)
+}
+
+func (r *repository) GetByID(ctx context.Context, id uuid.UUID) (*tag.Tag, error) {
+	query := `
+		SELECT id, user_id, name, created_at, updated_at
+		FROM tags
+		WHERE id = $1
+	`
+
+	t, err := scanTag(transactor.Conn(ctx, r.pool).QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperror.ErrTagNotFound
+		}
+		return nil, fmt.Errorf("get tag by id: %w", err)
+	}
+
+	return t, nil
+}
+
+func (r *repository) GetByUserID(ctx context.Context, userID uuid.UUID) ([]*tag.Tag, error) {
+	query := `
+		SELECT id, user_id, name, created_at, updated_at
+		FROM tags
+		WHERE user_id = $1
+		ORDER BY name
+	`
+
+	return r.query(ctx, query, userID)
+}
+
+func (r *repository) Update(ctx context.Context, tag *tag.Tag) error {
+	query := `
+		UPDATE tags
+		SET name = $1, updated_at = NOW()
+		WHERE id = $2
+		RETURNING updated_at
+	`
+
+	err := transactor.Conn(ctx, r.pool).QueryRow(ctx, query, tag.Name, tag.ID).Scan(&tag.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return apperror.ErrTagNotFound
+		}
+
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.UniqueViolation {
+			return apperror.ErrTagAlreadyExists
+		}
+
+		return fmt.Errorf("update tag: %w", err)
+	}
+
+	return nil
+}
+
+func (r *repository) Merge(ctx context.Context, fromID, intoID uuid.UUID) error {
+	move := `
+		INSERT INTO transaction_tags (transaction_id, tag_id)
+		SELECT transaction_id, $2 FROM transaction_tags WHERE tag_id = $1
+		ON CONFLICT DO NOTHING
+	`
+
+	if _, err := transactor.Conn(ctx, r.pool).Exec(ctx, move, fromID, intoID); err != nil {
+		return fmt.Errorf("move tagged transactions: %w", err)
+	}
+
+	if _, err := transactor.Conn(ctx, r.pool).Exec(ctx, `DELETE FROM tags WHERE id = $1`, fromID); err != nil {
+		return fmt.Errorf("delete merged tag: %w", err)
+	}
+
+	return nil
+}
+
+func (r *repository) Delete(ctx context.Context, userID, id uuid.UUID) error {
+	query := `
+		DELETE FROM tags
+		WHERE id = $1 AND user_id = $2
+	`
+
+	result, err := transactor.Conn(ctx, r.pool).Exec(ctx, query, id, userID)
+	if err != nil {
+		return fmt.Errorf("delete tag: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return apperror.ErrTagNotFound
+	}
+
+	return nil
+}
+
+func (r *repository) query(ctx context.Context, query string, args ...any) ([]*tag.Tag, error) {
+	rows, err := transactor.Conn(ctx, r.pool).Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query tags: %w", err)
+	}
+	defer rows.Close()
+
+	var tags []*tag.Tag
+	for rows.Next() {
+		t, err := scanTag(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan tag row: %w", err)
+		}
+		tags = append(tags, t)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate tag rows: %w", err)
+	}
+
+	return tags, nil
+}
+
+func scanTag(row pgx.Row) (*tag.Tag, error) {
+	var t tag.Tag
+
+	if err := row.Scan(&t.ID, &t.UserID, &t.Name, &t.CreatedAt, &t.UpdatedAt); err != nil {
+		return nil, err
+	}
+
+	return &t, nil
+}
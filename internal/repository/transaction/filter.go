@@ -0,0 +1,163 @@
+package transaction
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nontypeable/financial-tracker/internal/domain/transaction"
+	apperror "github.com/nontypeable/financial-tracker/internal/errors"
+	"github.com/shopspring/decimal"
+)
+
+type queryBuilder struct {
+	conditions []string
+	args       []any
+}
+
+func (b *queryBuilder) arg(value any) string {
+	b.args = append(b.args, value)
+	return fmt.Sprintf("$%d", len(b.args))
+}
+
+func (b *queryBuilder) where(condition string) {
+	b.conditions = append(b.conditions, condition)
+}
+
+func (b *queryBuilder) clause() string {
+	return strings.Join(b.conditions, " AND ")
+}
+
+// applyFilter adds the conditions shared by every filtered transaction query.
+// It expects the transactions table aliased as t and accounts as a.
+func applyFilter(b *queryBuilder, f *transaction.Filter) {
+	b.where("a.user_id = " + b.arg(f.UserID))
+	b.where("a.deleted_at IS NULL")
+	b.where("t.deleted_at IS NULL")
+
+	if len(f.AccountIDs) > 0 {
+		b.where("t.account_id = ANY(" + b.arg(f.AccountIDs) + ")")
+	}
+	if len(f.CategoryIDs) > 0 {
+		tree := categoryTree(b.arg(f.CategoryIDs))
+		b.where(fmt.Sprintf(
+			"(t.category_id IN (%[1]s) OR EXISTS (SELECT 1 FROM transaction_splits s WHERE s.transaction_id = t.id AND s.category_id IN (%[1]s)))",
+			tree,
+		))
+	}
+	if len(f.TagIDsAny) > 0 {
+		b.where("EXISTS (SELECT 1 FROM transaction_tags tt WHERE tt.transaction_id = t.id AND tt.tag_id = ANY(" + b.arg(f.TagIDsAny) + "))")
+	}
+	if len(f.TagIDsAll) > 0 {
+		b.where(fmt.Sprintf(
+			"(SELECT COUNT(DISTINCT tt.tag_id) FROM transaction_tags tt WHERE tt.transaction_id = t.id AND tt.tag_id = ANY(%s)) = %s",
+			b.arg(f.TagIDsAll), b.arg(len(uniqueIDs(f.TagIDsAll))),
+		))
+	}
+	if f.Type != nil {
+		b.where("t.type = " + b.arg(*f.Type))
+	}
+	if len(f.Statuses) > 0 {
+		b.where("t.status = ANY(" + b.arg(f.Statuses) + ")")
+	}
+	if f.From != nil {
+		b.where("t.occurred_at >= " + b.arg(*f.From))
+	}
+	if f.To != nil {
+		b.where("t.occurred_at < " + b.arg(*f.To))
+	}
+	if f.AmountMin != nil {
+		b.where(amountColumn + " >= " + b.arg(*f.AmountMin))
+	}
+	if f.AmountMax != nil {
+		b.where(amountColumn + " <= " + b.arg(*f.AmountMax))
+	}
+	if f.Description != "" {
+		b.where("t.description ILIKE " + b.arg("%"+escapeLike(f.Description)+"%"))
+	}
+}
+
+// applyLineCategories narrows queries over split lines to the lines in the
+// filtered categories, so a split transaction only counts its matching
+// part. It expects the split lines joined as s.
+func applyLineCategories(b *queryBuilder, f *transaction.Filter) {
+	if len(f.CategoryIDs) > 0 {
+		b.where("COALESCE(s.category_id, t.category_id) IN (" + categoryTree(b.arg(f.CategoryIDs)) + ")")
+	}
+}
+
+// categoryTree selects the given categories and all their subcategories.
+func categoryTree(ids string) string {
+	return `WITH RECURSIVE tree AS (
+		SELECT id FROM categories WHERE id = ANY(` + ids + `)
+		UNION ALL
+		SELECT c.id FROM categories c JOIN tree ON c.parent_id = tree.id
+	) SELECT id FROM tree`
+}
+
+func applyCursor(b *queryBuilder, f *transaction.Filter) error {
+	if f.Cursor == nil {
+		return nil
+	}
+
+	column := sortColumn(f.SortBy)
+
+	var value any
+	switch f.SortBy {
+	case transaction.SortByAmount:
+		amount, err := decimal.NewFromString(f.Cursor.Value)
+		if err != nil {
+			return fmt.Errorf("%w: malformed cursor", apperror.ErrInvalidInput)
+		}
+		value = amount
+	default:
+		occurredAt, err := time.Parse(time.RFC3339Nano, f.Cursor.Value)
+		if err != nil {
+			return fmt.Errorf("%w: malformed cursor", apperror.ErrInvalidInput)
+		}
+		value = occurredAt
+	}
+
+	operator := "<"
+	if f.Order == transaction.Ascending {
+		operator = ">"
+	}
+
+	b.where(fmt.Sprintf("(%s, t.id) %s (%s, %s)", column, operator, b.arg(value), b.arg(f.Cursor.ID)))
+	return nil
+}
+
+func orderClause(f *transaction.Filter) string {
+	direction := "DESC"
+	if f.Order == transaction.Ascending {
+		direction = "ASC"
+	}
+
+	return fmt.Sprintf("%s %s, t.id %s", sortColumn(f.SortBy), direction, direction)
+}
+
+// amountColumn is the size of a transaction. Transfer legs are stored
+// signed, so the outgoing leg is compared and sorted by its magnitude like
+// an expense.
+const amountColumn = "ABS(t.amount)"
+
+func sortColumn(sortBy transaction.SortField) string {
+	if sortBy == transaction.SortByAmount {
+		return amountColumn
+	}
+	return "t.occurred_at"
+}
+
+func uniqueIDs(ids []uuid.UUID) map[uuid.UUID]struct{} {
+	set := make(map[uuid.UUID]struct{}, len(ids))
+	for _, id := range ids {
+		set[id] = struct{}{}
+	}
+	return set
+}
+
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
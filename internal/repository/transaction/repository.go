@@ -2,8 +2,10 @@ package transaction
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgerrcode"
@@ -13,8 +15,32 @@ import (
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/nontypeable/financial-tracker/internal/domain/transaction"
 	apperror "github.com/nontypeable/financial-tracker/internal/errors"
+	"github.com/nontypeable/financial-tracker/internal/transactor"
+	"github.com/shopspring/decimal"
 )
 
+const selectColumns = `t.id, t.account_id, t.amount, t.type, t.description, t.status, t.reconciliation_id,
+		t.category_id, t.transfer_id,
+		t.recurring_rule_id, t.occurrence_date, t.external_id,
+		t.occurred_at, t.created_at, t.updated_at, t.deleted_at,
+		ARRAY(
+			SELECT tg.name FROM transaction_tags tt JOIN tags tg ON tg.id = tt.tag_id
+			WHERE tt.transaction_id = t.id ORDER BY tg.name
+		),
+		COALESCE((
+			SELECT json_agg(json_build_object(
+				'id', s.id, 'category_id', s.category_id, 'amount', s.amount::text, 'memo', COALESCE(s.memo, '')
+			) ORDER BY s.position)
+			FROM transaction_splits s WHERE s.transaction_id = t.id
+		), '[]')`
+
+type splitRow struct {
+	ID         uuid.UUID       `json:"id"`
+	CategoryID *uuid.UUID      `json:"category_id"`
+	Amount     decimal.Decimal `json:"amount"`
+	Memo       string          `json:"memo"`
+}
+
 type repository struct {
 	pool *pgxpool.Pool
 }
@@ -27,26 +53,41 @@ func NewRepository(pool *pgxpool.Pool) transaction.Repository {
 
 func (r *repository) Create(ctx context.Context, transaction *transaction.Transaction) (uuid.UUID, error) {
 	query := `
-		INSERT INTO transactions (account_id, amount, type, description)
-		VALUES ($1, $2, $3, $4)
+		INSERT INTO transactions (account_id, amount, type, description, status, category_id, transfer_id, recurring_rule_id, occurrence_date, external_id, occurred_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
 		RETURNING id;
 	`
 
 	var id uuid.UUID
-	err := r.pool.QueryRow(ctx, query,
+	err := transactor.Conn(ctx, r.pool).QueryRow(ctx, query,
 		transaction.AccountID,
 		transaction.Amount,
 		transaction.Type,
 		transaction.Description,
+		transaction.Status,
+		transaction.CategoryID,
+		transaction.TransferID,
+		transaction.RecurringRuleID,
+		transaction.OccurrenceDate,
+		transaction.ExternalID,
+		transaction.OccurredAt,
 	).Scan(&id)
 
 	if err != nil {
 		var pgErr *pgconn.PgError
 		if errors.As(err, &pgErr) {
 			switch pgErr.Code {
+			case pgerrcode.UniqueViolation:
+				if pgErr.ConstraintName == "idx_transactions_account_id_external_id" {
+					return uuid.Nil, apperror.ErrTransactionImported
+				}
+				return uuid.Nil, apperror.ErrOccurrenceAlreadyPosted
 			case pgerrcode.NotNullViolation, pgerrcode.CheckViolation:
 				return uuid.Nil, apperror.ErrInvalidInput
 			case pgerrcode.ForeignKeyViolation:
+				if pgErr.ConstraintName == "transactions_category_id_fkey" {
+					return uuid.Nil, apperror.ErrCategoryNotFound
+				}
 				return uuid.Nil, apperror.ErrAccountNotFound
 			}
 		}
@@ -58,48 +99,120 @@ func (r *repository) Create(ctx context.Context, transaction *transaction.Transa
 
 func (r *repository) GetByID(ctx context.Context, id uuid.UUID) (*transaction.Transaction, error) {
 	query := `
-		SELECT id, account_id, amount, type, description, created_at, updated_at, deleted_at
-		FROM transactions
-		WHERE id = $1 AND deleted_at IS NULL
+		SELECT ` + selectColumns + `
+		FROM transactions t
+		WHERE t.id = $1 AND t.deleted_at IS NULL
 	`
 
-	var t transaction.Transaction
-	var deletedAt pgtype.Timestamptz
+	t, err := scanTransaction(transactor.Conn(ctx, r.pool).QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperror.ErrTransactionNotFound
+		}
+		return nil, fmt.Errorf("get transaction by id: %w", err)
+	}
 
-	err := r.pool.QueryRow(ctx, query, id).Scan(
-		&t.ID,
-		&t.AccountID,
-		&t.Amount,
-		&t.Type,
-		&t.Description,
-		&t.CreatedAt,
-		&t.UpdatedAt,
-		&deletedAt,
-	)
+	return t, nil
+}
+
+func (r *repository) GetByIDForUpdate(ctx context.Context, id uuid.UUID) (*transaction.Transaction, error) {
+	query := `
+		SELECT ` + selectColumns + `
+		FROM transactions t
+		WHERE t.id = $1 AND t.deleted_at IS NULL
+		FOR UPDATE
+	`
 
+	t, err := scanTransaction(transactor.Conn(ctx, r.pool).QueryRow(ctx, query, id))
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, apperror.ErrTransactionNotFound
 		}
-		return nil, fmt.Errorf("get transaction by id: %w", err)
+		return nil, fmt.Errorf("get transaction by id for update: %w", err)
 	}
 
-	if deletedAt.Valid {
-		t.DeletedAt = &deletedAt.Time
+	return t, nil
+}
+
+func (r *repository) GetDeletedByIDForUpdate(ctx context.Context, id uuid.UUID) (*transaction.Transaction, error) {
+	query := `
+		SELECT ` + selectColumns + `
+		FROM transactions t
+		WHERE t.id = $1 AND t.deleted_at IS NOT NULL
+		FOR UPDATE
+	`
+
+	t, err := scanTransaction(transactor.Conn(ctx, r.pool).QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperror.ErrTransactionNotFound
+		}
+		return nil, fmt.Errorf("get deleted transaction by id: %w", err)
 	}
 
-	return &t, nil
+	return t, nil
+}
+
+func (r *repository) GetByTransferID(ctx context.Context, transferID uuid.UUID) ([]*transaction.Transaction, error) {
+	query := `
+		SELECT ` + selectColumns + `
+		FROM transactions t
+		WHERE t.transfer_id = $1 AND t.deleted_at IS NULL
+		ORDER BY t.id
+	`
+
+	return r.queryTransferLegs(ctx, query, transferID)
+}
+
+func (r *repository) GetByTransferIDForUpdate(ctx context.Context, transferID uuid.UUID) ([]*transaction.Transaction, error) {
+	query := `
+		SELECT ` + selectColumns + `
+		FROM transactions t
+		WHERE t.transfer_id = $1
+		ORDER BY t.id
+		FOR UPDATE
+	`
+
+	return r.queryTransferLegs(ctx, query, transferID)
+}
+
+func (r *repository) queryTransferLegs(ctx context.Context, query string, transferID uuid.UUID) ([]*transaction.Transaction, error) {
+	rows, err := transactor.Conn(ctx, r.pool).Query(ctx, query, transferID)
+	if err != nil {
+		return nil, fmt.Errorf("get transactions by transfer_id: %w", err)
+	}
+	defer rows.Close()
+
+	var transactions []*transaction.Transaction
+	for rows.Next() {
+		t, err := scanTransaction(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan transaction row: %w", err)
+		}
+
+		transactions = append(transactions, t)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate transaction rows: %w", err)
+	}
+
+	if len(transactions) == 0 {
+		return nil, apperror.ErrTransferNotFound
+	}
+
+	return transactions, nil
 }
 
 func (r *repository) GetByAccountID(ctx context.Context, accountID uuid.UUID) ([]*transaction.Transaction, error) {
 	query := `
-		SELECT id, account_id, amount, type, description, created_at, updated_at, deleted_at
-		FROM transactions
-		WHERE account_id = $1 AND deleted_at IS NULL
-		ORDER BY created_at DESC
+		SELECT ` + selectColumns + `
+		FROM transactions t
+		WHERE t.account_id = $1 AND t.deleted_at IS NULL
+		ORDER BY t.occurred_at DESC, t.id DESC
 	`
 
-	rows, err := r.pool.Query(ctx, query, accountID)
+	rows, err := transactor.Conn(ctx, r.pool).Query(ctx, query, accountID)
 	if err != nil {
 		return nil, fmt.Errorf("get transactions by account_id: %w", err)
 	}
@@ -107,48 +220,710 @@ func (r *repository) GetByAccountID(ctx context.Context, accountID uuid.UUID) ([
 
 	var transactions []*transaction.Transaction
 	for rows.Next() {
-		var t transaction.Transaction
-		var deletedAt pgtype.Timestamptz
-
-		err = rows.Scan(
-			&t.ID,
-			&t.AccountID,
-			&t.Amount,
-			&t.Type,
-			&t.Description,
-			&t.CreatedAt,
-			&t.UpdatedAt,
-			&deletedAt,
+		t, err := scanTransaction(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan transaction row: %w", err)
+		}
+
+		transactions = append(transactions, t)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate transaction rows: %w", err)
+	}
+
+	return transactions, nil
+}
+
+func (r *repository) List(ctx context.Context, filter *transaction.Filter) ([]*transaction.Transaction, error) {
+	var b queryBuilder
+
+	applyFilter(&b, filter)
+	if err := applyCursor(&b, filter); err != nil {
+		return nil, err
+	}
+
+	query := fmt.Sprintf(`
+		SELECT `+selectColumns+`
+		FROM transactions t
+		JOIN accounts a ON a.id = t.account_id
+		WHERE %s
+		ORDER BY %s
+		LIMIT %s
+	`, b.clause(), orderClause(filter), b.arg(filter.Limit+1))
+
+	rows, err := transactor.Conn(ctx, r.pool).Query(ctx, query, b.args...)
+	if err != nil {
+		return nil, fmt.Errorf("list transactions: %w", err)
+	}
+	defer rows.Close()
+
+	var transactions []*transaction.Transaction
+	for rows.Next() {
+		t, err := scanTransaction(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan transaction row: %w", err)
+		}
+
+		transactions = append(transactions, t)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate transaction rows: %w", err)
+	}
+
+	return transactions, nil
+}
+
+func (r *repository) Stream(ctx context.Context, filter *transaction.Filter, fn func(*transaction.Transaction) error) error {
+	var b queryBuilder
+
+	applyFilter(&b, filter)
+
+	query := fmt.Sprintf(`
+		SELECT `+selectColumns+`
+		FROM transactions t
+		JOIN accounts a ON a.id = t.account_id
+		WHERE %s
+		ORDER BY %s
+	`, b.clause(), orderClause(filter))
+
+	rows, err := transactor.Conn(ctx, r.pool).Query(ctx, query, b.args...)
+	if err != nil {
+		return fmt.Errorf("stream transactions: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		t, err := scanTransaction(rows)
+		if err != nil {
+			return fmt.Errorf("scan transaction row: %w", err)
+		}
+
+		if err := fn(t); err != nil {
+			return err
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("iterate transaction rows: %w", err)
+	}
+
+	return nil
+}
+
+func (r *repository) CashFlow(ctx context.Context, filter *transaction.Filter, interval transaction.Interval) (*transaction.CashFlow, error) {
+	var b queryBuilder
+
+	step := b.arg(string(interval))
+	from := b.arg(*filter.From)
+	to := b.arg(*filter.To)
+
+	applyFilter(&b, filter)
+	b.where("t.type IN ('income', 'expense')")
+
+	// Periods are generated separately so that those without transactions
+	// are still reported.
+	query := fmt.Sprintf(`
+		WITH buckets AS (
+			SELECT date_trunc(%[1]s, t.occurred_at AT TIME ZONE 'UTC') AS period,
+			       SUM(t.amount) FILTER (WHERE t.type = 'income') AS income,
+			       SUM(t.amount) FILTER (WHERE t.type = 'expense') AS expense
+			FROM transactions t
+			JOIN accounts a ON a.id = t.account_id
+			WHERE %[4]s
+			GROUP BY 1
+		),
+		periods AS (
+			SELECT p.period,
+			       COALESCE(b.income, 0) AS income,
+			       COALESCE(b.expense, 0) AS expense
+			FROM generate_series(
+				date_trunc(%[1]s, %[2]s::timestamptz AT TIME ZONE 'UTC'),
+				%[3]s::timestamptz AT TIME ZONE 'UTC' - INTERVAL '1 microsecond',
+				('1 ' || %[1]s)::interval
+			) AS p(period)
+			LEFT JOIN buckets b ON b.period = p.period
+		)
+		SELECT period, income, expense, income - expense,
+		       SUM(income) OVER (), SUM(expense) OVER (), SUM(income - expense) OVER ()
+		FROM periods
+		ORDER BY period
+	`, step, from, to, b.clause())
+
+	rows, err := transactor.Conn(ctx, r.pool).Query(ctx, query, b.args...)
+	if err != nil {
+		return nil, fmt.Errorf("get cash flow: %w", err)
+	}
+	defer rows.Close()
+
+	cashFlow := &transaction.CashFlow{
+		Interval: interval,
+		Periods:  []transaction.CashFlowPeriod{},
+		Income:   decimal.Zero,
+		Expense:  decimal.Zero,
+		Net:      decimal.Zero,
+	}
+
+	for rows.Next() {
+		var p transaction.CashFlowPeriod
+		if err := rows.Scan(&p.Start, &p.Income, &p.Expense, &p.Net, &cashFlow.Income, &cashFlow.Expense, &cashFlow.Net); err != nil {
+			return nil, fmt.Errorf("scan cash flow row: %w", err)
+		}
+		p.Start = p.Start.UTC()
+		cashFlow.Periods = append(cashFlow.Periods, p)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate cash flow rows: %w", err)
+	}
+
+	return cashFlow, nil
+}
+
+func (r *repository) CategoryBreakdown(ctx context.Context, filter *transaction.Filter, previousFrom time.Time) (*transaction.CategoryBreakdown, error) {
+	var b queryBuilder
+
+	from := b.arg(*filter.From)
+
+	wide := *filter
+	wide.From = &previousFrom
+	applyFilter(&b, &wide)
+	applyLineCategories(&b, &wide)
+
+	// ancestry pairs every category with a total with itself and each of
+	// its parents, so summing over it rolls totals up the tree.
+	query := fmt.Sprintf(`
+		WITH RECURSIVE lines AS (
+			SELECT COALESCE(s.category_id, t.category_id) AS category_id,
+			       COALESCE(s.amount, t.amount) AS amount,
+			       t.occurred_at >= %s AS in_period
+			FROM transactions t
+			JOIN accounts a ON a.id = t.account_id
+			LEFT JOIN transaction_splits s ON s.transaction_id = t.id
+			WHERE %s
+		),
+		totals AS (
+			SELECT category_id,
+			       COALESCE(SUM(amount) FILTER (WHERE in_period), 0) AS current_total,
+			       COALESCE(SUM(amount) FILTER (WHERE NOT in_period), 0) AS previous_total
+			FROM lines
+			GROUP BY category_id
+		),
+		ancestry AS (
+			SELECT tot.category_id, c.id AS ancestor_id, c.parent_id
+			FROM totals tot
+			JOIN categories c ON c.id = tot.category_id
+			UNION ALL
+			SELECT an.category_id, p.id, p.parent_id
+			FROM ancestry an
+			JOIN categories p ON p.id = an.parent_id
+		),
+		rolled AS (
+			SELECT an.ancestor_id AS category_id,
+			       SUM(tot.current_total) AS current_total,
+			       SUM(tot.previous_total) AS previous_total
+			FROM ancestry an
+			JOIN totals tot ON tot.category_id = an.category_id
+			GROUP BY an.ancestor_id
+			UNION ALL
+			SELECT NULL, current_total, previous_total
+			FROM totals
+			WHERE category_id IS NULL
+		)
+		SELECT r.category_id, c.parent_id, COALESCE(c.name, ''), r.current_total, r.previous_total,
+		       (SELECT COALESCE(SUM(current_total), 0) FROM totals),
+		       (SELECT COALESCE(SUM(previous_total), 0) FROM totals)
+		FROM rolled r
+		LEFT JOIN categories c ON c.id = r.category_id
+		ORDER BY r.current_total DESC, r.previous_total DESC, c.name
+	`, from, b.clause())
+
+	rows, err := transactor.Conn(ctx, r.pool).Query(ctx, query, b.args...)
+	if err != nil {
+		return nil, fmt.Errorf("get category breakdown: %w", err)
+	}
+	defer rows.Close()
+
+	breakdown := &transaction.CategoryBreakdown{
+		Current:    decimal.Zero,
+		Previous:   decimal.Zero,
+		Categories: []transaction.CategoryTotal{},
+	}
+
+	for rows.Next() {
+		var c transaction.CategoryTotal
+		if err := rows.Scan(&c.CategoryID, &c.ParentID, &c.Name, &c.Current, &c.Previous, &breakdown.Current, &breakdown.Previous); err != nil {
+			return nil, fmt.Errorf("scan category breakdown row: %w", err)
+		}
+		breakdown.Categories = append(breakdown.Categories, c)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate category breakdown rows: %w", err)
+	}
+
+	return breakdown, nil
+}
+
+func (r *repository) TopDescriptions(ctx context.Context, filter *transaction.Filter, previousFrom time.Time, limit int) ([]*transaction.DescriptionTotal, error) {
+	var b queryBuilder
+
+	from := b.arg(*filter.From)
+
+	wide := *filter
+	wide.From = &previousFrom
+	applyFilter(&b, &wide)
+	applyLineCategories(&b, &wide)
+	b.where("TRIM(COALESCE(t.description, '')) <> ''")
+
+	// Totals are summed over split lines so that, when filtering by
+	// category, a split transaction only counts its matching lines.
+	query := fmt.Sprintf(`
+		SELECT MIN(t.description),
+		       COUNT(DISTINCT t.id) FILTER (WHERE t.occurred_at >= %[1]s),
+		       COALESCE(SUM(COALESCE(s.amount, t.amount)) FILTER (WHERE t.occurred_at >= %[1]s), 0) AS current_total,
+		       COALESCE(SUM(COALESCE(s.amount, t.amount)) FILTER (WHERE t.occurred_at < %[1]s), 0) AS previous_total
+		FROM transactions t
+		JOIN accounts a ON a.id = t.account_id
+		LEFT JOIN transaction_splits s ON s.transaction_id = t.id
+		WHERE %[2]s
+		GROUP BY LOWER(TRIM(t.description))
+		HAVING COUNT(*) FILTER (WHERE t.occurred_at >= %[1]s) > 0
+		ORDER BY current_total DESC, previous_total DESC, 1
+		LIMIT %[3]s
+	`, from, b.clause(), b.arg(limit))
+
+	rows, err := transactor.Conn(ctx, r.pool).Query(ctx, query, b.args...)
+	if err != nil {
+		return nil, fmt.Errorf("get top descriptions: %w", err)
+	}
+	defer rows.Close()
+
+	var totals []*transaction.DescriptionTotal
+	for rows.Next() {
+		var d transaction.DescriptionTotal
+		if err := rows.Scan(&d.Description, &d.Count, &d.Current, &d.Previous); err != nil {
+			return nil, fmt.Errorf("scan description total row: %w", err)
+		}
+		totals = append(totals, &d)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate description total rows: %w", err)
+	}
+
+	return totals, nil
+}
+
+func (r *repository) BalanceHistory(ctx context.Context, userID uuid.UUID, accountIDs []uuid.UUID, from, to time.Time, interval transaction.Interval) ([]*transaction.AccountBalance, error) {
+	// Transactions are netted per account and period, those before the
+	// first period counting towards it, and a running sum over the periods
+	// turns the movements into balances without a query per period.
+	query := `
+		WITH periods AS (
+			SELECT p.start,
+			       LEAST(p.start + ('1 ' || $3)::interval, $5::timestamptz AT TIME ZONE 'UTC') AS as_of
+			FROM generate_series(
+				date_trunc($3, $4::timestamptz AT TIME ZONE 'UTC'),
+				$5::timestamptz AT TIME ZONE 'UTC' - INTERVAL '1 microsecond',
+				('1 ' || $3)::interval
+			) AS p(start)
+		),
+		owned AS (
+			SELECT a.id, a.opening_balance
+			FROM accounts a
+			WHERE a.user_id = $1 AND a.deleted_at IS NULL
+			  AND (COALESCE(cardinality($2::uuid[]), 0) = 0 OR a.id = ANY($2))
+		),
+		movements AS (
+			SELECT t.account_id,
+			       GREATEST(
+			           date_trunc($3, t.occurred_at AT TIME ZONE 'UTC'),
+			           date_trunc($3, $4::timestamptz AT TIME ZONE 'UTC')
+			       ) AS start,
+			       SUM(CASE WHEN t.type = 'expense' THEN -t.amount ELSE t.amount END) AS delta
+			FROM transactions t
+			JOIN owned a ON a.id = t.account_id
+			WHERE t.deleted_at IS NULL AND t.occurred_at < $5
+			GROUP BY 1, 2
 		)
+		SELECT a.id, p.start, p.as_of,
+		       a.opening_balance + SUM(COALESCE(m.delta, 0)) OVER (PARTITION BY a.id ORDER BY p.start)
+		FROM owned a
+		CROSS JOIN periods p
+		LEFT JOIN movements m ON m.account_id = a.id AND m.start = p.start
+		ORDER BY a.id, p.start
+	`
+
+	rows, err := transactor.Conn(ctx, r.pool).Query(ctx, query, userID, accountIDs, string(interval), from, to)
+	if err != nil {
+		return nil, fmt.Errorf("get balance history: %w", err)
+	}
+	defer rows.Close()
+
+	var balances []*transaction.AccountBalance
+	for rows.Next() {
+		var b transaction.AccountBalance
+		if err := rows.Scan(&b.AccountID, &b.Start, &b.AsOf, &b.Balance); err != nil {
+			return nil, fmt.Errorf("scan balance history row: %w", err)
+		}
+		b.Start = b.Start.UTC()
+		b.AsOf = b.AsOf.UTC()
+		balances = append(balances, &b)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate balance history rows: %w", err)
+	}
+
+	return balances, nil
+}
+
+func (r *repository) ListDeleted(ctx context.Context, userID uuid.UUID, since time.Time) ([]*transaction.Transaction, error) {
+	query := `
+		SELECT ` + selectColumns + `
+		FROM transactions t
+		JOIN accounts a ON a.id = t.account_id
+		WHERE a.user_id = $1 AND a.deleted_at IS NULL
+		  AND t.deleted_at IS NOT NULL AND t.deleted_at >= $2
+		ORDER BY t.deleted_at DESC, t.id DESC
+	`
+
+	rows, err := transactor.Conn(ctx, r.pool).Query(ctx, query, userID, since)
+	if err != nil {
+		return nil, fmt.Errorf("list deleted transactions: %w", err)
+	}
+	defer rows.Close()
+
+	var transactions []*transaction.Transaction
+	for rows.Next() {
+		t, err := scanTransaction(rows)
 		if err != nil {
 			return nil, fmt.Errorf("scan transaction row: %w", err)
 		}
 
-		if deletedAt.Valid {
-			t.DeletedAt = &deletedAt.Time
+		transactions = append(transactions, t)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate transaction rows: %w", err)
+	}
+
+	return transactions, nil
+}
+
+func (r *repository) HasTransfers(ctx context.Context, accountID uuid.UUID) (bool, error) {
+	query := `
+		SELECT EXISTS (
+			SELECT 1
+			FROM transactions
+			WHERE account_id = $1 AND transfer_id IS NOT NULL AND deleted_at IS NULL
+		)
+	`
+
+	var exists bool
+	if err := transactor.Conn(ctx, r.pool).QueryRow(ctx, query, accountID).Scan(&exists); err != nil {
+		return false, fmt.Errorf("check transfers by account_id: %w", err)
+	}
+
+	return exists, nil
+}
+
+func (r *repository) SumByAccountID(ctx context.Context, accountID uuid.UUID, before *time.Time) (decimal.Decimal, error) {
+	query := `
+		SELECT COALESCE(SUM(CASE WHEN type = 'expense' THEN -amount ELSE amount END), 0)
+		FROM transactions
+		WHERE account_id = $1 AND deleted_at IS NULL AND ($2::timestamptz IS NULL OR occurred_at < $2)
+	`
+
+	var sum decimal.Decimal
+	if err := transactor.Conn(ctx, r.pool).QueryRow(ctx, query, accountID, before).Scan(&sum); err != nil {
+		return decimal.Zero, fmt.Errorf("sum transactions by account_id: %w", err)
+	}
+
+	return sum, nil
+}
+
+func (r *repository) SumClearedByAccountIDs(ctx context.Context, accountIDs []uuid.UUID, before *time.Time) (map[uuid.UUID]decimal.Decimal, error) {
+	query := `
+		SELECT account_id, SUM(CASE WHEN type = 'expense' THEN -amount ELSE amount END)
+		FROM transactions
+		WHERE account_id = ANY($1) AND deleted_at IS NULL AND status IN ('cleared', 'reconciled')
+		  AND ($2::timestamptz IS NULL OR occurred_at < $2)
+		GROUP BY account_id
+	`
+
+	rows, err := transactor.Conn(ctx, r.pool).Query(ctx, query, accountIDs, before)
+	if err != nil {
+		return nil, fmt.Errorf("sum cleared transactions: %w", err)
+	}
+	defer rows.Close()
+
+	sums := make(map[uuid.UUID]decimal.Decimal, len(accountIDs))
+	for rows.Next() {
+		var accountID uuid.UUID
+		var sum decimal.Decimal
+		if err := rows.Scan(&accountID, &sum); err != nil {
+			return nil, fmt.Errorf("scan cleared sum row: %w", err)
 		}
+		sums[accountID] = sum
+	}
 
-		transactions = append(transactions, &t)
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate cleared sum rows: %w", err)
+	}
+
+	return sums, nil
+}
+
+func (r *repository) GetUnreconciled(ctx context.Context, accountID uuid.UUID, before time.Time) ([]*transaction.Transaction, error) {
+	query := `
+		SELECT ` + selectColumns + `
+		FROM transactions t
+		WHERE t.account_id = $1 AND t.deleted_at IS NULL AND t.status <> 'reconciled' AND t.occurred_at < $2
+		ORDER BY t.occurred_at, t.id
+	`
+
+	rows, err := transactor.Conn(ctx, r.pool).Query(ctx, query, accountID, before)
+	if err != nil {
+		return nil, fmt.Errorf("get unreconciled transactions: %w", err)
+	}
+	defer rows.Close()
+
+	var transactions []*transaction.Transaction
+	for rows.Next() {
+		t, err := scanTransaction(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan transaction row: %w", err)
+		}
+		transactions = append(transactions, t)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate transaction rows: %w", err)
 	}
 
 	return transactions, nil
 }
 
+func (r *repository) GetFirstDates(ctx context.Context, userID uuid.UUID) (map[uuid.UUID]time.Time, error) {
+	query := `
+		SELECT t.account_id, MIN(t.occurred_at)
+		FROM transactions t
+		JOIN accounts a ON a.id = t.account_id
+		WHERE a.user_id = $1 AND a.deleted_at IS NULL AND t.deleted_at IS NULL
+		GROUP BY t.account_id
+		UNION ALL
+		SELECT COALESCE(s.category_id, t.category_id), MIN(t.occurred_at)
+		FROM transactions t
+		JOIN accounts a ON a.id = t.account_id
+		LEFT JOIN transaction_splits s ON s.transaction_id = t.id
+		WHERE a.user_id = $1 AND a.deleted_at IS NULL AND t.deleted_at IS NULL
+		  AND COALESCE(s.category_id, t.category_id) IS NOT NULL
+		GROUP BY 1
+	`
+
+	rows, err := transactor.Conn(ctx, r.pool).Query(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("get first transaction dates: %w", err)
+	}
+	defer rows.Close()
+
+	dates := make(map[uuid.UUID]time.Time)
+	for rows.Next() {
+		var id uuid.UUID
+		var first time.Time
+		if err := rows.Scan(&id, &first); err != nil {
+			return nil, fmt.Errorf("scan first date row: %w", err)
+		}
+		dates[id] = first
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate first date rows: %w", err)
+	}
+
+	return dates, nil
+}
+
+func (r *repository) GetExternalIDs(ctx context.Context, accountID uuid.UUID, externalIDs []string) (map[string]struct{}, error) {
+	query := `
+		SELECT external_id
+		FROM transactions
+		WHERE account_id = $1 AND external_id = ANY($2)
+	`
+
+	rows, err := transactor.Conn(ctx, r.pool).Query(ctx, query, accountID, externalIDs)
+	if err != nil {
+		return nil, fmt.Errorf("get external ids: %w", err)
+	}
+	defer rows.Close()
+
+	existing := make(map[string]struct{})
+	for rows.Next() {
+		var externalID string
+		if err := rows.Scan(&externalID); err != nil {
+			return nil, fmt.Errorf("scan external id row: %w", err)
+		}
+		existing[externalID] = struct{}{}
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate external id rows: %w", err)
+	}
+
+	return existing, nil
+}
+
+func (r *repository) GetByAccountIDBetween(ctx context.Context, accountID uuid.UUID, from, to time.Time) ([]*transaction.Transaction, error) {
+	query := `
+		SELECT ` + selectColumns + `
+		FROM transactions t
+		WHERE t.account_id = $1 AND t.deleted_at IS NULL AND t.occurred_at >= $2 AND t.occurred_at < $3
+		ORDER BY t.occurred_at, t.id
+	`
+
+	rows, err := transactor.Conn(ctx, r.pool).Query(ctx, query, accountID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("get transactions by account id: %w", err)
+	}
+	defer rows.Close()
+
+	var transactions []*transaction.Transaction
+	for rows.Next() {
+		t, err := scanTransaction(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan transaction row: %w", err)
+		}
+		transactions = append(transactions, t)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate transaction rows: %w", err)
+	}
+
+	return transactions, nil
+}
+
+func (r *repository) FindDuplicates(ctx context.Context, filter *transaction.DuplicateFilter) ([]*transaction.DuplicatePair, error) {
+	var b queryBuilder
+
+	b.where("a.user_id = " + b.arg(filter.UserID))
+	b.where("a.deleted_at IS NULL")
+	b.where("o.deleted_at IS NULL AND d.deleted_at IS NULL")
+	b.where("o.type <> 'transfer'")
+	b.where("(o.status <> 'reconciled' OR d.status <> 'reconciled')")
+	b.where("(o.external_id IS NULL OR d.external_id IS NULL OR o.external_id = d.external_id)")
+	if filter.AccountID != nil {
+		b.where("o.account_id = " + b.arg(*filter.AccountID))
+	}
+	if filter.From != nil {
+		b.where("d.occurred_at >= " + b.arg(*filter.From))
+	}
+
+	// The window is widened by a day so that time zones cannot hide a pair
+	// whose calendar dates are within it; the scorer applies the exact limit.
+	window := b.arg(transaction.DuplicateWindow + 1)
+
+	query := fmt.Sprintf(`
+		SELECT o.id, d.id
+		FROM transactions o
+		JOIN accounts a ON a.id = o.account_id
+		JOIN transactions d ON d.account_id = o.account_id
+			AND d.type = o.type AND d.amount = o.amount
+			AND (d.created_at, d.id) > (o.created_at, o.id)
+			AND d.occurred_at BETWEEN o.occurred_at - make_interval(days => %[1]s)
+				AND o.occurred_at + make_interval(days => %[1]s)
+		WHERE %[2]s
+		ORDER BY d.occurred_at DESC, d.id
+	`, window, b.clause())
+
+	conn := transactor.Conn(ctx, r.pool)
+
+	rows, err := conn.Query(ctx, query, b.args...)
+	if err != nil {
+		return nil, fmt.Errorf("find duplicate transactions: %w", err)
+	}
+	defer rows.Close()
+
+	var ids [][2]uuid.UUID
+	for rows.Next() {
+		var pair [2]uuid.UUID
+		if err := rows.Scan(&pair[0], &pair[1]); err != nil {
+			return nil, fmt.Errorf("scan duplicate row: %w", err)
+		}
+		ids = append(ids, pair)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate duplicate rows: %w", err)
+	}
+
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	unique := make([]uuid.UUID, 0, 2*len(ids))
+	for _, pair := range ids {
+		unique = append(unique, pair[0], pair[1])
+	}
+
+	rows, err = conn.Query(ctx, `
+		SELECT `+selectColumns+`
+		FROM transactions t
+		WHERE t.id = ANY($1)
+	`, unique)
+	if err != nil {
+		return nil, fmt.Errorf("get duplicate transactions: %w", err)
+	}
+	defer rows.Close()
+
+	byID := make(map[uuid.UUID]*transaction.Transaction, len(unique))
+	for rows.Next() {
+		t, err := scanTransaction(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan transaction row: %w", err)
+		}
+		byID[t.ID] = t
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate transaction rows: %w", err)
+	}
+
+	pairs := make([]*transaction.DuplicatePair, 0, len(ids))
+	for _, pair := range ids {
+		pairs = append(pairs, &transaction.DuplicatePair{
+			Original:  byID[pair[0]],
+			Duplicate: byID[pair[1]],
+		})
+	}
+
+	return pairs, nil
+}
+
 func (r *repository) Update(ctx context.Context, transaction *transaction.Transaction) error {
 	query := `
 		UPDATE transactions
 		SET amount = $1,
 			type = $2,
 			description = $3,
+			category_id = $4,
+			occurred_at = $5,
+			status = $6,
 			updated_at = NOW()
-		WHERE id = $4 AND deleted_at IS NULL
+		WHERE id = $7 AND deleted_at IS NULL AND status <> 'reconciled'
 		RETURNING updated_at
 	`
 
-	err := r.pool.QueryRow(ctx, query,
+	err := transactor.Conn(ctx, r.pool).QueryRow(ctx, query,
 		transaction.Amount,
 		transaction.Type,
 		transaction.Description,
+		transaction.CategoryID,
+		transaction.OccurredAt,
+		transaction.Status,
 		transaction.ID,
 	).Scan(&transaction.UpdatedAt)
 
@@ -171,6 +946,61 @@ func (r *repository) Update(ctx context.Context, transaction *transaction.Transa
 	return nil
 }
 
+func (r *repository) SetExternalID(ctx context.Context, id uuid.UUID, externalID *string) error {
+	query := `
+		UPDATE transactions
+		SET external_id = $1,
+			updated_at = NOW()
+		WHERE id = $2
+	`
+
+	result, err := transactor.Conn(ctx, r.pool).Exec(ctx, query, externalID, id)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.UniqueViolation {
+			return apperror.ErrTransactionImported
+		}
+		return fmt.Errorf("set transaction external id: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return apperror.ErrTransactionNotFound
+	}
+
+	return nil
+}
+
+func (r *repository) SetStatus(ctx context.Context, accountID uuid.UUID, ids []uuid.UUID, before time.Time, status transaction.Status) (int64, error) {
+	query := `
+		UPDATE transactions
+		SET status = $1, updated_at = NOW()
+		WHERE account_id = $2 AND id = ANY($3) AND deleted_at IS NULL AND status <> 'reconciled'
+		  AND occurred_at < $4
+	`
+
+	result, err := transactor.Conn(ctx, r.pool).Exec(ctx, query, status, accountID, ids, before)
+	if err != nil {
+		return 0, fmt.Errorf("set transaction status: %w", err)
+	}
+
+	return result.RowsAffected(), nil
+}
+
+func (r *repository) Reconcile(ctx context.Context, accountID uuid.UUID, before time.Time, reconciliationID uuid.UUID) (int64, error) {
+	query := `
+		UPDATE transactions
+		SET status = 'reconciled', reconciliation_id = $1, updated_at = NOW()
+		WHERE account_id = $2 AND deleted_at IS NULL AND status = 'cleared' AND occurred_at < $3
+	`
+
+	result, err := transactor.Conn(ctx, r.pool).Exec(ctx, query, reconciliationID, accountID, before)
+	if err != nil {
+		return 0, fmt.Errorf("reconcile transactions: %w", err)
+	}
+
+	return result.RowsAffected(), nil
+}
+
 func (r *repository) Delete(ctx context.Context, accountID, id uuid.UUID) error {
 	query := `
 		UPDATE transactions
@@ -178,7 +1008,7 @@ func (r *repository) Delete(ctx context.Context, accountID, id uuid.UUID) error
 		WHERE id = $1 AND account_id = $2 AND deleted_at IS NULL
 	`
 
-	result, err := r.pool.Exec(ctx, query, id, accountID)
+	result, err := transactor.Conn(ctx, r.pool).Exec(ctx, query, id, accountID)
 	if err != nil {
 		return fmt.Errorf("delete transaction: %w", err)
 	}
@@ -189,3 +1019,181 @@ func (r *repository) Delete(ctx context.Context, accountID, id uuid.UUID) error
 
 	return nil
 }
+
+func (r *repository) Restore(ctx context.Context, accountID, id uuid.UUID) error {
+	query := `
+		UPDATE transactions
+		SET deleted_at = NULL, updated_at = NOW()
+		WHERE id = $1 AND account_id = $2 AND deleted_at IS NOT NULL
+	`
+
+	result, err := transactor.Conn(ctx, r.pool).Exec(ctx, query, id, accountID)
+	if err != nil {
+		return fmt.Errorf("restore transaction: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return apperror.ErrTransactionNotFound
+	}
+
+	return nil
+}
+
+func (r *repository) ReplaceSplits(ctx context.Context, transactionID uuid.UUID, splits []transaction.Split) error {
+	conn := transactor.Conn(ctx, r.pool)
+
+	if _, err := conn.Exec(ctx, `DELETE FROM transaction_splits WHERE transaction_id = $1`, transactionID); err != nil {
+		return fmt.Errorf("delete transaction splits: %w", err)
+	}
+
+	query := `
+		INSERT INTO transaction_splits (transaction_id, category_id, amount, memo, position)
+		VALUES ($1, $2, $3, $4, $5)
+	`
+
+	for i, split := range splits {
+		if _, err := conn.Exec(ctx, query, transactionID, split.CategoryID, split.Amount, split.Memo, i); err != nil {
+			var pgErr *pgconn.PgError
+			if errors.As(err, &pgErr) {
+				switch pgErr.Code {
+				case pgerrcode.CheckViolation:
+					return apperror.ErrInvalidInput
+				case pgerrcode.ForeignKeyViolation:
+					return apperror.ErrCategoryNotFound
+				}
+			}
+			return fmt.Errorf("insert transaction split: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (r *repository) AddTags(ctx context.Context, transactionIDs, tagIDs []uuid.UUID) error {
+	query := `
+		INSERT INTO transaction_tags (transaction_id, tag_id)
+		SELECT transaction_id, tag_id
+		FROM UNNEST($1::uuid[]) AS transaction_id
+		CROSS JOIN UNNEST($2::uuid[]) AS tag_id
+		ON CONFLICT DO NOTHING
+	`
+
+	if _, err := transactor.Conn(ctx, r.pool).Exec(ctx, query, transactionIDs, tagIDs); err != nil {
+		return fmt.Errorf("add transaction tags: %w", err)
+	}
+
+	return nil
+}
+
+func (r *repository) RemoveTags(ctx context.Context, transactionID uuid.UUID, tagIDs []uuid.UUID) error {
+	query := `
+		DELETE FROM transaction_tags
+		WHERE transaction_id = $1 AND tag_id = ANY($2)
+	`
+
+	if _, err := transactor.Conn(ctx, r.pool).Exec(ctx, query, transactionID, tagIDs); err != nil {
+		return fmt.Errorf("remove transaction tags: %w", err)
+	}
+
+	return nil
+}
+
+func (r *repository) AddTagsByFilter(ctx context.Context, filter *transaction.Filter, tagIDs []uuid.UUID) (int64, error) {
+	var b queryBuilder
+
+	applyFilter(&b, filter)
+
+	query := fmt.Sprintf(`
+		INSERT INTO transaction_tags (transaction_id, tag_id)
+		SELECT t.id, tag_id
+		FROM transactions t
+		JOIN accounts a ON a.id = t.account_id
+		CROSS JOIN UNNEST(%s::uuid[]) AS tag_id
+		WHERE %s
+		ON CONFLICT DO NOTHING
+	`, b.arg(tagIDs), b.clause())
+
+	result, err := transactor.Conn(ctx, r.pool).Exec(ctx, query, b.args...)
+	if err != nil {
+		return 0, fmt.Errorf("add tags by filter: %w", err)
+	}
+
+	return result.RowsAffected(), nil
+}
+
+func (r *repository) ReassignCategory(ctx context.Context, fromCategoryID uuid.UUID, toCategoryID *uuid.UUID) error {
+	query := `
+		UPDATE transactions
+		SET category_id = $2, updated_at = NOW()
+		WHERE category_id = $1
+	`
+
+	if _, err := transactor.Conn(ctx, r.pool).Exec(ctx, query, fromCategoryID, toCategoryID); err != nil {
+		return fmt.Errorf("reassign transaction category: %w", err)
+	}
+
+	splits := `
+		UPDATE transaction_splits
+		SET category_id = $2
+		WHERE category_id = $1
+	`
+
+	if _, err := transactor.Conn(ctx, r.pool).Exec(ctx, splits, fromCategoryID, toCategoryID); err != nil {
+		return fmt.Errorf("reassign split category: %w", err)
+	}
+
+	return nil
+}
+
+func scanTransaction(row pgx.Row) (*transaction.Transaction, error) {
+	var t transaction.Transaction
+	var description pgtype.Text
+	var deletedAt pgtype.Timestamptz
+	var splits []byte
+
+	err := row.Scan(
+		&t.ID,
+		&t.AccountID,
+		&t.Amount,
+		&t.Type,
+		&description,
+		&t.Status,
+		&t.ReconciliationID,
+		&t.CategoryID,
+		&t.TransferID,
+		&t.RecurringRuleID,
+		&t.OccurrenceDate,
+		&t.ExternalID,
+		&t.OccurredAt,
+		&t.CreatedAt,
+		&t.UpdatedAt,
+		&deletedAt,
+		&t.Tags,
+		&splits,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []splitRow
+	if err := json.Unmarshal(splits, &rows); err != nil {
+		return nil, fmt.Errorf("unmarshal splits: %w", err)
+	}
+
+	for _, row := range rows {
+		t.Splits = append(t.Splits, transaction.Split{
+			ID:         row.ID,
+			CategoryID: row.CategoryID,
+			Amount:     row.Amount,
+			Memo:       row.Memo,
+		})
+	}
+
+	t.Description = description.String
+
+	if deletedAt.Valid {
+		t.DeletedAt = &deletedAt.Time
+	}
+
+	return &t, nil
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/nontypeable/financial-tracker/internal/domain/user"
 	apperror "github.com/nontypeable/financial-tracker/internal/errors"
+	"github.com/nontypeable/financial-tracker/internal/transactor"
 )
 
 type repository struct {
@@ -32,7 +33,7 @@ func (r *repository) Create(ctx context.Context, user *user.User) (uuid.UUID, er
 
 	var id uuid.UUID
 
-	err := r.pool.QueryRow(ctx, query,
+	err := transactor.Conn(ctx, r.pool).QueryRow(ctx, query,
 		user.Email,
 		user.PasswordHash,
 		user.FirstName,
@@ -66,7 +67,7 @@ func (r *repository) GetByID(ctx context.Context, id uuid.UUID) (*user.User, err
 	var u user.User
 	var deletedAt pgtype.Timestamptz
 
-	err := r.pool.QueryRow(ctx, query, id).Scan(
+	err := transactor.Conn(ctx, r.pool).QueryRow(ctx, query, id).Scan(
 		&u.ID,
 		&u.Email,
 		&u.PasswordHash,
@@ -101,7 +102,7 @@ func (r *repository) GetByEmail(ctx context.Context, email string) (*user.User,
 	var u user.User
 	var deletedAt pgtype.Timestamptz
 
-	err := r.pool.QueryRow(ctx, query, email).Scan(
+	err := transactor.Conn(ctx, r.pool).QueryRow(ctx, query, email).Scan(
 		&u.ID,
 		&u.Email,
 		&u.PasswordHash,
@@ -138,7 +139,7 @@ func (r *repository) Update(ctx context.Context, u *user.User) error {
         RETURNING updated_at
     `
 
-	err := r.pool.QueryRow(ctx, query,
+	err := transactor.Conn(ctx, r.pool).QueryRow(ctx, query,
 		u.Email,
 		u.PasswordHash,
 		u.FirstName,
@@ -173,7 +174,7 @@ func (r *repository) Delete(ctx context.Context, id uuid.UUID) error {
         WHERE id = $1 AND deleted_at IS NULL
     `
 
-	result, err := r.pool.Exec(ctx, query, id)
+	result, err := transactor.Conn(ctx, r.pool).Exec(ctx, query, id)
 	if err != nil {
 		return fmt.Errorf("delete user: %w", err)
 	}
@@ -189,7 +190,7 @@ func (r *repository) EmailExists(ctx context.Context, email string) (bool, error
 	const query = `SELECT EXISTS(SELECT 1 FROM users WHERE email = $1 AND deleted_at IS NULL);`
 
 	var exists bool
-	err := r.pool.QueryRow(ctx, query, email).Scan(&exists)
+	err := transactor.Conn(ctx, r.pool).QueryRow(ctx, query, email).Scan(&exists)
 	if err != nil {
 		return false, fmt.Errorf("check email existence: %w", err)
 	}
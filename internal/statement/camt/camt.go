@@ -0,0 +1,343 @@
+// Package camt parses ISO 20022 camt.053 bank-to-customer statements. The
+// element names are the same across message versions, so any version is
+// read, and namespaces are ignored.
+package camt
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	apperror "github.com/nontypeable/financial-tracker/internal/errors"
+	"github.com/nontypeable/financial-tracker/internal/statement"
+	"github.com/shopspring/decimal"
+)
+
+type amount struct {
+	Value    string `xml:",chardata"`
+	Currency string `xml:"Ccy,attr"`
+}
+
+type dateChoice struct {
+	Date     string `xml:"Dt"`
+	DateTime string `xml:"DtTm"`
+}
+
+type balance struct {
+	Code      string     `xml:"Tp>CdOrPrtry>Cd"`
+	Amount    amount     `xml:"Amt"`
+	Indicator string     `xml:"CdtDbtInd"`
+	Date      dateChoice `xml:"Dt"`
+}
+
+// party covers both the flat name of early versions and the nested Pty
+// element of later ones.
+type party struct {
+	Name      string `xml:"Nm"`
+	PartyName string `xml:"Pty>Nm"`
+}
+
+type partyAccount struct {
+	IBAN  string `xml:"Id>IBAN"`
+	Other string `xml:"Id>Othr>Id"`
+}
+
+type references struct {
+	AccountServicerReference string `xml:"AcctSvcrRef"`
+	EndToEndID               string `xml:"EndToEndId"`
+	TransactionID            string `xml:"TxId"`
+}
+
+type details struct {
+	Refs                references   `xml:"Refs"`
+	Amount              amount       `xml:"Amt"`
+	TransactionAmount   amount       `xml:"AmtDtls>TxAmt>Amt"`
+	Indicator           string       `xml:"CdtDbtInd"`
+	Debtor              party        `xml:"RltdPties>Dbtr"`
+	DebtorAccount       partyAccount `xml:"RltdPties>DbtrAcct"`
+	Creditor            party        `xml:"RltdPties>Cdtr"`
+	CreditorAccount     partyAccount `xml:"RltdPties>CdtrAcct"`
+	Unstructured        []string     `xml:"RmtInf>Ustrd"`
+	StructuredReference []string     `xml:"RmtInf>Strd>CdtrRefInf>Ref"`
+	AdditionalInfo      string       `xml:"AddtlTxInf"`
+}
+
+// status is plain text in early versions and a Cd element in later ones.
+type status struct {
+	Value string `xml:",chardata"`
+	Code  string `xml:"Cd"`
+}
+
+type entry struct {
+	Reference                string     `xml:"NtryRef"`
+	Amount                   amount     `xml:"Amt"`
+	Indicator                string     `xml:"CdtDbtInd"`
+	Status                   status     `xml:"Sts"`
+	BookingDate              dateChoice `xml:"BookgDt"`
+	ValueDate                dateChoice `xml:"ValDt"`
+	AccountServicerReference string     `xml:"AcctSvcrRef"`
+	Details                  []details  `xml:"NtryDtls>TxDtls"`
+	AdditionalInfo           string     `xml:"AddtlNtryInf"`
+}
+
+// Parse reads every Bal and Ntry element of the file. Entries are numbered
+// by the line their Ntry element starts on. A file holding several
+// statements, such as one per day, yields the first opening and the last
+// closing balance.
+func Parse(r io.Reader) (*statement.Statement, error) {
+	decoder := xml.NewDecoder(r)
+	decoder.CharsetReader = func(charset string, input io.Reader) (io.Reader, error) {
+		return statement.Decode(input, charset)
+	}
+
+	var (
+		stmt  statement.Statement
+		found bool
+	)
+
+	for {
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", apperror.ErrStatementMalformed, err)
+		}
+
+		start, ok := token.(xml.StartElement)
+		if !ok {
+			continue
+		}
+
+		line, _ := decoder.InputPos()
+
+		switch start.Name.Local {
+		case "Bal":
+			found = true
+
+			var bal balance
+			if err := decoder.DecodeElement(&bal, &start); err != nil {
+				return nil, fmt.Errorf("%w: %v", apperror.ErrStatementMalformed, err)
+			}
+			if err := addBalance(&stmt, bal); err != nil {
+				return nil, fmt.Errorf("%w: balance on line %d: %v", apperror.ErrStatementMalformed, line, err)
+			}
+		case "Ntry":
+			found = true
+
+			var ntry entry
+			if err := decoder.DecodeElement(&ntry, &start); err != nil {
+				return nil, fmt.Errorf("%w: %v", apperror.ErrStatementMalformed, err)
+			}
+			addEntries(&stmt, line, ntry)
+		}
+	}
+
+	if !found {
+		return nil, fmt.Errorf("%w: no camt.053 statement found", apperror.ErrStatementMalformed)
+	}
+
+	return &stmt, nil
+}
+
+func addBalance(stmt *statement.Statement, bal balance) error {
+	switch bal.Code {
+	case "OPBD", "PRCD", "CLBD":
+	default:
+		return nil
+	}
+
+	value, err := signed(bal.Amount, bal.Indicator)
+	if err != nil {
+		return err
+	}
+
+	date, err := parseDate(bal.Date)
+	if err != nil {
+		return err
+	}
+
+	b := &statement.Balance{Amount: value, Date: date}
+	if bal.Code == "CLBD" {
+		stmt.ClosingBalance = b
+	} else if stmt.OpeningBalance == nil {
+		stmt.OpeningBalance = b
+	}
+
+	return nil
+}
+
+// addEntries turns an Ntry into statement entries. A batch booking with
+// several transaction details becomes one entry per detail.
+func addEntries(stmt *statement.Statement, line int, ntry entry) {
+	if code := ntry.statusCode(); code != "" && code != "BOOK" {
+		stmt.AddError(line, "entry status %s is not booked", code)
+		return
+	}
+
+	valueDate, err := parseDate(ntry.ValueDate)
+	bookingDate, bookingErr := parseDate(ntry.BookingDate)
+	switch {
+	case err != nil && bookingErr != nil:
+		stmt.AddError(line, "entry has no valid booking or value date")
+		return
+	case err != nil:
+		valueDate = bookingDate
+	}
+
+	base := statement.Entry{Line: line, Date: valueDate}
+	if bookingErr == nil {
+		base.BookingDate = &bookingDate
+	}
+
+	if len(ntry.Details) > 1 {
+		for i, d := range ntry.Details {
+			e := base
+
+			value := d.Amount
+			if value.Value == "" {
+				value = d.TransactionAmount
+			}
+			indicator := d.Indicator
+			if indicator == "" {
+				indicator = ntry.Indicator
+			}
+
+			e.Amount, err = signed(value, indicator)
+			if err != nil {
+				stmt.AddError(line, "transaction %d: %v", i+1, err)
+				continue
+			}
+
+			describe(&e, d, indicator)
+			e.ExternalID = d.Refs.id()
+			if e.ExternalID == "" && ntry.ref() != "" {
+				e.ExternalID = fmt.Sprintf("%s/%d", ntry.ref(), i+1)
+			}
+
+			stmt.Entries = append(stmt.Entries, e)
+		}
+		return
+	}
+
+	e := base
+	e.Amount, err = signed(ntry.Amount, ntry.Indicator)
+	if err != nil {
+		stmt.AddError(line, "%v", err)
+		return
+	}
+
+	e.ExternalID = ntry.ref()
+	if len(ntry.Details) == 1 {
+		describe(&e, ntry.Details[0], ntry.Indicator)
+		if e.ExternalID == "" {
+			e.ExternalID = ntry.Details[0].Refs.id()
+		}
+	}
+	if e.Description == "" {
+		e.Description = strings.TrimSpace(ntry.AdditionalInfo)
+	}
+
+	stmt.Entries = append(stmt.Entries, e)
+}
+
+// describe fills the counterparty and the description. For incoming money
+// the counterparty is the debtor, for outgoing money the creditor.
+func describe(e *statement.Entry, d details, indicator string) {
+	p, account := d.Creditor, d.CreditorAccount
+	if indicator == "CRDT" {
+		p, account = d.Debtor, d.DebtorAccount
+	}
+
+	e.Counterparty = strings.TrimSpace(p.Name)
+	if e.Counterparty == "" {
+		e.Counterparty = strings.TrimSpace(p.PartyName)
+	}
+
+	e.CounterpartyAccount = strings.TrimSpace(account.IBAN)
+	if e.CounterpartyAccount == "" {
+		e.CounterpartyAccount = strings.TrimSpace(account.Other)
+	}
+
+	remittance := strings.TrimSpace(strings.Join(d.Unstructured, " "))
+	if remittance == "" {
+		remittance = strings.TrimSpace(strings.Join(d.StructuredReference, " "))
+	}
+	if remittance == "" {
+		remittance = strings.TrimSpace(d.AdditionalInfo)
+	}
+
+	e.Description = statement.Describe(e.Counterparty, remittance)
+}
+
+func (n entry) statusCode() string {
+	if n.Status.Code != "" {
+		return strings.TrimSpace(n.Status.Code)
+	}
+	return strings.TrimSpace(n.Status.Value)
+}
+
+// ref is the bank's reference for the entry, preferring the servicer's
+// own one.
+func (n entry) ref() string {
+	if n.AccountServicerReference != "" {
+		return n.AccountServicerReference
+	}
+	return n.Reference
+}
+
+func (r references) id() string {
+	switch {
+	case r.AccountServicerReference != "":
+		return r.AccountServicerReference
+	case r.TransactionID != "" && r.TransactionID != "NOTPROVIDED":
+		return r.TransactionID
+	case r.EndToEndID != "" && r.EndToEndID != "NOTPROVIDED":
+		return r.EndToEndID
+	default:
+		return ""
+	}
+}
+
+// signed applies the credit/debit indicator to an amount. Amounts are
+// unsigned in camt, so a negative one is as invalid as a zero one.
+func signed(a amount, indicator string) (decimal.Decimal, error) {
+	value, err := decimal.NewFromString(strings.TrimSpace(a.Value))
+	if err != nil || value.IsNegative() {
+		return decimal.Zero, fmt.Errorf("invalid amount %q", a.Value)
+	}
+	if value.IsZero() {
+		return decimal.Zero, errors.New("amount is zero")
+	}
+
+	switch indicator {
+	case "DBIT":
+		return value.Neg(), nil
+	case "CRDT":
+		return value, nil
+	default:
+		return decimal.Zero, fmt.Errorf("invalid credit/debit indicator %q", indicator)
+	}
+}
+
+// parseDate reads the date of a Dt or DtTm element; the time of day is
+// dropped.
+func parseDate(d dateChoice) (time.Time, error) {
+	raw := strings.TrimSpace(d.Date)
+	if raw == "" {
+		raw = strings.TrimSpace(d.DateTime)
+	}
+	if len(raw) < 10 {
+		return time.Time{}, fmt.Errorf("invalid date %q", raw)
+	}
+
+	date, err := time.Parse(time.DateOnly, raw[:10])
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date %q", raw)
+	}
+
+	return date, nil
+}
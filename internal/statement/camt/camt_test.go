@@ -0,0 +1,118 @@
+package camt
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nontypeable/financial-tracker/internal/statement"
+	"github.com/shopspring/decimal"
+)
+
+type wantEntry struct {
+	line                int
+	date                string
+	bookingDate         string
+	amount              string
+	description         string
+	counterparty        string
+	counterpartyAccount string
+	externalID          string
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		entries []wantEntry
+		errors  []statement.RowError
+		opening string
+		closing string
+	}{
+		{
+			name: "camt.053 with a batch booking",
+			file: "statement.xml",
+			entries: []wantEntry{
+				{20, "2024-01-04", "2024-01-03", "-30", "Telco AG - Invoice 42", "Telco AG", "DE02100100100006820101", "SVC-1"},
+				// Each detail of the batch becomes an entry of its own;
+				// the one without a reference gets the batch's.
+				{39, "2024-01-10", "2024-01-10", "600", "Client One - RF18539007547034", "Client One", "", "E2E-1"},
+				{39, "2024-01-10", "2024-01-10", "300", "Client Two - Consulting", "Client Two", "", "BATCH-7/2"},
+				{77, "2024-01-31", "", "-12", "Account fee", "", "", ""},
+			},
+			errors: []statement.RowError{
+				{Line: 39, Message: "transaction 3: amount is zero"},
+				{Line: 39, Message: `transaction 4: invalid amount "-5.00"`},
+				{Line: 65, Message: "amount is zero"},
+				{Line: 71, Message: "entry status PDNG is not booked"},
+			},
+			opening: "500 2024-01-01",
+			closing: "1370 2024-01-31",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := os.Open(filepath.Join("testdata", tt.file))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer f.Close()
+
+			stmt, err := Parse(f)
+			if err != nil {
+				t.Fatalf("Parse: %v", err)
+			}
+
+			if len(stmt.Entries) != len(tt.entries) {
+				t.Fatalf("got %d entries, want %d: %+v", len(stmt.Entries), len(tt.entries), stmt.Entries)
+			}
+			for i, want := range tt.entries {
+				got := stmt.Entries[i]
+
+				bookingDate := ""
+				if got.BookingDate != nil {
+					bookingDate = got.BookingDate.Format(time.DateOnly)
+				}
+
+				if got.Line != want.line || got.Date.Format(time.DateOnly) != want.date || bookingDate != want.bookingDate ||
+					!got.Amount.Equal(decimal.RequireFromString(want.amount)) || got.Description != want.description ||
+					got.Counterparty != want.counterparty || got.CounterpartyAccount != want.counterpartyAccount ||
+					got.ExternalID != want.externalID {
+					t.Errorf("entry %d = %+v, want %+v", i, got, want)
+				}
+			}
+
+			if len(stmt.Errors) != len(tt.errors) {
+				t.Fatalf("got errors %+v, want %+v", stmt.Errors, tt.errors)
+			}
+			for i, want := range tt.errors {
+				if stmt.Errors[i] != want {
+					t.Errorf("error %d = %+v, want %+v", i, stmt.Errors[i], want)
+				}
+			}
+
+			if got := formatBalance(stmt.OpeningBalance); got != tt.opening {
+				t.Errorf("opening balance = %s, want %s", got, tt.opening)
+			}
+			if got := formatBalance(stmt.ClosingBalance); got != tt.closing {
+				t.Errorf("closing balance = %s, want %s", got, tt.closing)
+			}
+		})
+	}
+}
+
+func TestParseWithoutStatement(t *testing.T) {
+	if _, err := Parse(strings.NewReader(`<?xml version="1.0"?><Document></Document>`)); err == nil {
+		t.Error("Parse of a document without a statement succeeded")
+	}
+}
+
+func formatBalance(b *statement.Balance) string {
+	if b == nil {
+		return ""
+	}
+	return b.Amount.String() + " " + b.Date.Format(time.DateOnly)
+}
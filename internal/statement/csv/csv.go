@@ -0,0 +1,285 @@
+// Package csv parses delimited bank exports according to a column mapping
+// profile.
+package csv
+
+import (
+	stdcsv "encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	apperror "github.com/nontypeable/financial-tracker/internal/errors"
+	"github.com/nontypeable/financial-tracker/internal/statement"
+	"github.com/shopspring/decimal"
+)
+
+// Profile describes how a bank lays out its CSV export. Columns are header
+// names when HasHeader is set and 1-based positions otherwise.
+type Profile struct {
+	Delimiter          string   `json:"delimiter"`
+	HasHeader          bool     `json:"has_header"`
+	SkipRows           int      `json:"skip_rows"`
+	DateColumn         string   `json:"date_column"`
+	DateFormat         string   `json:"date_format"`
+	AmountColumn       string   `json:"amount_column,omitempty"`
+	DebitColumn        string   `json:"debit_column,omitempty"`
+	CreditColumn       string   `json:"credit_column,omitempty"`
+	DescriptionColumns []string `json:"description_columns"`
+	// NegateAmount flips the sign for banks that export spending as
+	// positive numbers in a single amount column.
+	NegateAmount       bool   `json:"negate_amount"`
+	DecimalSeparator   string `json:"decimal_separator"`
+	ThousandsSeparator string `json:"thousands_separator"`
+	Encoding           string `json:"encoding"`
+}
+
+func (p *Profile) Validate() error {
+	if p.DateColumn == "" {
+		return fmt.Errorf("%w: date column is required", apperror.ErrInvalidInput)
+	}
+	if p.AmountColumn == "" && p.DebitColumn == "" && p.CreditColumn == "" {
+		return fmt.Errorf("%w: an amount column or debit/credit columns are required", apperror.ErrInvalidInput)
+	}
+	if p.AmountColumn != "" && (p.DebitColumn != "" || p.CreditColumn != "") {
+		return fmt.Errorf("%w: amount and debit/credit columns are mutually exclusive", apperror.ErrInvalidInput)
+	}
+	if len([]rune(p.Delimiter)) > 1 {
+		return fmt.Errorf("%w: delimiter must be a single character", apperror.ErrInvalidInput)
+	}
+	if p.DecimalSeparator != "" && p.DecimalSeparator == p.ThousandsSeparator {
+		return fmt.Errorf("%w: decimal and thousands separators must differ", apperror.ErrInvalidInput)
+	}
+	return nil
+}
+
+// Layout converts a date format such as "DD.MM.YYYY" into a Go time
+// layout. Formats that already use Go reference values pass through.
+func (p *Profile) Layout() string {
+	if p.DateFormat == "" {
+		return time.DateOnly
+	}
+	if strings.Contains(p.DateFormat, "2006") {
+		return p.DateFormat
+	}
+	return strings.NewReplacer(
+		"YYYY", "2006",
+		"YY", "06",
+		"MM", "01",
+		"DD", "02",
+		"M", "1",
+		"D", "2",
+	).Replace(p.DateFormat)
+}
+
+// Parse reads the whole file. Structural problems, such as a missing
+// header column, fail the parse; problems with individual lines are
+// reported as row errors.
+func Parse(r io.Reader, profile Profile) (*statement.Statement, error) {
+	if err := profile.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", apperror.ErrStatementMalformed, err)
+	}
+
+	decoded, err := statement.Decode(r, profile.Encoding)
+	if err != nil {
+		return nil, err
+	}
+
+	reader := stdcsv.NewReader(decoded)
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+	if profile.Delimiter != "" {
+		reader.Comma = []rune(profile.Delimiter)[0]
+	}
+
+	decimalSeparator := profile.DecimalSeparator
+	if decimalSeparator == "" {
+		decimalSeparator = "."
+	}
+
+	var (
+		stmt    statement.Statement
+		columns *columnIndex
+		records int
+	)
+
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		records++
+
+		if err != nil {
+			var parseErr *stdcsv.ParseError
+			if errors.As(err, &parseErr) {
+				stmt.AddError(parseErr.StartLine, "%v", parseErr.Err)
+				continue
+			}
+			return nil, fmt.Errorf("read csv: %w", err)
+		}
+
+		// The reader drops empty lines and lets quoted fields span several,
+		// so the record count is not the line the record starts on.
+		line, _ := reader.FieldPos(0)
+
+		if records <= profile.SkipRows || isBlank(record) {
+			continue
+		}
+
+		if columns == nil {
+			var header []string
+			if profile.HasHeader {
+				header = record
+			}
+
+			columns, err = resolveColumns(profile, header)
+			if err != nil {
+				return nil, fmt.Errorf("%w: %v", apperror.ErrStatementMalformed, err)
+			}
+
+			if profile.HasHeader {
+				continue
+			}
+		}
+
+		entry, err := columns.entry(record, profile.Layout(), decimalSeparator, profile.ThousandsSeparator)
+		if err != nil {
+			stmt.AddError(line, "%v", err)
+			continue
+		}
+
+		if profile.NegateAmount {
+			entry.Amount = entry.Amount.Neg()
+		}
+		entry.Line = line
+
+		stmt.Entries = append(stmt.Entries, entry)
+	}
+
+	return &stmt, nil
+}
+
+// columnIndex holds 0-based positions; -1 marks an unmapped column.
+type columnIndex struct {
+	date         int
+	amount       int
+	debit        int
+	credit       int
+	descriptions []int
+}
+
+func resolveColumns(profile Profile, header []string) (*columnIndex, error) {
+	lookup := func(name string) (int, error) {
+		if name == "" {
+			return -1, nil
+		}
+
+		if header != nil {
+			for i, h := range header {
+				if strings.EqualFold(strings.TrimSpace(h), strings.TrimSpace(name)) {
+					return i, nil
+				}
+			}
+			return -1, fmt.Errorf("column %q not found in header", name)
+		}
+
+		n, err := strconv.Atoi(name)
+		if err != nil || n < 1 {
+			return -1, fmt.Errorf("column %q must be a 1-based position when the file has no header", name)
+		}
+		return n - 1, nil
+	}
+
+	var (
+		idx columnIndex
+		err error
+	)
+
+	if idx.date, err = lookup(profile.DateColumn); err != nil {
+		return nil, err
+	}
+	if idx.amount, err = lookup(profile.AmountColumn); err != nil {
+		return nil, err
+	}
+	if idx.debit, err = lookup(profile.DebitColumn); err != nil {
+		return nil, err
+	}
+	if idx.credit, err = lookup(profile.CreditColumn); err != nil {
+		return nil, err
+	}
+
+	for _, name := range profile.DescriptionColumns {
+		i, err := lookup(name)
+		if err != nil {
+			return nil, err
+		}
+		idx.descriptions = append(idx.descriptions, i)
+	}
+
+	return &idx, nil
+}
+
+func (c *columnIndex) entry(record []string, layout, decimalSeparator, thousandsSeparator string) (statement.Entry, error) {
+	var entry statement.Entry
+
+	field := func(i int) string {
+		if i < 0 || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	rawDate := field(c.date)
+	date, err := time.Parse(layout, rawDate)
+	if err != nil {
+		return entry, fmt.Errorf("invalid date %q", rawDate)
+	}
+	entry.Date = date
+
+	if c.amount >= 0 {
+		entry.Amount, err = statement.ParseAmount(field(c.amount), decimalSeparator, thousandsSeparator)
+		if err != nil {
+			return entry, err
+		}
+	} else {
+		// Debit and credit are read as magnitudes; usually only one is filled.
+		var debit, credit decimal.Decimal
+		if raw := field(c.debit); raw != "" {
+			if debit, err = statement.ParseAmount(raw, decimalSeparator, thousandsSeparator); err != nil {
+				return entry, err
+			}
+		}
+		if raw := field(c.credit); raw != "" {
+			if credit, err = statement.ParseAmount(raw, decimalSeparator, thousandsSeparator); err != nil {
+				return entry, err
+			}
+		}
+		entry.Amount = credit.Abs().Sub(debit.Abs())
+	}
+
+	if entry.Amount.IsZero() {
+		return entry, errors.New("amount is zero")
+	}
+
+	var parts []string
+	for _, i := range c.descriptions {
+		if s := field(i); s != "" {
+			parts = append(parts, s)
+		}
+	}
+	entry.Description = strings.Join(parts, " ")
+
+	return entry, nil
+}
+
+func isBlank(record []string) bool {
+	for _, field := range record {
+		if strings.TrimSpace(field) != "" {
+			return false
+		}
+	}
+	return true
+}
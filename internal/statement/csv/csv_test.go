@@ -0,0 +1,203 @@
+package csv
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	apperror "github.com/nontypeable/financial-tracker/internal/errors"
+	"github.com/nontypeable/financial-tracker/internal/statement"
+	"github.com/shopspring/decimal"
+)
+
+type wantEntry struct {
+	line        int
+	date        string
+	amount      string
+	description string
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		profile Profile
+		entries []wantEntry
+		errors  []statement.RowError
+	}{
+		{
+			name: "header with preamble and decimal comma",
+			file: "giro.csv",
+			profile: Profile{
+				Delimiter:          ";",
+				HasHeader:          true,
+				SkipRows:           2,
+				DateColumn:         "Buchungstag",
+				DateFormat:         "DD.MM.YYYY",
+				AmountColumn:       "betrag",
+				DescriptionColumns: []string{"Empfänger", "Verwendungszweck"},
+				DecimalSeparator:   ",",
+				ThousandsSeparator: ".",
+			},
+			entries: []wantEntry{
+				{4, "2024-01-02", "-1234.56", "Stadtwerke Abschlag Januar"},
+				// The quoted purpose spans lines 5 and 6, and line 7 is blank;
+				// entries still report the line they start on.
+				{5, "2024-01-05", "2500", "ACME GmbH Gehalt\nJanuar"},
+				{8, "2024-01-10", "-3.2", "Bäckerei"},
+				{12, "2024-01-25", "-12.5", "Shop Rückgabe"},
+			},
+			errors: []statement.RowError{
+				{Line: 9, Message: `invalid date "2024-01-12"`},
+				{Line: 10, Message: "amount is zero"},
+				{Line: 11, Message: `invalid amount "abc"`},
+			},
+		},
+		{
+			name: "positions with debit and credit columns",
+			file: "card.csv",
+			profile: Profile{
+				DateColumn:         "1",
+				DateFormat:         "MM/DD/YYYY",
+				DebitColumn:        "3",
+				CreditColumn:       "4",
+				DescriptionColumns: []string{"2"},
+				ThousandsSeparator: ",",
+			},
+			entries: []wantEntry{
+				{1, "2024-01-03", "-4.5", "Coffee Shop"},
+				{2, "2024-01-04", "2000", "Payroll"},
+				// Debit and credit are magnitudes whatever their notation.
+				{3, "2024-01-05", "10", "Refund"},
+				{5, "2024-01-07", "-1", `Bad "quote" row`},
+			},
+			errors: []statement.RowError{
+				{Line: 4, Message: "amount is zero"},
+			},
+		},
+		{
+			name: "windows-1252 tab separated with spending as positive",
+			file: "export.tsv",
+			profile: Profile{
+				Delimiter:          "\t",
+				HasHeader:          true,
+				DateColumn:         "Date",
+				AmountColumn:       "Amount",
+				DescriptionColumns: []string{"Payee"},
+				NegateAmount:       true,
+				Encoding:           "windows-1252",
+			},
+			entries: []wantEntry{
+				{2, "2024-02-01", "-4.5", "Café Central"},
+				{3, "2024-02-02", "20", "Refund"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := os.Open(filepath.Join("testdata", tt.file))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer f.Close()
+
+			stmt, err := Parse(f, tt.profile)
+			if err != nil {
+				t.Fatalf("Parse: %v", err)
+			}
+
+			if len(stmt.Entries) != len(tt.entries) {
+				t.Fatalf("got %d entries, want %d: %+v", len(stmt.Entries), len(tt.entries), stmt.Entries)
+			}
+			for i, want := range tt.entries {
+				got := stmt.Entries[i]
+				if got.Line != want.line || got.Date.Format(time.DateOnly) != want.date ||
+					!got.Amount.Equal(decimal.RequireFromString(want.amount)) || got.Description != want.description {
+					t.Errorf("entry %d = %d %s %s %q, want %+v", i,
+						got.Line, got.Date.Format(time.DateOnly), got.Amount, got.Description, want)
+				}
+			}
+
+			if len(stmt.Errors) != len(tt.errors) {
+				t.Fatalf("got errors %+v, want %+v", stmt.Errors, tt.errors)
+			}
+			for i, want := range tt.errors {
+				if stmt.Errors[i] != want {
+					t.Errorf("error %d = %+v, want %+v", i, stmt.Errors[i], want)
+				}
+			}
+		})
+	}
+}
+
+func TestParseMalformed(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		profile Profile
+	}{
+		{
+			name:    "no amount column",
+			input:   "2024-01-01,1.00\n",
+			profile: Profile{DateColumn: "1"},
+		},
+		{
+			name:    "amount and debit columns",
+			input:   "2024-01-01,1.00\n",
+			profile: Profile{DateColumn: "1", AmountColumn: "2", DebitColumn: "2"},
+		},
+		{
+			name:    "same decimal and thousands separator",
+			input:   "2024-01-01,1.00\n",
+			profile: Profile{DateColumn: "1", AmountColumn: "2", DecimalSeparator: ".", ThousandsSeparator: "."},
+		},
+		{
+			name:    "column missing from the header",
+			input:   "Date,Amount\n2024-01-01,1.00\n",
+			profile: Profile{HasHeader: true, DateColumn: "Date", AmountColumn: "Value"},
+		},
+		{
+			name:    "column name without a header",
+			input:   "2024-01-01,1.00\n",
+			profile: Profile{DateColumn: "Date", AmountColumn: "2"},
+		},
+		{
+			name:    "unsupported encoding",
+			input:   "2024-01-01,1.00\n",
+			profile: Profile{DateColumn: "1", AmountColumn: "2", Encoding: "klingon"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(strings.NewReader(tt.input), tt.profile)
+			if !errors.Is(err, apperror.ErrStatementMalformed) {
+				t.Errorf("Parse error = %v, want %v", err, apperror.ErrStatementMalformed)
+			}
+		})
+	}
+}
+
+func TestLayout(t *testing.T) {
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{"", time.DateOnly},
+		{"DD.MM.YYYY", "02.01.2006"},
+		{"MM/DD/YY", "01/02/06"},
+		{"D/M/YYYY", "2/1/2006"},
+		{"02 Jan 2006", "02 Jan 2006"},
+	}
+
+	for _, tt := range tests {
+		p := Profile{DateFormat: tt.format}
+		if got := p.Layout(); got != tt.want {
+			t.Errorf("Layout(%q) = %q, want %q", tt.format, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,344 @@
+// Package mt940 parses SWIFT MT940 customer statements, with or without
+// the SWIFT envelope, including the structured :86: layouts used by German
+// (GVC) and Dutch banks.
+package mt940
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	apperror "github.com/nontypeable/financial-tracker/internal/errors"
+	"github.com/nontypeable/financial-tracker/internal/statement"
+)
+
+var (
+	fieldTag = regexp.MustCompile(`^:(\d{2}[A-Z]?):(.*)$`)
+	// statementLine is the first line of a :61: field: value date, optional
+	// booking date, debit/credit mark, optional funds code, amount,
+	// transaction type and references.
+	statementLine = regexp.MustCompile(`^(\d{6})(\d{4})?(RC|RD|C|D)([A-Z])?(\d+,\d*)([NSF][A-Z0-9]{3})(.*)$`)
+	balanceLine   = regexp.MustCompile(`^([CD])(\d{6})([A-Z]{3})(\d+,\d*)`)
+	gvcHeader     = regexp.MustCompile(`^\d{3}(\D)`)
+	sepaTag       = regexp.MustCompile(`[A-Z]{4}\+`)
+	slashKey      = regexp.MustCompile(`/(TRTP|IBAN|BIC|NAME|REMI|EREF|MARF|CSID|ORDP|BENM|ADDR|ISDT|PREF|RTRN)/`)
+)
+
+type field struct {
+	line  int
+	tag   string
+	value string
+}
+
+// Parse reads every statement of the file. Entries are numbered by the
+// line of their :61: field. The first opening and the last closing balance
+// are kept.
+func Parse(r io.Reader, encoding string) (*statement.Statement, error) {
+	decoded, err := statement.Decode(r, encoding)
+	if err != nil {
+		return nil, err
+	}
+
+	fields, err := scan(decoded)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		stmt    statement.Statement
+		pending *statement.Entry
+		found   bool
+	)
+
+	flush := func() {
+		if pending != nil {
+			stmt.Entries = append(stmt.Entries, *pending)
+			pending = nil
+		}
+	}
+
+	for _, f := range fields {
+		switch f.tag {
+		case "60F", "60M":
+			found = true
+			if stmt.OpeningBalance != nil {
+				continue
+			}
+			b, err := parseBalance(f.value)
+			if err != nil {
+				return nil, fmt.Errorf("%w: opening balance on line %d: %v", apperror.ErrStatementMalformed, f.line, err)
+			}
+			stmt.OpeningBalance = b
+		case "62F", "62M":
+			found = true
+			b, err := parseBalance(f.value)
+			if err != nil {
+				return nil, fmt.Errorf("%w: closing balance on line %d: %v", apperror.ErrStatementMalformed, f.line, err)
+			}
+			stmt.ClosingBalance = b
+		case "61":
+			found = true
+			flush()
+
+			e, err := parseEntry(f.value)
+			if err != nil {
+				stmt.AddError(f.line, "%v", err)
+				continue
+			}
+			e.Line = f.line
+			pending = &e
+		case "86":
+			if pending != nil {
+				describe(pending, f.value)
+				flush()
+			}
+		default:
+			flush()
+		}
+	}
+	flush()
+
+	if !found {
+		return nil, fmt.Errorf("%w: no MT940 statement found", apperror.ErrStatementMalformed)
+	}
+
+	return &stmt, nil
+}
+
+// scan splits the file into fields. Continuation lines are joined to their
+// field with a newline; envelope blocks and message separators are dropped.
+func scan(r io.Reader) ([]field, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	var (
+		fields []field
+		line   int
+	)
+
+	for scanner.Scan() {
+		line++
+		text := strings.TrimRight(scanner.Text(), "\r ")
+
+		// "{4:" opens the message text of an enveloped statement and may
+		// share its line with the header blocks.
+		if i := strings.Index(text, "{4:"); i >= 0 {
+			text = text[i+3:]
+		}
+
+		switch {
+		case text == "", text == "-", text == "-}", strings.HasPrefix(text, "{"):
+			continue
+		}
+
+		if m := fieldTag.FindStringSubmatch(text); m != nil {
+			fields = append(fields, field{line: line, tag: m[1], value: m[2]})
+			continue
+		}
+
+		if len(fields) > 0 {
+			fields[len(fields)-1].value += "\n" + text
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %v", apperror.ErrStatementMalformed, err)
+	}
+
+	return fields, nil
+}
+
+func parseBalance(value string) (*statement.Balance, error) {
+	m := balanceLine.FindStringSubmatch(value)
+	if m == nil {
+		return nil, fmt.Errorf("invalid balance %q", value)
+	}
+
+	date, err := parseDate(m[2])
+	if err != nil {
+		return nil, err
+	}
+
+	amount, err := statement.ParseAmount(m[4], ",", "")
+	if err != nil {
+		return nil, err
+	}
+	if m[1] == "D" {
+		amount = amount.Neg()
+	}
+
+	return &statement.Balance{Amount: amount, Date: date}, nil
+}
+
+func parseEntry(value string) (statement.Entry, error) {
+	var e statement.Entry
+
+	first, supplementary, _ := strings.Cut(value, "\n")
+	m := statementLine.FindStringSubmatch(first)
+	if m == nil {
+		return e, fmt.Errorf("invalid statement line %q", first)
+	}
+
+	valueDate, err := parseDate(m[1])
+	if err != nil {
+		return e, err
+	}
+	e.Date = valueDate
+
+	if m[2] != "" {
+		bookingDate, err := bookingDate(valueDate, m[2])
+		if err != nil {
+			return e, err
+		}
+		e.BookingDate = &bookingDate
+	}
+
+	amount, err := statement.ParseAmount(m[5], ",", "")
+	if err != nil {
+		return e, err
+	}
+	if amount.IsZero() {
+		return e, fmt.Errorf("amount is zero")
+	}
+
+	// RC reverses a credit and RD a debit.
+	if m[3] == "D" || m[3] == "RC" {
+		amount = amount.Neg()
+	}
+	e.Amount = amount
+
+	customer, bank, _ := strings.Cut(m[7], "//")
+	if bank = strings.TrimSpace(bank); bank != "" && bank != "NONREF" {
+		e.ExternalID = bank
+	}
+
+	// Without a :86: field the references are all there is to show.
+	e.Description = strings.TrimSpace(supplementary)
+	if e.Description == "" && strings.TrimSpace(customer) != "NONREF" {
+		e.Description = strings.TrimSpace(customer)
+	}
+
+	return e, nil
+}
+
+// describe reads the :86: information to account owner. It recognises the
+// German GVC layout ("166?00...?20...?32...") and the Dutch slash layout
+// ("/NAME/.../REMI/..."); anything else is taken as free text.
+func describe(e *statement.Entry, value string) {
+	text := strings.ReplaceAll(value, "\n", "")
+
+	var name, account, remittance string
+	switch {
+	case gvcHeader.MatchString(text):
+		name, account, remittance = gvc(text)
+	case slashKey.MatchString(text):
+		name, account, remittance = slashed(text)
+	default:
+		remittance = strings.Join(strings.Fields(strings.ReplaceAll(value, "\n", " ")), " ")
+	}
+
+	e.Counterparty = name
+	e.CounterpartyAccount = account
+	if name != "" || remittance != "" {
+		e.Description = statement.Describe(name, remittance)
+	}
+}
+
+func gvc(text string) (name, account, remittance string) {
+	separator := gvcHeader.FindStringSubmatch(text)[1]
+
+	subfields := make(map[int]string)
+	for _, part := range strings.Split(text[3:], separator) {
+		if len(part) < 2 {
+			continue
+		}
+		code, err := strconv.Atoi(part[:2])
+		if err != nil {
+			continue
+		}
+		subfields[code] += part[2:]
+	}
+
+	var purpose strings.Builder
+	for _, code := range []int{20, 21, 22, 23, 24, 25, 26, 27, 28, 29, 60, 61, 62, 63} {
+		purpose.WriteString(subfields[code])
+	}
+
+	remittance = purpose.String()
+	// SEPA payments prefix the purpose with tagged references; the text
+	// after SVWZ+ is what the payer wrote.
+	if _, after, ok := strings.Cut(remittance, "SVWZ+"); ok {
+		remittance = after
+		if loc := sepaTag.FindStringIndex(remittance); loc != nil {
+			remittance = remittance[:loc[0]]
+		}
+	}
+
+	name = strings.TrimSpace(subfields[32] + subfields[33])
+	account = strings.TrimSpace(subfields[31])
+	return name, account, strings.TrimSpace(remittance)
+}
+
+func slashed(text string) (name, account, remittance string) {
+	matches := slashKey.FindAllStringSubmatchIndex(text, -1)
+	for i, m := range matches {
+		end := len(text)
+		if i+1 < len(matches) {
+			end = matches[i+1][0]
+		}
+
+		value := strings.TrimSpace(text[m[1]:end])
+		switch text[m[2]:m[3]] {
+		case "NAME":
+			name = value
+		case "IBAN":
+			account = value
+		case "REMI":
+			remittance = strings.TrimPrefix(value, "USTD//")
+		}
+	}
+
+	return name, account, remittance
+}
+
+// parseDate reads a YYMMDD date. SWIFT has no century; years from 69 on
+// are taken as 19xx, like Go's two-digit year layout does.
+func parseDate(raw string) (time.Time, error) {
+	date, err := time.Parse("060102", raw)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date %q", raw)
+	}
+	return date, nil
+}
+
+// bookingDate completes an MMDD booking date with the year of the value
+// date, moving it across the new year when the two are months apart.
+func bookingDate(valueDate time.Time, raw string) (time.Time, error) {
+	month, err := strconv.Atoi(raw[:2])
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid booking date %q", raw)
+	}
+	day, err := strconv.Atoi(raw[2:])
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid booking date %q", raw)
+	}
+
+	year := valueDate.Year()
+	switch diff := month - int(valueDate.Month()); {
+	case diff > 6:
+		year--
+	case diff < -6:
+		year++
+	}
+
+	date := time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+	if int(date.Month()) != month || date.Day() != day {
+		return time.Time{}, fmt.Errorf("invalid booking date %q", raw)
+	}
+
+	return date, nil
+}
@@ -0,0 +1,127 @@
+package mt940
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nontypeable/financial-tracker/internal/statement"
+	"github.com/shopspring/decimal"
+)
+
+type wantEntry struct {
+	line                int
+	date                string
+	bookingDate         string
+	amount              string
+	description         string
+	counterparty        string
+	counterpartyAccount string
+	externalID          string
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		entries []wantEntry
+		errors  []statement.RowError
+		opening string
+		closing string
+	}{
+		{
+			name: "enveloped with german gvc details",
+			file: "gvc.sta",
+			entries: []wantEntry{
+				{6, "2024-01-02", "2024-01-02", "-50", "Stadtwerke Musterstadt - Stromabschlag Januar",
+					"Stadtwerke Musterstadt", "DE02120300000000202051", "BANKREF1"},
+				{8, "2024-01-05", "2024-01-05", "1200.5", "ACME GmbH - Gehalt Januar", "ACME GmbH", "", ""},
+				// The booking date falls in the year after the value date.
+				{12, "2024-12-31", "2025-01-02", "5", "", "", "", "INT1"},
+			},
+			errors: []statement.RowError{
+				{Line: 10, Message: "amount is zero"},
+			},
+			opening: "1000 2023-12-29",
+			closing: "2155.5 2024-01-31",
+		},
+		{
+			name: "dutch slash details",
+			file: "slash.sta",
+			entries: []wantEntry{
+				{5, "2024-01-31", "", "250", "J. Jansen - Terugbetaling lunch", "J. Jansen", "NL20INGB0001234567", ""},
+				{8, "2024-01-31", "", "-1.25", "Kosten betalingsverkeer", "", "", ""},
+			},
+			errors: []statement.RowError{
+				{Line: 10, Message: `invalid statement line "24013XD1,00NMSC"`},
+			},
+			opening: "-100 2024-01-30",
+			closing: "148.75 2024-01-31",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := os.Open(filepath.Join("testdata", tt.file))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer f.Close()
+
+			stmt, err := Parse(f, "")
+			if err != nil {
+				t.Fatalf("Parse: %v", err)
+			}
+
+			if len(stmt.Entries) != len(tt.entries) {
+				t.Fatalf("got %d entries, want %d: %+v", len(stmt.Entries), len(tt.entries), stmt.Entries)
+			}
+			for i, want := range tt.entries {
+				got := stmt.Entries[i]
+
+				bookingDate := ""
+				if got.BookingDate != nil {
+					bookingDate = got.BookingDate.Format(time.DateOnly)
+				}
+
+				if got.Line != want.line || got.Date.Format(time.DateOnly) != want.date || bookingDate != want.bookingDate ||
+					!got.Amount.Equal(decimal.RequireFromString(want.amount)) || got.Description != want.description ||
+					got.Counterparty != want.counterparty || got.CounterpartyAccount != want.counterpartyAccount ||
+					got.ExternalID != want.externalID {
+					t.Errorf("entry %d = %+v, want %+v", i, got, want)
+				}
+			}
+
+			if len(stmt.Errors) != len(tt.errors) {
+				t.Fatalf("got errors %+v, want %+v", stmt.Errors, tt.errors)
+			}
+			for i, want := range tt.errors {
+				if stmt.Errors[i] != want {
+					t.Errorf("error %d = %+v, want %+v", i, stmt.Errors[i], want)
+				}
+			}
+
+			if got := formatBalance(stmt.OpeningBalance); got != tt.opening {
+				t.Errorf("opening balance = %s, want %s", got, tt.opening)
+			}
+			if got := formatBalance(stmt.ClosingBalance); got != tt.closing {
+				t.Errorf("closing balance = %s, want %s", got, tt.closing)
+			}
+		})
+	}
+}
+
+func TestParseWithoutStatement(t *testing.T) {
+	if _, err := Parse(strings.NewReader("not a statement\n"), ""); err == nil {
+		t.Error("Parse of a file without a statement succeeded")
+	}
+}
+
+func formatBalance(b *statement.Balance) string {
+	if b == nil {
+		return ""
+	}
+	return b.Amount.String() + " " + b.Date.Format(time.DateOnly)
+}
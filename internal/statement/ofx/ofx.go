@@ -0,0 +1,287 @@
+// Package ofx parses OFX and QFX statement downloads, both the SGML flavour
+// of OFX 1.x and the XML flavour of OFX 2.x.
+package ofx
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+
+	apperror "github.com/nontypeable/financial-tracker/internal/errors"
+	"github.com/nontypeable/financial-tracker/internal/statement"
+	"github.com/shopspring/decimal"
+)
+
+// debitTypes are TRNTYPE values that always take money out. Some banks
+// report them with a positive TRNAMT, so the sign is forced for them.
+var debitTypes = map[string]bool{
+	"DEBIT":       true,
+	"PAYMENT":     true,
+	"CHECK":       true,
+	"FEE":         true,
+	"SRVCHG":      true,
+	"ATM":         true,
+	"POS":         true,
+	"DIRECTDEBIT": true,
+	"REPEATPMT":   true,
+	"CASH":        true,
+}
+
+var (
+	ofxStart    = regexp.MustCompile(`(?i)<OFX>`)
+	xmlEncoding = regexp.MustCompile(`(?i)encoding\s*=\s*["']([^"']+)["']`)
+	sgmlCharset = regexp.MustCompile(`(?im)^\s*CHARSET\s*:\s*(\S+)`)
+)
+
+// Parse reads a whole OFX file. Every STMTTRN becomes an entry whose line
+// is where the aggregate starts; LEDGERBAL becomes the closing balance.
+func Parse(r io.Reader) (*statement.Statement, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("read ofx: %w", err)
+	}
+
+	// The header may be in any encoding, so the start is matched on the
+	// raw bytes rather than on an upper-cased copy of a different length.
+	loc := ofxStart.FindIndex(data)
+	if loc == nil {
+		return nil, fmt.Errorf("%w: no OFX element found", apperror.ErrStatementMalformed)
+	}
+	start := loc[0]
+
+	decoded, err := statement.Decode(bytes.NewReader(data[start:]), encoding(data[:start]))
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := io.ReadAll(decoded)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", apperror.ErrStatementMalformed, err)
+	}
+
+	p := parser{line: bytes.Count(data[:start], []byte("\n")) + 1}
+	p.run(string(body))
+
+	if p.ledger != nil {
+		balance, err := toBalance(p.ledger)
+		if err != nil {
+			return nil, fmt.Errorf("%w: ledger balance: %v", apperror.ErrStatementMalformed, err)
+		}
+		p.stmt.ClosingBalance = balance
+	}
+
+	return &p.stmt, nil
+}
+
+// encoding picks the character set declared in the file header. SGML
+// headers give a Windows code page number, XML ones an encoding name.
+func encoding(header []byte) string {
+	if m := xmlEncoding.FindSubmatch(header); m != nil {
+		return string(m[1])
+	}
+
+	if m := sgmlCharset.FindSubmatch(header); m != nil {
+		charset := strings.ToUpper(string(m[1]))
+		switch {
+		case charset == "NONE" || charset == "USASCII":
+			return ""
+		case charset[0] >= '0' && charset[0] <= '9':
+			return "windows-" + charset
+		default:
+			return charset
+		}
+	}
+
+	return ""
+}
+
+type parser struct {
+	stmt statement.Statement
+	line int
+
+	// txn collects the leaf values of the open STMTTRN aggregate and
+	// txnLine records where it started; txn is nil outside one.
+	txn     map[string]string
+	txnLine int
+	// ledger collects the leaf values of the last LEDGERBAL aggregate.
+	ledger   map[string]string
+	inLedger bool
+}
+
+// run walks the tags in order. SGML leaves have no closing tag, so a value
+// is simply the text that follows an opening tag up to the next tag.
+func (p *parser) run(body string) {
+	pos := 0
+	for {
+		open := strings.IndexByte(body[pos:], '<')
+		if open < 0 {
+			break
+		}
+		open += pos
+		p.line += strings.Count(body[pos:open], "\n")
+
+		end := strings.IndexByte(body[open:], '>')
+		if end < 0 {
+			break
+		}
+		end += open
+
+		tag := strings.ToUpper(strings.TrimSpace(body[open+1 : end]))
+
+		next := strings.IndexByte(body[end:], '<')
+		if next < 0 {
+			next = len(body)
+		} else {
+			next += end
+		}
+		text := strings.TrimSpace(html.UnescapeString(body[end+1 : next]))
+
+		p.line += strings.Count(body[open:end], "\n")
+		pos = end + 1
+
+		switch {
+		case tag == "" || tag[0] == '?' || tag[0] == '!':
+		case tag[0] == '/':
+			p.close(tag[1:])
+		default:
+			p.open(strings.TrimSuffix(tag, "/"), text)
+		}
+	}
+
+	if p.txn != nil {
+		p.flush()
+	}
+}
+
+func (p *parser) open(tag, text string) {
+	switch tag {
+	case "STMTTRN":
+		if p.txn != nil {
+			p.flush()
+		}
+		p.txn = make(map[string]string)
+		p.txnLine = p.line
+		return
+	case "LEDGERBAL":
+		p.ledger = make(map[string]string)
+		p.inLedger = true
+		return
+	}
+
+	if text == "" {
+		return
+	}
+
+	switch {
+	case p.txn != nil:
+		if _, ok := p.txn[tag]; !ok {
+			p.txn[tag] = text
+		}
+	case p.inLedger:
+		p.ledger[tag] = text
+	}
+}
+
+func (p *parser) close(tag string) {
+	switch tag {
+	case "STMTTRN":
+		if p.txn != nil {
+			p.flush()
+		}
+	case "LEDGERBAL":
+		p.inLedger = false
+	}
+}
+
+func (p *parser) flush() {
+	txn, line := p.txn, p.txnLine
+	p.txn = nil
+
+	entry, err := toEntry(txn)
+	if err != nil {
+		p.stmt.AddError(line, "%v", err)
+		return
+	}
+
+	entry.Line = line
+	p.stmt.Entries = append(p.stmt.Entries, entry)
+}
+
+func toBalance(values map[string]string) (*statement.Balance, error) {
+	amount, err := parseAmount(values["BALAMT"])
+	if err != nil {
+		return nil, err
+	}
+
+	date, err := parseDate(values["DTASOF"])
+	if err != nil {
+		return nil, err
+	}
+
+	return &statement.Balance{Amount: amount, Date: date}, nil
+}
+
+func toEntry(txn map[string]string) (statement.Entry, error) {
+	var entry statement.Entry
+
+	raw := txn["DTPOSTED"]
+	if raw == "" {
+		raw = txn["DTUSER"]
+	}
+	date, err := parseDate(raw)
+	if err != nil {
+		return entry, err
+	}
+
+	amount, err := parseAmount(txn["TRNAMT"])
+	if err != nil {
+		return entry, err
+	}
+	if amount.IsZero() {
+		return entry, fmt.Errorf("amount is zero")
+	}
+	if amount.IsPositive() && debitTypes[strings.ToUpper(txn["TRNTYPE"])] {
+		amount = amount.Neg()
+	}
+
+	entry.Date = date
+	entry.Amount = amount
+	entry.Description = statement.Describe(txn["NAME"], txn["MEMO"])
+	entry.ExternalID = txn["FITID"]
+
+	return entry, nil
+}
+
+func parseAmount(raw string) (decimal.Decimal, error) {
+	if raw == "" {
+		return decimal.Zero, fmt.Errorf("amount is missing")
+	}
+
+	// The spec allows a comma as the decimal separator.
+	separator := "."
+	if strings.Contains(raw, ",") && !strings.Contains(raw, ".") {
+		separator = ","
+	}
+
+	return statement.ParseAmount(raw, separator, "")
+}
+
+// parseDate reads the date part of an OFX datetime such as
+// "20240131120000.000[-5:EST]". The time and zone are ignored because the
+// value date is what matters for bookkeeping.
+func parseDate(raw string) (time.Time, error) {
+	if len(raw) < 8 {
+		return time.Time{}, fmt.Errorf("invalid date %q", raw)
+	}
+
+	date, err := time.Parse("20060102", raw[:8])
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date %q", raw)
+	}
+
+	return date, nil
+}
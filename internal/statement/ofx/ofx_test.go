@@ -0,0 +1,143 @@
+package ofx
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nontypeable/financial-tracker/internal/statement"
+	"github.com/shopspring/decimal"
+)
+
+type wantEntry struct {
+	line        int
+	date        string
+	amount      string
+	description string
+	externalID  string
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		entries []wantEntry
+		errors  []statement.RowError
+		closing string
+	}{
+		{
+			name: "sgml v1",
+			file: "v1.ofx",
+			entries: []wantEntry{
+				{35, "2024-01-05", "1500", "ACME PAYROLL - Salary January", "2024010501"},
+				// POS is a debit type, so its positive amount is negated;
+				// the file is windows-1252 and uses a decimal comma.
+				{43, "2024-01-10", "-4.5", "Café Central", "2024011001"},
+				{50, "2024-01-15", "-42.1", "GROCER", "2024011501"},
+			},
+			errors: []statement.RowError{
+				{Line: 58, Message: "amount is zero"},
+				{Line: 65, Message: `invalid date "2024"`},
+			},
+			closing: "2453.4 2024-01-31",
+		},
+		{
+			name: "xml v2",
+			file: "v2.ofx",
+			entries: []wantEntry{
+				{20, "2024-02-03", "-19.99", "Books & Co - Online order", "CC-0001"},
+				{28, "2024-02-10", "-2.5", "Foreign transaction fee", "CC-0002"},
+				{35, "2024-02-29", "100", "Payment - Thank you", "CC-0003"},
+			},
+			errors: []statement.RowError{
+				{Line: 43, Message: "amount is missing"},
+			},
+			closing: "-322.49 2024-02-29",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := os.Open(filepath.Join("testdata", tt.file))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer f.Close()
+
+			stmt, err := Parse(f)
+			if err != nil {
+				t.Fatalf("Parse: %v", err)
+			}
+
+			if len(stmt.Entries) != len(tt.entries) {
+				t.Fatalf("got %d entries, want %d: %+v", len(stmt.Entries), len(tt.entries), stmt.Entries)
+			}
+			for i, want := range tt.entries {
+				got := stmt.Entries[i]
+				if got.Line != want.line || got.Date.Format(time.DateOnly) != want.date ||
+					!got.Amount.Equal(decimal.RequireFromString(want.amount)) ||
+					got.Description != want.description || got.ExternalID != want.externalID {
+					t.Errorf("entry %d = %d %s %s %q %q, want %+v", i,
+						got.Line, got.Date.Format(time.DateOnly), got.Amount, got.Description, got.ExternalID, want)
+				}
+			}
+
+			if len(stmt.Errors) != len(tt.errors) {
+				t.Fatalf("got errors %+v, want %+v", stmt.Errors, tt.errors)
+			}
+			for i, want := range tt.errors {
+				if stmt.Errors[i] != want {
+					t.Errorf("error %d = %+v, want %+v", i, stmt.Errors[i], want)
+				}
+			}
+
+			if stmt.ClosingBalance == nil {
+				t.Fatal("no closing balance")
+			}
+			closing := stmt.ClosingBalance.Amount.String() + " " + stmt.ClosingBalance.Date.Format(time.DateOnly)
+			if closing != tt.closing {
+				t.Errorf("closing balance = %s, want %s", closing, tt.closing)
+			}
+		})
+	}
+}
+
+// TestParseHeaderBytes feeds headers whose upper-cased form differs in
+// length from the raw bytes; finding the OFX element must not depend on it.
+func TestParseHeaderBytes(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{"invalid utf-8", "000000\x9e\x9e\x9e\x9e\x9e\x9e<OFX>"},
+		{"lower case tag", "OFXHEADER:100\n\n<ofx>"},
+		{"runes that change length", "\u0250\u0250\u0250<OFX>"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stmt, err := Parse(strings.NewReader(tt.input))
+			if err != nil {
+				t.Fatalf("Parse: %v", err)
+			}
+			if len(stmt.Entries) != 0 || len(stmt.Errors) != 0 {
+				t.Errorf("got %+v, want an empty statement", stmt)
+			}
+		})
+	}
+}
+
+func TestParseWithoutOFX(t *testing.T) {
+	f, err := os.Open(filepath.Join("testdata", "v1.ofx"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	if _, err := Parse(io.LimitReader(f, 100)); err == nil {
+		t.Error("Parse of a file without an OFX element succeeded")
+	}
+}
@@ -0,0 +1,479 @@
+// Package qif parses Quicken Interchange Format exports from desktop
+// finance applications. Only bank, cash, credit card and other asset or
+// liability registers are read.
+package qif
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	apperror "github.com/nontypeable/financial-tracker/internal/errors"
+	"github.com/nontypeable/financial-tracker/internal/statement"
+	"github.com/shopspring/decimal"
+)
+
+// DateOrder says how to read dates such as 03/04/2024. QIF files carry no
+// hint, so when it is empty the order is inferred from the file itself.
+type DateOrder string
+
+const (
+	DateOrderAuto DateOrder = ""
+	DateOrderMDY  DateOrder = "mdy"
+	DateOrderDMY  DateOrder = "dmy"
+)
+
+type Options struct {
+	DateOrder DateOrder
+	Encoding  string
+}
+
+type File struct {
+	Accounts []*Account
+	Errors   []statement.RowError
+}
+
+func (f *File) addError(line int, format string, args ...any) {
+	f.Errors = append(f.Errors, statement.RowError{Line: line, Message: fmt.Sprintf(format, args...)})
+}
+
+// Account is one register of the file. Name is empty for transactions that
+// are not preceded by an !Account block, as in single-account exports.
+type Account struct {
+	Name         string
+	Type         string
+	Description  string
+	Transactions []Transaction
+}
+
+// Transaction is a register entry with a signed amount. Category is a
+// colon-separated path; Transfer names the other account instead when the
+// entry moves money between registers.
+type Transaction struct {
+	Line     int
+	Date     time.Time
+	Amount   decimal.Decimal
+	Payee    string
+	Memo     string
+	Cleared  bool
+	Category string
+	Transfer string
+	Splits   []Split
+}
+
+type Split struct {
+	Category string
+	Transfer string
+	Memo     string
+	Amount   decimal.Decimal
+}
+
+// registers maps the supported !Type headers to their account types.
+var registers = map[string]string{
+	"bank":  "Bank",
+	"cash":  "Cash",
+	"ccard": "CCard",
+	"oth a": "Oth A",
+	"oth l": "Oth L",
+}
+
+// lists are sections that describe things the import derives on its own,
+// such as categories, and are skipped without complaint.
+var lists = map[string]bool{
+	"cat":   true,
+	"class": true,
+}
+
+type field struct {
+	code  byte
+	value string
+}
+
+type record struct {
+	line    int
+	account *Account
+	fields  []field
+}
+
+// Parse reads the whole file. Records that cannot be read are reported as
+// row errors; a file without any register fails.
+func Parse(r io.Reader, opts Options) (*File, error) {
+	decoded, err := statement.Decode(r, opts.Encoding)
+	if err != nil {
+		return nil, err
+	}
+
+	p := parser{file: &File{}, accounts: make(map[string]*Account)}
+	if err := p.scan(decoded); err != nil {
+		return nil, err
+	}
+
+	if len(p.records) == 0 && len(p.file.Accounts) == 0 {
+		return nil, fmt.Errorf("%w: no bank, cash or credit card register found", apperror.ErrStatementMalformed)
+	}
+
+	order := opts.DateOrder
+	if order == DateOrderAuto {
+		order = p.inferDateOrder()
+	}
+
+	for _, rec := range p.records {
+		txn, err := toTransaction(rec, order)
+		if err != nil {
+			p.file.addError(rec.line, "%v", err)
+			continue
+		}
+		rec.account.Transactions = append(rec.account.Transactions, txn)
+	}
+
+	sort.SliceStable(p.file.Errors, func(i, j int) bool {
+		return p.file.Errors[i].Line < p.file.Errors[j].Line
+	})
+
+	return p.file, nil
+}
+
+type parser struct {
+	file     *File
+	accounts map[string]*Account
+	records  []record
+
+	// current receives transactions; it is set by !Account blocks and
+	// created on demand for files without them.
+	current *Account
+}
+
+func (p *parser) scan(r io.Reader) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	var (
+		section string
+		fields  []field
+		start   int
+		line    int
+	)
+
+	for scanner.Scan() {
+		line++
+		text := strings.TrimRight(scanner.Text(), "\r")
+		if strings.TrimSpace(text) == "" {
+			continue
+		}
+
+		if text[0] == '!' {
+			section = p.header(line, strings.ToLower(strings.TrimSpace(text[1:])))
+			fields = nil
+			continue
+		}
+
+		if text[0] == '^' {
+			p.end(section, start, fields)
+			fields = nil
+			continue
+		}
+
+		if len(fields) == 0 {
+			start = line
+		}
+		fields = append(fields, field{code: text[0], value: strings.TrimSpace(text[1:])})
+	}
+
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("%w: %v", apperror.ErrStatementMalformed, err)
+	}
+
+	// Some exporters omit the terminator after the last record.
+	if len(fields) > 0 {
+		p.end(section, start, fields)
+	}
+
+	return nil
+}
+
+// header returns the section that the following records belong to:
+// "account", a register type, or "" for sections that are skipped.
+func (p *parser) header(line int, name string) string {
+	switch {
+	case name == "account":
+		return "account"
+	case strings.HasPrefix(name, "option:"), strings.HasPrefix(name, "clear:"):
+		return ""
+	case strings.HasPrefix(name, "type:"):
+		kind := strings.TrimSpace(strings.TrimPrefix(name, "type:"))
+		if register, ok := registers[kind]; ok {
+			if p.current == nil {
+				p.current = p.account("", register)
+			}
+			if p.current.Type == "" {
+				p.current.Type = register
+			}
+			return register
+		}
+		if !lists[kind] {
+			p.file.addError(line, "unsupported section %q skipped", kind)
+		}
+		return ""
+	default:
+		p.file.addError(line, "unsupported section %q skipped", name)
+		return ""
+	}
+}
+
+func (p *parser) end(section string, line int, fields []field) {
+	switch section {
+	case "":
+	case "account":
+		var name, kind, description string
+		for _, f := range fields {
+			switch f.code {
+			case 'N':
+				name = f.value
+			case 'T':
+				kind = f.value
+			case 'D':
+				description = f.value
+			}
+		}
+		if name == "" {
+			p.file.addError(line, "account without a name")
+			return
+		}
+		p.current = p.account(name, kind)
+		if description != "" {
+			p.current.Description = description
+		}
+	default:
+		p.records = append(p.records, record{line: line, account: p.current, fields: fields})
+	}
+}
+
+// account returns the account with the name, creating it on first use, so
+// registers split across several sections end up together.
+func (p *parser) account(name, kind string) *Account {
+	key := strings.ToLower(name)
+	if a, ok := p.accounts[key]; ok {
+		return a
+	}
+
+	a := &Account{Name: name, Type: kind}
+	p.accounts[key] = a
+	p.file.Accounts = append(p.file.Accounts, a)
+	return a
+}
+
+// inferDateOrder looks for a date whose first or second part cannot be a
+// month. Files without such a date are assumed to be month first, which is
+// what Quicken writes.
+func (p *parser) inferDateOrder() DateOrder {
+	for _, rec := range p.records {
+		for _, f := range rec.fields {
+			if f.code != 'D' {
+				continue
+			}
+
+			parts, _, ok := dateParts(f.value)
+			if !ok || len(parts[0]) == 4 {
+				continue
+			}
+
+			first, _ := strconv.Atoi(parts[0])
+			second, _ := strconv.Atoi(parts[1])
+			switch {
+			case first > 12 && second <= 12:
+				return DateOrderDMY
+			case second > 12 && first <= 12:
+				return DateOrderMDY
+			}
+		}
+	}
+
+	return DateOrderMDY
+}
+
+func toTransaction(rec record, order DateOrder) (Transaction, error) {
+	txn := Transaction{Line: rec.line}
+
+	var (
+		date, amount string
+		split        *Split
+		splitMemo    bool
+		splitAmount  bool
+	)
+
+	newSplit := func() {
+		txn.Splits = append(txn.Splits, Split{})
+		split = &txn.Splits[len(txn.Splits)-1]
+		splitMemo, splitAmount = false, false
+	}
+
+	for _, f := range rec.fields {
+		switch f.code {
+		case 'D':
+			date = f.value
+		case 'T':
+			amount = f.value
+		case 'U':
+			if amount == "" {
+				amount = f.value
+			}
+		case 'P':
+			txn.Payee = f.value
+		case 'M':
+			txn.Memo = f.value
+		case 'C':
+			txn.Cleared = f.value != ""
+		case 'L':
+			txn.Category, txn.Transfer = category(f.value)
+		case 'S':
+			newSplit()
+			split.Category, split.Transfer = category(f.value)
+		case 'E':
+			if split == nil || splitMemo {
+				newSplit()
+			}
+			split.Memo = f.value
+			splitMemo = true
+		case '$':
+			if split == nil || splitAmount {
+				newSplit()
+			}
+			value, err := parseAmount(f.value)
+			if err != nil {
+				return txn, fmt.Errorf("split amount: %v", err)
+			}
+			split.Amount = value
+			splitAmount = true
+		}
+	}
+
+	if date == "" {
+		return txn, fmt.Errorf("date is missing")
+	}
+	parsed, err := parseDate(date, order)
+	if err != nil {
+		return txn, err
+	}
+	txn.Date = parsed
+
+	if amount == "" {
+		return txn, fmt.Errorf("amount is missing")
+	}
+	txn.Amount, err = parseAmount(amount)
+	if err != nil {
+		return txn, err
+	}
+	if txn.Amount.IsZero() {
+		return txn, fmt.Errorf("amount is zero")
+	}
+
+	if len(txn.Splits) > 0 {
+		sum := decimal.Zero
+		for _, s := range txn.Splits {
+			sum = sum.Add(s.Amount)
+		}
+		if !sum.Equal(txn.Amount) {
+			return txn, fmt.Errorf("splits add up to %s instead of %s", sum, txn.Amount)
+		}
+	}
+
+	return txn, nil
+}
+
+// category reads an L or S value. "[Savings]" is a transfer to the Savings
+// account; otherwise the value is a category path, and a class after a
+// slash is dropped.
+func category(value string) (path, transfer string) {
+	if i := strings.IndexByte(value, '/'); i >= 0 {
+		value = value[:i]
+	}
+	value = strings.TrimSpace(value)
+
+	if strings.HasPrefix(value, "[") && strings.HasSuffix(value, "]") {
+		return "", strings.TrimSpace(value[1 : len(value)-1])
+	}
+	if value == "--Split--" {
+		return "", ""
+	}
+	return value, ""
+}
+
+// parseAmount accepts both 1,234.56 and 1.234,56. When only commas occur,
+// a comma followed by one or two digits at the end is the decimal one.
+func parseAmount(raw string) (decimal.Decimal, error) {
+	s := strings.TrimSpace(raw)
+
+	dot, comma := strings.LastIndexByte(s, '.'), strings.LastIndexByte(s, ',')
+	switch {
+	case dot >= 0 && comma >= 0 && comma > dot:
+		return statement.ParseAmount(s, ",", ".")
+	case dot < 0 && comma >= 0 && len(s)-comma-1 <= 2:
+		return statement.ParseAmount(s, ",", "")
+	default:
+		return statement.ParseAmount(s, ".", ",")
+	}
+}
+
+// dateParts splits dates such as "1/31/2024", " 1/31' 4", "31.01.24" or
+// "2024-01-31" into their numbers. The apostrophe Quicken puts before
+// years from 2000 on is reported separately.
+func dateParts(raw string) (parts []string, apostrophe bool, ok bool) {
+	s := strings.ReplaceAll(raw, " ", "")
+	apostrophe = strings.Contains(s, "'")
+
+	parts = strings.FieldsFunc(s, func(r rune) bool {
+		return r == '/' || r == '-' || r == '.' || r == '\''
+	})
+	if len(parts) != 3 {
+		return nil, false, false
+	}
+
+	for _, part := range parts {
+		if _, err := strconv.Atoi(part); err != nil {
+			return nil, false, false
+		}
+	}
+
+	return parts, apostrophe, true
+}
+
+func parseDate(raw string, order DateOrder) (time.Time, error) {
+	parts, apostrophe, ok := dateParts(raw)
+	if !ok {
+		return time.Time{}, fmt.Errorf("invalid date %q", raw)
+	}
+
+	var year, month, day string
+	switch {
+	case len(parts[0]) == 4:
+		year, month, day = parts[0], parts[1], parts[2]
+	case order == DateOrderDMY:
+		day, month, year = parts[0], parts[1], parts[2]
+	default:
+		month, day, year = parts[0], parts[1], parts[2]
+	}
+
+	y, _ := strconv.Atoi(year)
+	m, _ := strconv.Atoi(month)
+	d, _ := strconv.Atoi(day)
+
+	if len(year) <= 2 {
+		switch {
+		case apostrophe || y < 70:
+			y += 2000
+		default:
+			y += 1900
+		}
+	}
+
+	date := time.Date(y, time.Month(m), d, 0, 0, 0, 0, time.UTC)
+	if date.Year() != y || int(date.Month()) != m || date.Day() != d {
+		return time.Time{}, fmt.Errorf("invalid date %q", raw)
+	}
+
+	return date, nil
+}
@@ -0,0 +1,152 @@
+package qif
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nontypeable/financial-tracker/internal/statement"
+)
+
+// txn is a Transaction with its amounts and splits flattened to strings so
+// that it compares with ==.
+type txn struct {
+	line     int
+	date     string
+	amount   string
+	payee    string
+	memo     string
+	cleared  bool
+	category string
+	transfer string
+	splits   string
+}
+
+type wantAccount struct {
+	name         string
+	kind         string
+	description  string
+	transactions []txn
+}
+
+func flatten(t Transaction) txn {
+	splits := make([]string, 0, len(t.Splits))
+	for _, s := range t.Splits {
+		splits = append(splits, strings.Join([]string{s.Category, s.Transfer, s.Memo, s.Amount.String()}, "|"))
+	}
+
+	return txn{
+		line:     t.Line,
+		date:     t.Date.Format(time.DateOnly),
+		amount:   t.Amount.String(),
+		payee:    t.Payee,
+		memo:     t.Memo,
+		cleared:  t.Cleared,
+		category: t.Category,
+		transfer: t.Transfer,
+		splits:   strings.Join(splits, "; "),
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name     string
+		file     string
+		opts     Options
+		accounts []wantAccount
+		errors   []statement.RowError
+	}{
+		{
+			name: "accounts with splits and transfers",
+			file: "accounts.qif",
+			accounts: []wantAccount{
+				{"Checking", "Bank", "Main account", []txn{
+					{16, "2004-01-31", "-1234.56", "Landlord", "January rent", true, "Housing:Rent", "", ""},
+					{23, "2004-02-01", "500", "", "", false, "", "Savings", ""},
+					{28, "2004-02-03", "-100", "Supermarket", "", false, "", "",
+						"Food:Groceries||Weekly shop|-80; Household|||-20"},
+				}},
+				// The class after the slash is dropped from the category.
+				{"Visa", "CCard", "", []txn{
+					{54, "2004-02-10", "-15", "Bookshop", "", false, "Leisure", "", ""},
+				}},
+			},
+			errors: []statement.RowError{
+				{Line: 38, Message: "amount is zero"},
+				{Line: 42, Message: "splits add up to -40 instead of -50"},
+				{Line: 59, Message: `unsupported section "invst" skipped`},
+			},
+		},
+		{
+			name: "inferred day-first dates without an account block",
+			file: "dmy.qif",
+			accounts: []wantAccount{
+				{"", "Bank", "", []txn{
+					{2, "2024-01-31", "-12.5", "Bakery", "", false, "", "", ""},
+					{6, "2024-02-01", "1000", "Employer", "", false, "", "", ""},
+				}},
+			},
+		},
+		{
+			name: "explicit month-first order",
+			file: "dmy.qif",
+			opts: Options{DateOrder: DateOrderMDY},
+			accounts: []wantAccount{
+				{"", "Bank", "", []txn{
+					{6, "2024-01-02", "1000", "Employer", "", false, "", "", ""},
+				}},
+			},
+			errors: []statement.RowError{
+				{Line: 2, Message: `invalid date "31.01.24"`},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := os.Open(filepath.Join("testdata", tt.file))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer f.Close()
+
+			file, err := Parse(f, tt.opts)
+			if err != nil {
+				t.Fatalf("Parse: %v", err)
+			}
+
+			if len(file.Accounts) != len(tt.accounts) {
+				t.Fatalf("got %d accounts, want %d", len(file.Accounts), len(tt.accounts))
+			}
+			for i, want := range tt.accounts {
+				got := file.Accounts[i]
+				if got.Name != want.name || got.Type != want.kind || got.Description != want.description {
+					t.Errorf("account %d = %q %q %q, want %q %q %q", i,
+						got.Name, got.Type, got.Description, want.name, want.kind, want.description)
+				}
+
+				transactions := make([]txn, 0, len(got.Transactions))
+				for _, t := range got.Transactions {
+					transactions = append(transactions, flatten(t))
+				}
+				if !slices.Equal(transactions, want.transactions) {
+					t.Errorf("account %q transactions =\n%+v\nwant\n%+v", want.name, transactions, want.transactions)
+				}
+			}
+
+			if !slices.Equal(file.Errors, tt.errors) {
+				t.Errorf("errors = %+v, want %+v", file.Errors, tt.errors)
+			}
+		})
+	}
+}
+
+func TestParseWithoutRegister(t *testing.T) {
+	_, err := Parse(strings.NewReader("!Type:Cat\nNFood\n^\n"), Options{})
+	if err == nil {
+		t.Error("Parse of a file without a register succeeded")
+	}
+}
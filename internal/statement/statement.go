@@ -0,0 +1,169 @@
+// Package statement holds the format-independent result of parsing a bank
+// statement file. Format parsers live in subpackages.
+package statement
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	apperror "github.com/nontypeable/financial-tracker/internal/errors"
+	"github.com/shopspring/decimal"
+	"golang.org/x/text/encoding/htmlindex"
+)
+
+// Entry is a single booked line. Amount is signed: negative values are
+// money leaving the account. Date is the value date; BookingDate is set by
+// formats that report both.
+type Entry struct {
+	Line        int
+	Date        time.Time
+	BookingDate *time.Time
+	Amount      decimal.Decimal
+	Description string
+	// Counterparty and CounterpartyAccount name the other side of the
+	// payment, when the format reports it. The account is usually an IBAN.
+	Counterparty        string
+	CounterpartyAccount string
+	// ExternalID is the bank's own identifier for the entry, when the
+	// format carries one. It keeps re-imports of the same file idempotent.
+	ExternalID string
+}
+
+// RowError describes a line that could not be turned into an entry.
+type RowError struct {
+	Line    int
+	Message string
+}
+
+// Balance is a balance reported by the bank as of the given date.
+type Balance struct {
+	Amount decimal.Decimal
+	Date   time.Time
+}
+
+type Statement struct {
+	Entries []Entry
+	Errors  []RowError
+	// OpeningBalance and ClosingBalance are the booked balances at the start
+	// and end of the statement, when the format reports them.
+	OpeningBalance *Balance
+	ClosingBalance *Balance
+}
+
+func (s *Statement) AddError(line int, format string, args ...any) {
+	s.Errors = append(s.Errors, RowError{Line: line, Message: fmt.Sprintf(format, args...)})
+}
+
+// Decode wraps r so it yields UTF-8 from the named encoding (any WHATWG
+// label such as "windows-1251" or "iso-8859-1"). An empty name or UTF-8
+// returns r with a leading byte order mark removed.
+func Decode(r io.Reader, encoding string) (io.Reader, error) {
+	name := strings.ToLower(strings.TrimSpace(encoding))
+	if name == "" || name == "utf-8" || name == "utf8" {
+		return skipBOM(r), nil
+	}
+
+	enc, err := htmlindex.Get(name)
+	if err != nil {
+		return nil, fmt.Errorf("%w: unsupported encoding %q", apperror.ErrStatementMalformed, encoding)
+	}
+
+	return enc.NewDecoder().Reader(r), nil
+}
+
+func skipBOM(r io.Reader) io.Reader {
+	return &bomReader{r: r}
+}
+
+type bomReader struct {
+	r       io.Reader
+	checked bool
+	pending []byte
+}
+
+func (b *bomReader) Read(p []byte) (int, error) {
+	if !b.checked {
+		b.checked = true
+
+		head := make([]byte, 3)
+		n, err := io.ReadFull(b.r, head)
+		head = head[:n]
+		if n == 3 && head[0] == 0xEF && head[1] == 0xBB && head[2] == 0xBF {
+			head = nil
+		}
+		b.pending = head
+
+		if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+			return 0, err
+		}
+	}
+
+	if len(b.pending) > 0 {
+		n := copy(p, b.pending)
+		b.pending = b.pending[n:]
+		return n, nil
+	}
+
+	return b.r.Read(p)
+}
+
+// Describe joins a payee name and a memo into one description. Banks often
+// fill both with the same or overlapping text, which is kept once.
+func Describe(name, memo string) string {
+	switch {
+	case memo == "" || strings.Contains(name, memo):
+		return name
+	case name == "" || strings.Contains(memo, name):
+		return memo
+	default:
+		return name + " - " + memo
+	}
+}
+
+// ParseAmount reads a human-formatted number. It drops the thousands
+// separator and spaces, accepts the given decimal separator, and treats
+// parentheses or a trailing minus as a negative sign.
+func ParseAmount(raw, decimalSeparator, thousandsSeparator string) (decimal.Decimal, error) {
+	s := strings.TrimSpace(raw)
+	if s == "" {
+		return decimal.Zero, fmt.Errorf("empty amount")
+	}
+
+	negative := false
+	if strings.HasPrefix(s, "(") && strings.HasSuffix(s, ")") {
+		negative = true
+		s = s[1 : len(s)-1]
+	}
+	if strings.HasSuffix(s, "-") {
+		negative = !negative
+		s = strings.TrimSuffix(s, "-")
+	}
+
+	s = strings.Map(func(r rune) rune {
+		switch r {
+		case ' ', '\u00a0', '\u202f', '\'':
+			return -1
+		}
+		return r
+	}, s)
+
+	if thousandsSeparator != "" {
+		s = strings.ReplaceAll(s, thousandsSeparator, "")
+	}
+	if decimalSeparator != "" && decimalSeparator != "." {
+		s = strings.ReplaceAll(s, decimalSeparator, ".")
+	}
+
+	amount, err := decimal.NewFromString(s)
+	if err != nil {
+		return decimal.Zero, fmt.Errorf("invalid amount %q", raw)
+	}
+
+	if negative {
+		amount = amount.Neg()
+	}
+
+	return amount, nil
+}
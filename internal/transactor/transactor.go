@@ -0,0 +1,72 @@
+package transactor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	contextKeys "github.com/nontypeable/financial-tracker/internal/context"
+)
+
+// Querier is the subset of pgx methods shared by *pgxpool.Pool and pgx.Tx,
+// so repositories can run the same queries inside or outside a transaction.
+type Querier interface {
+	Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+	CopyFrom(ctx context.Context, tableName pgx.Identifier, columnNames []string, rowSrc pgx.CopyFromSource) (int64, error)
+}
+
+type Transactor interface {
+	// WithinTransaction runs fn inside a database transaction carried by ctx.
+	// Nested calls join the outer transaction instead of opening a new one.
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type transactor struct {
+	pool *pgxpool.Pool
+}
+
+func NewTransactor(pool *pgxpool.Pool) Transactor {
+	return &transactor{pool: pool}
+}
+
+func (t *transactor) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(contextKeys.TxKey).(pgx.Tx); ok {
+		return fn(ctx)
+	}
+
+	tx, err := t.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+
+	defer func() {
+		if err := tx.Rollback(ctx); err != nil && !errors.Is(err, pgx.ErrTxClosed) {
+			log.Printf("rollback transaction: %v", err)
+		}
+	}()
+
+	if err := fn(context.WithValue(ctx, contextKeys.TxKey, tx)); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// Conn returns the transaction stored in ctx, falling back to the pool.
+func Conn(ctx context.Context, pool *pgxpool.Pool) Querier {
+	if tx, ok := ctx.Value(contextKeys.TxKey).(pgx.Tx); ok {
+		return tx
+	}
+
+	return pool
+}
@@ -3,22 +3,36 @@ package account
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/nontypeable/financial-tracker/internal/domain/account"
+	"github.com/nontypeable/financial-tracker/internal/domain/transaction"
+	apperror "github.com/nontypeable/financial-tracker/internal/errors"
+	"github.com/nontypeable/financial-tracker/internal/transactor"
 	"github.com/shopspring/decimal"
 )
 
 type service struct {
-	repository account.Repository
+	repository            account.Repository
+	transactionRepository transaction.Repository
+	transactor            transactor.Transactor
 }
 
-func NewService(repository account.Repository) account.Service {
-	return &service{repository: repository}
+func NewService(repository account.Repository, transactionRepository transaction.Repository, transactor transactor.Transactor) account.Service {
+	return &service{
+		repository:            repository,
+		transactionRepository: transactionRepository,
+		transactor:            transactor,
+	}
 }
 
-func (s *service) Create(ctx context.Context, userID uuid.UUID, name string, balance decimal.Decimal) (uuid.UUID, error) {
-	account := account.NewAccount(userID, name, balance)
+func (s *service) Create(ctx context.Context, userID uuid.UUID, name string, kind account.Kind, balance decimal.Decimal) (uuid.UUID, error) {
+	if kind == "" {
+		kind = account.Asset
+	}
+
+	account := account.NewAccount(userID, name, kind, balance)
 
 	accountID, err := s.repository.Create(ctx, account)
 	if err != nil {
@@ -27,3 +41,169 @@ func (s *service) Create(ctx context.Context, userID uuid.UUID, name string, bal
 
 	return accountID, nil
 }
+
+func (s *service) GetByID(ctx context.Context, userID, id uuid.UUID) (*account.Account, error) {
+	account, err := s.repository.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("get account: %w", err)
+	}
+
+	if !account.BelongsUser(userID) {
+		return nil, apperror.ErrForbidden
+	}
+
+	if err := s.fillClearedBalances(ctx, account); err != nil {
+		return nil, err
+	}
+
+	return account, nil
+}
+
+func (s *service) List(ctx context.Context, userID uuid.UUID) ([]*account.Account, error) {
+	accounts, err := s.repository.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("list accounts: %w", err)
+	}
+
+	if err := s.fillClearedBalances(ctx, accounts...); err != nil {
+		return nil, err
+	}
+
+	return accounts, nil
+}
+
+func (s *service) Update(ctx context.Context, userID, id uuid.UUID, name string, kind account.Kind) error {
+	return s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		account, err := s.lockOwned(ctx, userID, id)
+		if err != nil {
+			return err
+		}
+
+		changed := false
+		if name != "" && name != account.Name {
+			account.Name = name
+			changed = true
+		}
+		if kind != "" && kind != account.Kind {
+			account.Kind = kind
+			changed = true
+		}
+
+		if !changed {
+			return nil
+		}
+
+		if err := s.repository.Update(ctx, account); err != nil {
+			return fmt.Errorf("update account: %w", err)
+		}
+
+		return nil
+	})
+}
+
+func (s *service) Delete(ctx context.Context, userID, id uuid.UUID) error {
+	return s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if _, err := s.lockOwned(ctx, userID, id); err != nil {
+			return err
+		}
+
+		// The counterpart legs live on other accounts and could no longer be
+		// edited or deleted once this one is gone.
+		hasTransfers, err := s.transactionRepository.HasTransfers(ctx, id)
+		if err != nil {
+			return fmt.Errorf("check transfers: %w", err)
+		}
+		if hasTransfers {
+			return apperror.ErrAccountHasTransfers
+		}
+
+		if err := s.repository.Delete(ctx, userID, id); err != nil {
+			return fmt.Errorf("delete account: %w", err)
+		}
+
+		return nil
+	})
+}
+
+func (s *service) Recalculate(ctx context.Context, userID, id uuid.UUID) (*account.Account, error) {
+	var result *account.Account
+
+	err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		account, err := s.lockOwned(ctx, userID, id)
+		if err != nil {
+			return err
+		}
+
+		sum, err := s.transactionRepository.SumByAccountID(ctx, account.ID, nil)
+		if err != nil {
+			return fmt.Errorf("sum transactions: %w", err)
+		}
+
+		account.Balance = account.OpeningBalance.Add(sum)
+
+		if err := s.repository.Update(ctx, account); err != nil {
+			return fmt.Errorf("update account balance: %w", err)
+		}
+
+		if err := s.fillClearedBalances(ctx, account); err != nil {
+			return err
+		}
+
+		result = account
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (s *service) BalanceAsOf(ctx context.Context, userID, id uuid.UUID, asOf time.Time) (decimal.Decimal, error) {
+	account, err := s.GetByID(ctx, userID, id)
+	if err != nil {
+		return decimal.Zero, err
+	}
+
+	sum, err := s.transactionRepository.SumByAccountID(ctx, account.ID, &asOf)
+	if err != nil {
+		return decimal.Zero, fmt.Errorf("sum transactions: %w", err)
+	}
+
+	return account.OpeningBalance.Add(sum), nil
+}
+
+func (s *service) fillClearedBalances(ctx context.Context, accounts ...*account.Account) error {
+	if len(accounts) == 0 {
+		return nil
+	}
+
+	ids := make([]uuid.UUID, 0, len(accounts))
+	for _, a := range accounts {
+		ids = append(ids, a.ID)
+	}
+
+	sums, err := s.transactionRepository.SumClearedByAccountIDs(ctx, ids, nil)
+	if err != nil {
+		return fmt.Errorf("sum cleared transactions: %w", err)
+	}
+
+	for _, a := range accounts {
+		a.ClearedBalance = a.OpeningBalance.Add(sums[a.ID])
+	}
+
+	return nil
+}
+
+func (s *service) lockOwned(ctx context.Context, userID, id uuid.UUID) (*account.Account, error) {
+	account, err := s.repository.GetByIDForUpdate(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("lock account: %w", err)
+	}
+
+	if !account.BelongsUser(userID) {
+		return nil, apperror.ErrForbidden
+	}
+
+	return account, nil
+}
@@ -0,0 +1,114 @@
+package account
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/nontypeable/financial-tracker/internal/domain/account"
+	"github.com/nontypeable/financial-tracker/internal/domain/transaction"
+	apperror "github.com/nontypeable/financial-tracker/internal/errors"
+	"github.com/shopspring/decimal"
+)
+
+// accountStore keeps accounts in memory. Methods the tests do not reach are
+// left to the embedded interface and panic if called.
+type accountStore struct {
+	account.Repository
+	accounts map[uuid.UUID]*account.Account
+}
+
+func (r *accountStore) GetByIDForUpdate(_ context.Context, id uuid.UUID) (*account.Account, error) {
+	a, ok := r.accounts[id]
+	if !ok || a.DeletedAt != nil {
+		return nil, apperror.ErrAccountNotFound
+	}
+	return a, nil
+}
+
+func (r *accountStore) Delete(_ context.Context, userID, id uuid.UUID) error {
+	a, ok := r.accounts[id]
+	if !ok || a.DeletedAt != nil || !a.BelongsUser(userID) {
+		return apperror.ErrAccountNotFound
+	}
+	a.Delete()
+	return nil
+}
+
+type transactionStore struct {
+	transaction.Repository
+	transactions []*transaction.Transaction
+}
+
+func (r *transactionStore) HasTransfers(_ context.Context, accountID uuid.UUID) (bool, error) {
+	for _, t := range r.transactions {
+		if t.AccountID == accountID && t.IsTransfer() && t.DeletedAt == nil {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+type inline struct{}
+
+func (inline) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+func TestDelete(t *testing.T) {
+	userID := uuid.New()
+	amount := decimal.RequireFromString("25")
+
+	tests := []struct {
+		name    string
+		legs    func(from, to uuid.UUID) []*transaction.Transaction
+		wantErr error
+	}{
+		{
+			name: "account without transfers",
+			legs: func(from, _ uuid.UUID) []*transaction.Transaction {
+				return []*transaction.Transaction{transaction.NewTransaction(from, amount, transaction.Expense, "Lunch", nil)}
+			},
+		},
+		{
+			name: "account with a transfer leg",
+			legs: func(from, to uuid.UUID) []*transaction.Transaction {
+				outgoing, incoming := transaction.NewTransferLegs(from, to, amount, "Savings")
+				return []*transaction.Transaction{outgoing, incoming}
+			},
+			wantErr: apperror.ErrAccountHasTransfers,
+		},
+		{
+			name: "account with a deleted transfer",
+			legs: func(from, to uuid.UUID) []*transaction.Transaction {
+				outgoing, incoming := transaction.NewTransferLegs(from, to, amount, "Savings")
+				outgoing.Delete()
+				incoming.Delete()
+				return []*transaction.Transaction{outgoing, incoming}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			from := account.NewAccount(userID, "Checking", account.Asset, decimal.Zero)
+			from.ID = uuid.New()
+			to := account.NewAccount(userID, "Savings", account.Asset, decimal.Zero)
+			to.ID = uuid.New()
+
+			accounts := &accountStore{accounts: map[uuid.UUID]*account.Account{from.ID: from, to.ID: to}}
+			transactions := &transactionStore{transactions: tt.legs(from.ID, to.ID)}
+			s := NewService(accounts, transactions, inline{})
+
+			err := s.Delete(context.Background(), userID, from.ID)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Delete() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if deleted := from.DeletedAt != nil; deleted != (tt.wantErr == nil) {
+				t.Errorf("account deleted = %t, want %t", deleted, tt.wantErr == nil)
+			}
+		})
+	}
+}
@@ -0,0 +1,228 @@
+package budget
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nontypeable/financial-tracker/internal/domain/budget"
+	"github.com/nontypeable/financial-tracker/internal/domain/category"
+	apperror "github.com/nontypeable/financial-tracker/internal/errors"
+	"github.com/nontypeable/financial-tracker/internal/transactor"
+)
+
+type service struct {
+	repository         budget.Repository
+	categoryRepository category.Repository
+	transactor         transactor.Transactor
+}
+
+func NewService(repository budget.Repository, categoryRepository category.Repository, transactor transactor.Transactor) budget.Service {
+	return &service{
+		repository:         repository,
+		categoryRepository: categoryRepository,
+		transactor:         transactor,
+	}
+}
+
+func (s *service) Create(ctx context.Context, userID uuid.UUID, params budget.CreateParams) (uuid.UUID, error) {
+	if params.Amount.IsNegative() {
+		return uuid.Nil, apperror.ErrInvalidInput
+	}
+
+	if err := s.checkCategory(ctx, userID, params.CategoryID); err != nil {
+		return uuid.Nil, err
+	}
+
+	budget := budget.NewBudget(userID, params.CategoryID, params.Month, params.Amount, params.Rollover)
+
+	id, err := s.repository.Create(ctx, budget)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("create budget: %w", err)
+	}
+
+	return id, nil
+}
+
+func (s *service) GetByID(ctx context.Context, userID, id uuid.UUID) (*budget.Budget, error) {
+	budget, err := s.repository.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("get budget: %w", err)
+	}
+
+	if !budget.BelongsUser(userID) {
+		return nil, apperror.ErrForbidden
+	}
+
+	return budget, nil
+}
+
+func (s *service) Update(ctx context.Context, userID, id uuid.UUID, params budget.UpdateParams) error {
+	budget, err := s.GetByID(ctx, userID, id)
+	if err != nil {
+		return err
+	}
+
+	if params.Amount != nil {
+		if params.Amount.IsNegative() {
+			return apperror.ErrInvalidInput
+		}
+		budget.Amount = *params.Amount
+	}
+
+	if params.Rollover != nil {
+		budget.Rollover = *params.Rollover
+	}
+
+	if err := s.repository.Update(ctx, budget); err != nil {
+		return fmt.Errorf("update budget: %w", err)
+	}
+
+	return nil
+}
+
+func (s *service) Delete(ctx context.Context, userID, id uuid.UUID) error {
+	if _, err := s.GetByID(ctx, userID, id); err != nil {
+		return err
+	}
+
+	if err := s.repository.Delete(ctx, id); err != nil {
+		return fmt.Errorf("delete budget: %w", err)
+	}
+
+	return nil
+}
+
+func (s *service) Summary(ctx context.Context, userID uuid.UUID, month time.Time) (*budget.Summary, error) {
+	month = budget.MonthOf(month)
+
+	actuals, err := s.repository.GetActuals(ctx, userID, month)
+	if err != nil {
+		return nil, fmt.Errorf("get budget actuals: %w", err)
+	}
+
+	return budget.Summarize(month, actuals), nil
+}
+
+func (s *service) CopyFromPrevious(ctx context.Context, userID uuid.UUID, month time.Time) (int64, error) {
+	month = budget.MonthOf(month)
+
+	n, err := s.repository.CopyMonth(ctx, userID, month.AddDate(0, -1, 0), month)
+	if err != nil {
+		return 0, fmt.Errorf("copy budgets: %w", err)
+	}
+
+	return n, nil
+}
+
+func (s *service) Assign(ctx context.Context, userID uuid.UUID, params budget.AssignParams) (uuid.UUID, error) {
+	if params.Amount.IsZero() {
+		return uuid.Nil, apperror.ErrInvalidInput
+	}
+
+	if err := s.checkCategory(ctx, userID, params.CategoryID); err != nil {
+		return uuid.Nil, err
+	}
+
+	assignment := budget.NewAssignment(userID, params.CategoryID, params.Month, params.Amount, params.Note)
+
+	id, err := s.repository.CreateAssignment(ctx, assignment)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("create envelope assignment: %w", err)
+	}
+
+	return id, nil
+}
+
+func (s *service) Move(ctx context.Context, userID uuid.UUID, params budget.MoveParams) error {
+	if !params.Amount.IsPositive() {
+		return apperror.ErrInvalidInput
+	}
+
+	if params.FromCategoryID == params.ToCategoryID {
+		return apperror.ErrEnvelopeSameSource
+	}
+
+	for _, id := range []uuid.UUID{params.FromCategoryID, params.ToCategoryID} {
+		if err := s.checkCategory(ctx, userID, id); err != nil {
+			return err
+		}
+	}
+
+	from, to := budget.NewMove(userID, params.FromCategoryID, params.ToCategoryID, params.Month, params.Amount, params.Note)
+
+	return s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		for _, assignment := range []*budget.Assignment{from, to} {
+			if _, err := s.repository.CreateAssignment(ctx, assignment); err != nil {
+				return fmt.Errorf("create envelope assignment: %w", err)
+			}
+		}
+		return nil
+	})
+}
+
+func (s *service) DeleteAssignment(ctx context.Context, userID, id uuid.UUID) error {
+	assignment, err := s.repository.GetAssignmentByID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("get envelope assignment: %w", err)
+	}
+
+	if !assignment.BelongsUser(userID) {
+		return apperror.ErrForbidden
+	}
+
+	if err := s.repository.DeleteAssignment(ctx, id); err != nil {
+		return fmt.Errorf("delete envelope assignment: %w", err)
+	}
+
+	return nil
+}
+
+func (s *service) Envelopes(ctx context.Context, userID uuid.UUID, month time.Time) (*budget.EnvelopeSummary, error) {
+	month = budget.MonthOf(month)
+
+	pool, err := s.repository.GetPoolActivity(ctx, userID, month)
+	if err != nil {
+		return nil, fmt.Errorf("get pool activity: %w", err)
+	}
+
+	activity, err := s.repository.GetEnvelopeActivity(ctx, userID, month)
+	if err != nil {
+		return nil, fmt.Errorf("get envelope activity: %w", err)
+	}
+
+	return budget.SummarizeEnvelopes(month, pool, activity), nil
+}
+
+func (s *service) Ledger(ctx context.Context, userID uuid.UUID, month time.Time) ([]*budget.Assignment, error) {
+	assignments, err := s.repository.GetAssignments(ctx, userID, budget.MonthOf(month))
+	if err != nil {
+		return nil, fmt.Errorf("get envelope ledger: %w", err)
+	}
+
+	return assignments, nil
+}
+
+// checkCategory makes sure budgets and envelopes are only set on the user's
+// own active expense categories.
+func (s *service) checkCategory(ctx context.Context, userID, categoryID uuid.UUID) error {
+	c, err := s.categoryRepository.GetByID(ctx, categoryID)
+	if err != nil {
+		return fmt.Errorf("get category: %w", err)
+	}
+
+	if !c.BelongsUser(userID) {
+		return apperror.ErrForbidden
+	}
+
+	if c.IsArchived() {
+		return apperror.ErrCategoryArchived
+	}
+
+	if c.Type != category.Expense {
+		return apperror.ErrCategoryTypeMismatch
+	}
+
+	return nil
+}
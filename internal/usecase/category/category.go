@@ -0,0 +1,194 @@
+package category
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/nontypeable/financial-tracker/internal/domain/category"
+	"github.com/nontypeable/financial-tracker/internal/domain/transaction"
+	apperror "github.com/nontypeable/financial-tracker/internal/errors"
+	"github.com/nontypeable/financial-tracker/internal/transactor"
+)
+
+type service struct {
+	repository            category.Repository
+	transactionRepository transaction.Repository
+	transactor            transactor.Transactor
+}
+
+func NewService(repository category.Repository, transactionRepository transaction.Repository, transactor transactor.Transactor) category.Service {
+	return &service{
+		repository:            repository,
+		transactionRepository: transactionRepository,
+		transactor:            transactor,
+	}
+}
+
+func (s *service) Create(ctx context.Context, userID uuid.UUID, parentID *uuid.UUID, name string, categoryType category.CategoryType) (uuid.UUID, error) {
+	if parentID != nil {
+		parent, err := s.GetByID(ctx, userID, *parentID)
+		if err != nil {
+			return uuid.Nil, err
+		}
+
+		if parent.Type != categoryType {
+			return uuid.Nil, apperror.ErrCategoryTypeMismatch
+		}
+	}
+
+	category := category.NewCategory(userID, parentID, name, categoryType)
+
+	id, err := s.repository.Create(ctx, category)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("create category: %w", err)
+	}
+
+	return id, nil
+}
+
+func (s *service) GetByID(ctx context.Context, userID, id uuid.UUID) (*category.Category, error) {
+	category, err := s.repository.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("get category: %w", err)
+	}
+
+	if !category.BelongsUser(userID) {
+		return nil, apperror.ErrForbidden
+	}
+
+	return category, nil
+}
+
+func (s *service) List(ctx context.Context, userID uuid.UUID, includeArchived bool) ([]*category.Category, error) {
+	categories, err := s.repository.GetByUserID(ctx, userID, includeArchived)
+	if err != nil {
+		return nil, fmt.Errorf("list categories: %w", err)
+	}
+
+	return categories, nil
+}
+
+func (s *service) Update(ctx context.Context, userID, id uuid.UUID, params category.UpdateParams) error {
+	category, err := s.GetByID(ctx, userID, id)
+	if err != nil {
+		return err
+	}
+
+	if params.Name != nil && *params.Name != "" {
+		category.Name = *params.Name
+	}
+
+	switch {
+	case params.ClearParent:
+		category.ParentID = nil
+	case params.ParentID != nil:
+		if err := s.checkParent(ctx, userID, category, *params.ParentID); err != nil {
+			return err
+		}
+		category.ParentID = params.ParentID
+	}
+
+	if params.Archived != nil && *params.Archived != category.IsArchived() {
+		if *params.Archived {
+			category.Archive()
+		} else {
+			category.Unarchive()
+		}
+	}
+
+	if err := s.repository.Update(ctx, category); err != nil {
+		return fmt.Errorf("update category: %w", err)
+	}
+
+	return nil
+}
+
+func (s *service) Delete(ctx context.Context, userID, id uuid.UUID, params category.DeleteParams) error {
+	if params.ReassignTo == nil && !params.Uncategorize {
+		return apperror.ErrInvalidInput
+	}
+
+	category, err := s.GetByID(ctx, userID, id)
+	if err != nil {
+		return err
+	}
+
+	if params.ReassignTo != nil {
+		if *params.ReassignTo == id {
+			return apperror.ErrInvalidInput
+		}
+
+		target, err := s.GetByID(ctx, userID, *params.ReassignTo)
+		if err != nil {
+			return err
+		}
+
+		if target.Type != category.Type {
+			return apperror.ErrCategoryTypeMismatch
+		}
+	}
+
+	return s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.transactionRepository.ReassignCategory(ctx, id, params.ReassignTo); err != nil {
+			return fmt.Errorf("reassign transactions: %w", err)
+		}
+
+		if err := s.repository.Reparent(ctx, id, category.ParentID); err != nil {
+			return fmt.Errorf("reparent child categories: %w", err)
+		}
+
+		if err := s.repository.Delete(ctx, userID, id); err != nil {
+			return fmt.Errorf("delete category: %w", err)
+		}
+
+		return nil
+	})
+}
+
+func (s *service) SeedDefaults(ctx context.Context, userID uuid.UUID) error {
+	return s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		for _, seed := range category.DefaultSeeds {
+			parentID, err := s.repository.Create(ctx, category.NewCategory(userID, nil, seed.Name, seed.Type))
+			if err != nil {
+				return fmt.Errorf("seed category %q: %w", seed.Name, err)
+			}
+
+			for _, child := range seed.Children {
+				if _, err := s.repository.Create(ctx, category.NewCategory(userID, &parentID, child, seed.Type)); err != nil {
+					return fmt.Errorf("seed category %q: %w", child, err)
+				}
+			}
+		}
+
+		return nil
+	})
+}
+
+// checkParent walks up from the proposed parent and rejects the move if it
+// would place the category beneath itself.
+func (s *service) checkParent(ctx context.Context, userID uuid.UUID, c *category.Category, parentID uuid.UUID) error {
+	parent, err := s.GetByID(ctx, userID, parentID)
+	if err != nil {
+		return err
+	}
+
+	if parent.Type != c.Type {
+		return apperror.ErrCategoryTypeMismatch
+	}
+
+	for ancestor := parent; ; {
+		if ancestor.ID == c.ID {
+			return apperror.ErrCategoryCycle
+		}
+
+		if ancestor.ParentID == nil {
+			return nil
+		}
+
+		ancestor, err = s.repository.GetByID(ctx, *ancestor.ParentID)
+		if err != nil {
+			return fmt.Errorf("get parent category: %w", err)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package export
+
+import (
+	"bufio"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+type beancount struct{}
+
+func (beancount) header(w *bufio.Writer, currency string) {
+	w.WriteString(`option "title" "financial-tracker export"` + "\n")
+	w.WriteString(`option "operating_currency" "` + currency + `"` + "\n\n")
+}
+
+func (beancount) open(w *bufio.Writer, date time.Time, account, currency string) {
+	w.WriteString(date.Format(time.DateOnly) + " open " + account + " " + currency + "\n")
+}
+
+func (beancount) entry(w *bufio.Writer, e *entry, currency string) {
+	flag := "!"
+	if e.cleared {
+		flag = "*"
+	}
+
+	w.WriteString(e.date.Format(time.DateOnly) + " " + flag + " " + quote(e.narration))
+	for _, name := range e.tags {
+		if name = tag(name); name != "" {
+			w.WriteString(" #" + name)
+		}
+	}
+	w.WriteString("\n")
+
+	for _, kv := range e.meta {
+		w.WriteString("  " + kv[0] + ": " + quote(kv[1]) + "\n")
+	}
+
+	for _, p := range e.postings {
+		w.WriteString("  " + p.account + pad(p.account) + amount(p.amount) + " " + currency + "\n")
+		if p.memo != "" {
+			w.WriteString("    memo: " + quote(p.memo) + "\n")
+		}
+	}
+
+	w.WriteString("\n")
+}
+
+// balance asserts the balance at the start of the given day, before any of
+// that day's entries.
+func (beancount) balance(w *bufio.Writer, date time.Time, account string, balance decimal.Decimal, currency, note string) {
+	if note != "" {
+		w.WriteString("; " + note + "\n")
+	}
+	w.WriteString(date.Format(time.DateOnly) + " balance " + account + pad(account) + amount(balance) + " " + currency + "\n\n")
+}
+
+func quote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(line(s)) + `"`
+}
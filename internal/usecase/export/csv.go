@@ -0,0 +1,86 @@
+package export
+
+import (
+	"context"
+	"encoding/csv"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/nontypeable/financial-tracker/internal/domain/transaction"
+)
+
+var csvHeader = []string{
+	"id", "date", "account", "type", "status", "amount", "description",
+	"category", "memo", "tags", "transfer_id", "external_id",
+}
+
+// writeCSV writes one line per transaction, or one per split line for split
+// transactions, so that the amount column always sums to the total.
+// Amounts are signed: expenses and outgoing transfers are negative.
+func (s *service) writeCSV(ctx context.Context, filter *transaction.Filter, names *names, w io.Writer) error {
+	out := csv.NewWriter(w)
+
+	if err := out.Write(csvHeader); err != nil {
+		return err
+	}
+
+	err := s.transactionRepository.Stream(ctx, filter, func(t *transaction.Transaction) error {
+		record := []string{
+			t.ID.String(),
+			t.OccurredAt.Format(time.DateOnly),
+			cell(names.account(t.AccountID)),
+			string(t.Type),
+			string(t.Status),
+			t.SignedAmount().String(),
+			cell(t.Description),
+			cell(names.category(t.CategoryID, ":")),
+			"",
+			cell(strings.Join(t.Tags, ",")),
+			"",
+			"",
+		}
+		if t.TransferID != nil {
+			record[10] = t.TransferID.String()
+		}
+		if t.ExternalID != nil {
+			record[11] = cell(*t.ExternalID)
+		}
+
+		if len(t.Splits) == 0 {
+			return out.Write(record)
+		}
+
+		for _, split := range t.Splits {
+			amount := split.Amount
+			if t.Type == transaction.Expense {
+				amount = amount.Neg()
+			}
+
+			record[5] = amount.String()
+			record[7] = cell(names.category(split.CategoryID, ":"))
+			record[8] = cell(split.Memo)
+
+			if err := out.Write(record); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	out.Flush()
+	return out.Error()
+}
+
+// cell defuses text that a spreadsheet would otherwise evaluate as a
+// formula.
+func cell(s string) string {
+	if s != "" && strings.ContainsRune("=+-@\t\r", rune(s[0])) {
+		return "'" + s
+	}
+	return s
+}
@@ -0,0 +1,127 @@
+package export
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/nontypeable/financial-tracker/internal/domain/account"
+	"github.com/nontypeable/financial-tracker/internal/domain/category"
+	"github.com/nontypeable/financial-tracker/internal/domain/export"
+	"github.com/nontypeable/financial-tracker/internal/domain/reconciliation"
+	"github.com/nontypeable/financial-tracker/internal/domain/transaction"
+	apperror "github.com/nontypeable/financial-tracker/internal/errors"
+)
+
+type service struct {
+	transactionRepository    transaction.Repository
+	accountRepository        account.Repository
+	categoryRepository       category.Repository
+	reconciliationRepository reconciliation.Repository
+}
+
+func NewService(transactionRepository transaction.Repository, accountRepository account.Repository, categoryRepository category.Repository, reconciliationRepository reconciliation.Repository) export.Service {
+	return &service{
+		transactionRepository:    transactionRepository,
+		accountRepository:        accountRepository,
+		categoryRepository:       categoryRepository,
+		reconciliationRepository: reconciliationRepository,
+	}
+}
+
+func (s *service) Transactions(ctx context.Context, userID uuid.UUID, params export.TransactionsParams, w io.Writer) error {
+	filter := params.Filter
+	filter.UserID = userID
+	filter.Cursor = nil
+	filter.Normalize()
+
+	names, err := s.names(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	switch params.Format {
+	case export.FormatCSV:
+		return s.writeCSV(ctx, &filter, names, w)
+	case export.FormatNDJSON:
+		return s.writeNDJSON(ctx, &filter, names, w)
+	case export.FormatOFX:
+		return s.writeOFX(ctx, &filter, params.Currency, names, w)
+	default:
+		return apperror.ErrInvalidInput
+	}
+}
+
+// names resolves the account and category IDs found on transactions to
+// what the user sees. Categories are given with their full path.
+type names struct {
+	accounts   map[uuid.UUID]*account.Account
+	categories map[uuid.UUID][]string
+}
+
+func (s *service) names(ctx context.Context, userID uuid.UUID) (*names, error) {
+	accounts, err := s.accountRepository.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("get accounts: %w", err)
+	}
+
+	categories, err := s.categoryRepository.GetByUserID(ctx, userID, true)
+	if err != nil {
+		return nil, fmt.Errorf("get categories: %w", err)
+	}
+
+	n := &names{
+		accounts:   make(map[uuid.UUID]*account.Account, len(accounts)),
+		categories: categoryPaths(categories),
+	}
+	for _, a := range accounts {
+		n.accounts[a.ID] = a
+	}
+
+	return n, nil
+}
+
+func (n *names) account(id uuid.UUID) string {
+	if a, ok := n.accounts[id]; ok {
+		return a.Name
+	}
+	return ""
+}
+
+func (n *names) category(id *uuid.UUID, sep string) string {
+	if id == nil {
+		return ""
+	}
+	return strings.Join(n.categories[*id], sep)
+}
+
+// categoryPaths maps every category to its names from the root down.
+func categoryPaths(categories []*category.Category) map[uuid.UUID][]string {
+	byID := make(map[uuid.UUID]*category.Category, len(categories))
+	for _, c := range categories {
+		byID[c.ID] = c
+	}
+
+	paths := make(map[uuid.UUID][]string, len(categories))
+	for _, c := range categories {
+		var path []string
+		seen := make(map[uuid.UUID]struct{})
+		for cur := c; cur != nil; {
+			if _, ok := seen[cur.ID]; ok {
+				break
+			}
+			seen[cur.ID] = struct{}{}
+
+			path = append([]string{cur.Name}, path...)
+			if cur.ParentID == nil {
+				break
+			}
+			cur = byID[*cur.ParentID]
+		}
+		paths[c.ID] = path
+	}
+
+	return paths
+}
@@ -0,0 +1,508 @@
+package export
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/google/uuid"
+	"github.com/nontypeable/financial-tracker/internal/domain/account"
+	"github.com/nontypeable/financial-tracker/internal/domain/category"
+	"github.com/nontypeable/financial-tracker/internal/domain/export"
+	"github.com/nontypeable/financial-tracker/internal/domain/transaction"
+	apperror "github.com/nontypeable/financial-tracker/internal/errors"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	openingBalances       = "Equity:Opening-Balances"
+	unmatchedTransfers    = "Equity:Transfers"
+	uncategorizedIncome   = "Income:Uncategorized"
+	uncategorizedExpenses = "Expenses:Uncategorized"
+)
+
+type posting struct {
+	account string
+	amount  decimal.Decimal
+	memo    string
+}
+
+type entry struct {
+	date      time.Time
+	cleared   bool
+	narration string
+	meta      [][2]string
+	tags      []string
+	postings  []posting
+}
+
+// dialect writes journal directives in one plain-text accounting syntax.
+type dialect interface {
+	header(w *bufio.Writer, currency string)
+	open(w *bufio.Writer, date time.Time, account, currency string)
+	entry(w *bufio.Writer, e *entry, currency string)
+	balance(w *bufio.Writer, date time.Time, account string, amount decimal.Decimal, currency, note string)
+}
+
+// checkpoint is a completed reconciliation: the journal asserts the
+// account's balance at the start of the day after the statement date.
+type checkpoint struct {
+	accountID uuid.UUID
+	date      time.Time
+	statement decimal.Decimal
+}
+
+func (s *service) Journal(ctx context.Context, userID uuid.UUID, params export.JournalParams, w io.Writer) error {
+	var d dialect
+	switch params.Format {
+	case export.FormatBeancount:
+		d = beancount{}
+	case export.FormatLedger:
+		d = ledger{}
+	default:
+		return apperror.ErrInvalidInput
+	}
+
+	currency := params.Currency
+	if currency == "" {
+		currency = "USD"
+	}
+
+	accounts, err := s.accountRepository.GetByUserID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("get accounts: %w", err)
+	}
+
+	categories, err := s.categoryRepository.GetByUserID(ctx, userID, true)
+	if err != nil {
+		return fmt.Errorf("get categories: %w", err)
+	}
+
+	firsts, err := s.transactionRepository.GetFirstDates(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("get first transaction dates: %w", err)
+	}
+
+	checkpoints, err := s.checkpoints(ctx, accounts)
+	if err != nil {
+		return err
+	}
+
+	chart := newChart(accounts, categories)
+	out := bufio.NewWriter(w)
+
+	d.header(out, currency)
+	s.writeOpenings(out, d, chart, accounts, categories, firsts, currency)
+
+	running := make(map[uuid.UUID]decimal.Decimal, len(accounts))
+	for _, a := range accounts {
+		running[a.ID] = a.OpeningBalance
+	}
+
+	next := 0
+	assertUntil := func(date time.Time) {
+		for ; next < len(checkpoints) && !checkpoints[next].date.After(date); next++ {
+			c := checkpoints[next]
+			balance := running[c.accountID]
+
+			var note string
+			if !balance.Equal(c.statement) {
+				note = fmt.Sprintf("statement balance %s %s; %s %s is in transactions reconciled later or still pending",
+					amount(c.statement), currency, amount(balance.Sub(c.statement)), currency)
+			}
+
+			d.balance(out, c.date, chart.accounts[c.accountID], balance, currency, note)
+		}
+	}
+
+	// Transfer legs are written as one entry once both have been read. They
+	// share a timestamp, so the second leg follows the first closely.
+	legs := make(map[uuid.UUID]*transaction.Transaction)
+
+	filter := transaction.Filter{
+		UserID: userID,
+		SortBy: transaction.SortByDate,
+		Order:  transaction.Ascending,
+	}
+
+	err = s.transactionRepository.Stream(ctx, &filter, func(t *transaction.Transaction) error {
+		assertUntil(dateOf(t.OccurredAt))
+		running[t.AccountID] = running[t.AccountID].Add(t.SignedAmount())
+
+		if t.TransferID == nil {
+			d.entry(out, chart.entry(t), currency)
+			return writeErr(out)
+		}
+
+		other, ok := legs[*t.TransferID]
+		if !ok {
+			legs[*t.TransferID] = t
+			return nil
+		}
+
+		delete(legs, *t.TransferID)
+		d.entry(out, chart.transfer(other, t), currency)
+		return writeErr(out)
+	})
+	if err != nil {
+		return err
+	}
+
+	// A leg whose counterpart is gone, such as one in a deleted account, is
+	// balanced against equity.
+	unmatched := make([]*transaction.Transaction, 0, len(legs))
+	for _, leg := range legs {
+		unmatched = append(unmatched, leg)
+	}
+	sort.Slice(unmatched, func(i, j int) bool {
+		return unmatched[i].OccurredAt.Before(unmatched[j].OccurredAt)
+	})
+	for _, leg := range unmatched {
+		d.entry(out, chart.transfer(leg, nil), currency)
+	}
+
+	assertUntil(time.Date(9999, 12, 31, 0, 0, 0, 0, time.UTC))
+
+	return out.Flush()
+}
+
+// writeOpenings declares every account, and every category that has been
+// used, dated to its first transaction, followed by the opening balances.
+func (s *service) writeOpenings(out *bufio.Writer, d dialect, chart *chart, accounts []*account.Account, categories []*category.Category, firsts map[uuid.UUID]time.Time, currency string) {
+	type opening struct {
+		date    time.Time
+		account string
+	}
+
+	var openings []opening
+	opened := make(map[uuid.UUID]time.Time, len(accounts))
+	earliest := time.Now()
+
+	for _, a := range accounts {
+		date := a.CreatedAt
+		if first, ok := firsts[a.ID]; ok && first.Before(date) {
+			date = first
+		}
+		date = dateOf(date)
+
+		opened[a.ID] = date
+		openings = append(openings, opening{date: date, account: chart.accounts[a.ID]})
+		if date.Before(earliest) {
+			earliest = date
+		}
+	}
+
+	for _, c := range categories {
+		first, ok := firsts[c.ID]
+		if !ok {
+			continue
+		}
+
+		openings = append(openings, opening{date: dateOf(first), account: chart.categories[c.ID]})
+		if first.Before(earliest) {
+			earliest = first
+		}
+	}
+
+	earliest = dateOf(earliest)
+	for _, name := range []string{openingBalances, unmatchedTransfers, uncategorizedIncome, uncategorizedExpenses} {
+		openings = append(openings, opening{date: earliest, account: name})
+	}
+
+	sort.SliceStable(openings, func(i, j int) bool {
+		if !openings[i].date.Equal(openings[j].date) {
+			return openings[i].date.Before(openings[j].date)
+		}
+		return openings[i].account < openings[j].account
+	})
+
+	for _, o := range openings {
+		d.open(out, o.date, o.account, currency)
+	}
+	out.WriteString("\n")
+
+	for _, a := range accounts {
+		if a.OpeningBalance.IsZero() {
+			continue
+		}
+
+		d.entry(out, &entry{
+			date:      opened[a.ID],
+			cleared:   true,
+			narration: "Opening balance",
+			postings: []posting{
+				{account: chart.accounts[a.ID], amount: a.OpeningBalance},
+				{account: openingBalances, amount: a.OpeningBalance.Neg()},
+			},
+		}, currency)
+	}
+}
+
+// checkpoints lists the completed reconciliations of the accounts in date
+// order.
+func (s *service) checkpoints(ctx context.Context, accounts []*account.Account) ([]checkpoint, error) {
+	var checkpoints []checkpoint
+	for _, a := range accounts {
+		reconciliations, err := s.reconciliationRepository.GetByAccountID(ctx, a.ID)
+		if err != nil {
+			return nil, fmt.Errorf("get reconciliations: %w", err)
+		}
+
+		for _, r := range reconciliations {
+			if !r.IsCompleted() {
+				continue
+			}
+
+			checkpoints = append(checkpoints, checkpoint{
+				accountID: a.ID,
+				date:      r.Cutoff(),
+				statement: r.StatementBalance,
+			})
+		}
+	}
+
+	sort.SliceStable(checkpoints, func(i, j int) bool {
+		return checkpoints[i].date.Before(checkpoints[j].date)
+	})
+
+	return checkpoints, nil
+}
+
+// chart names the journal accounts: tracker accounts become assets or
+// liabilities by kind and categories become income or expense accounts
+// along their full path.
+type chart struct {
+	accounts   map[uuid.UUID]string
+	categories map[uuid.UUID]string
+}
+
+func newChart(accounts []*account.Account, categories []*category.Category) *chart {
+	used := map[string]struct{}{
+		openingBalances:       {},
+		unmatchedTransfers:    {},
+		uncategorizedIncome:   {},
+		uncategorizedExpenses: {},
+	}
+	unique := func(name string) string {
+		candidate := name
+		for n := 2; ; n++ {
+			if _, ok := used[candidate]; !ok {
+				used[candidate] = struct{}{}
+				return candidate
+			}
+			candidate = name + "-" + strconv.Itoa(n)
+		}
+	}
+
+	c := &chart{
+		accounts:   make(map[uuid.UUID]string, len(accounts)),
+		categories: make(map[uuid.UUID]string, len(categories)),
+	}
+
+	for _, a := range accounts {
+		root := "Assets"
+		if a.Kind == account.Liability {
+			root = "Liabilities"
+		}
+		c.accounts[a.ID] = unique(root + ":" + component(a.Name))
+	}
+
+	paths := categoryPaths(categories)
+	for _, cat := range categories {
+		root := "Expenses"
+		if cat.Type == category.Income {
+			root = "Income"
+		}
+
+		parts := []string{root}
+		for _, name := range paths[cat.ID] {
+			parts = append(parts, component(name))
+		}
+		c.categories[cat.ID] = unique(strings.Join(parts, ":"))
+	}
+
+	return c
+}
+
+func (c *chart) category(id *uuid.UUID, transactionType transaction.TransactionType) string {
+	if id != nil {
+		if name, ok := c.categories[*id]; ok {
+			return name
+		}
+	}
+
+	if transactionType == transaction.Income {
+		return uncategorizedIncome
+	}
+	return uncategorizedExpenses
+}
+
+func (c *chart) entry(t *transaction.Transaction) *entry {
+	signed := t.SignedAmount()
+
+	e := &entry{
+		date:      dateOf(t.OccurredAt),
+		cleared:   t.Status != transaction.Pending,
+		narration: t.Description,
+		meta:      [][2]string{{"id", t.ID.String()}},
+		tags:      t.Tags,
+		postings:  []posting{{account: c.accounts[t.AccountID], amount: signed}},
+	}
+
+	if len(t.Splits) == 0 {
+		e.postings = append(e.postings, posting{
+			account: c.category(t.CategoryID, t.Type),
+			amount:  signed.Neg(),
+		})
+		return e
+	}
+
+	for _, split := range t.Splits {
+		amount := split.Amount
+		if t.Type == transaction.Income {
+			amount = amount.Neg()
+		}
+
+		e.postings = append(e.postings, posting{
+			account: c.category(split.CategoryID, t.Type),
+			amount:  amount,
+			memo:    split.Memo,
+		})
+	}
+
+	return e
+}
+
+// transfer joins the two legs of a transfer into one entry. A missing
+// second leg is replaced by an equity posting.
+func (c *chart) transfer(first, second *transaction.Transaction) *entry {
+	e := &entry{
+		date:      dateOf(first.OccurredAt),
+		cleared:   first.Status != transaction.Pending,
+		narration: first.Description,
+		meta:      [][2]string{{"transfer_id", first.TransferID.String()}},
+		tags:      first.Tags,
+		postings:  []posting{{account: c.accounts[first.AccountID], amount: first.Amount}},
+	}
+
+	if second == nil {
+		e.postings = append(e.postings, posting{account: unmatchedTransfers, amount: first.Amount.Neg()})
+		return e
+	}
+
+	e.cleared = e.cleared && second.Status != transaction.Pending
+	e.tags = mergeTags(first.Tags, second.Tags)
+	e.postings = append(e.postings, posting{account: c.accounts[second.AccountID], amount: second.Amount})
+
+	// List the outgoing leg first.
+	if e.postings[0].amount.IsPositive() {
+		e.postings[0], e.postings[1] = e.postings[1], e.postings[0]
+	}
+
+	return e
+}
+
+func mergeTags(a, b []string) []string {
+	seen := make(map[string]struct{}, len(a)+len(b))
+	var tags []string
+	for _, tag := range append(append([]string{}, a...), b...) {
+		if _, ok := seen[tag]; ok {
+			continue
+		}
+		seen[tag] = struct{}{}
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+	return tags
+}
+
+// writeErr reports a failed write, which bufio holds on to once its buffer
+// could not be flushed, so that a closed connection stops the export.
+func writeErr(w *bufio.Writer) error {
+	_, err := w.Write(nil)
+	return err
+}
+
+// component turns a name into an account name component both Beancount and
+// ledger accept: words are capitalised and joined with dashes, and the
+// component starts with an upper-case letter or a digit.
+func component(name string) string {
+	var b strings.Builder
+	wordStart := true
+
+	for _, r := range name {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			wordStart = true
+			continue
+		}
+
+		if wordStart {
+			if b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			r = unicode.ToUpper(r)
+			wordStart = false
+		}
+		b.WriteRune(r)
+	}
+
+	s := b.String()
+	if s == "" {
+		return "Unnamed"
+	}
+
+	if first := []rune(s)[0]; !unicode.IsUpper(first) && !unicode.IsDigit(first) {
+		s = "X-" + s
+	}
+	return s
+}
+
+// amount writes the exact decimal with at least two fractional digits.
+func amount(d decimal.Decimal) string {
+	places := int32(2)
+	if -d.Exponent() > places {
+		places = -d.Exponent()
+	}
+	return d.StringFixed(places)
+}
+
+// dateOf is the calendar day in UTC, the same day boundaries that
+// reconciliations use.
+func dateOf(t time.Time) time.Time {
+	year, month, day := t.UTC().Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// tag keeps the characters both syntaxes allow in a tag name, which are
+// ASCII only in Beancount.
+func tag(name string) string {
+	return strings.Map(func(r rune) rune {
+		if r <= unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r) || strings.ContainsRune("-_/.", r)) {
+			return r
+		}
+		if unicode.IsSpace(r) {
+			return '-'
+		}
+		return -1
+	}, name)
+}
+
+// line collapses whitespace, newlines included, to single spaces.
+func line(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+func pad(account string) string {
+	const width = 48
+	n := width - len([]rune(account))
+	if n < 2 {
+		n = 2
+	}
+	return strings.Repeat(" ", n)
+}
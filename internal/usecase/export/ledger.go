@@ -0,0 +1,64 @@
+package export
+
+import (
+	"bufio"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// ledger writes ledger-cli journal syntax, which hledger reads as well.
+type ledger struct{}
+
+func (ledger) header(w *bufio.Writer, currency string) {
+	w.WriteString("; financial-tracker export\n\n")
+	w.WriteString("commodity " + currency + "\n\n")
+}
+
+// open declares the account; ledger accounts carry no opening date.
+func (ledger) open(w *bufio.Writer, _ time.Time, account, _ string) {
+	w.WriteString("account " + account + "\n")
+}
+
+func (ledger) entry(w *bufio.Writer, e *entry, currency string) {
+	flag := "!"
+	if e.cleared {
+		flag = "*"
+	}
+
+	w.WriteString(e.date.Format(time.DateOnly) + " " + flag + " " + line(e.narration) + "\n")
+
+	for _, kv := range e.meta {
+		w.WriteString("    ; " + kv[0] + ": " + kv[1] + "\n")
+	}
+
+	var tags string
+	for _, name := range e.tags {
+		if name = tag(name); name != "" {
+			tags += name + ":"
+		}
+	}
+	if tags != "" {
+		w.WriteString("    ; :" + tags + "\n")
+	}
+
+	for _, p := range e.postings {
+		w.WriteString("    " + p.account + pad(p.account) + amount(p.amount) + " " + currency)
+		if p.memo != "" {
+			w.WriteString("  ; " + line(p.memo))
+		}
+		w.WriteString("\n")
+	}
+
+	w.WriteString("\n")
+}
+
+// balance asserts the balance with an empty posting placed before the
+// day's entries, since ledger checks assertions in file order.
+func (ledger) balance(w *bufio.Writer, date time.Time, account string, balance decimal.Decimal, currency, note string) {
+	w.WriteString(date.Format(time.DateOnly) + " * Balance assertion\n")
+	if note != "" {
+		w.WriteString("    ; " + note + "\n")
+	}
+	w.WriteString("    " + account + pad(account) + "0 " + currency + " = " + amount(balance) + " " + currency + "\n\n")
+}
@@ -0,0 +1,88 @@
+package export
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nontypeable/financial-tracker/internal/domain/transaction"
+	"github.com/shopspring/decimal"
+)
+
+type record struct {
+	ID          uuid.UUID                   `json:"id"`
+	Date        string                      `json:"date"`
+	OccurredAt  time.Time                   `json:"occurred_at"`
+	AccountID   uuid.UUID                   `json:"account_id"`
+	Account     string                      `json:"account"`
+	Type        transaction.TransactionType `json:"type"`
+	Status      transaction.Status          `json:"status"`
+	Amount      decimal.Decimal             `json:"amount"`
+	Description string                      `json:"description"`
+	CategoryID  *uuid.UUID                  `json:"category_id,omitempty"`
+	Category    string                      `json:"category,omitempty"`
+	Splits      []splitRecord               `json:"splits,omitempty"`
+	Tags        []string                    `json:"tags"`
+	TransferID  *uuid.UUID                  `json:"transfer_id,omitempty"`
+	ExternalID  *string                     `json:"external_id,omitempty"`
+}
+
+type splitRecord struct {
+	CategoryID *uuid.UUID      `json:"category_id,omitempty"`
+	Category   string          `json:"category,omitempty"`
+	Amount     decimal.Decimal `json:"amount"`
+	Memo       string          `json:"memo,omitempty"`
+}
+
+// writeNDJSON writes one JSON object per line. Amounts are signed strings so
+// that no precision is lost to floating point.
+func (s *service) writeNDJSON(ctx context.Context, filter *transaction.Filter, names *names, w io.Writer) error {
+	out := bufio.NewWriter(w)
+	encoder := json.NewEncoder(out)
+
+	err := s.transactionRepository.Stream(ctx, filter, func(t *transaction.Transaction) error {
+		r := record{
+			ID:          t.ID,
+			Date:        t.OccurredAt.Format(time.DateOnly),
+			OccurredAt:  t.OccurredAt,
+			AccountID:   t.AccountID,
+			Account:     names.account(t.AccountID),
+			Type:        t.Type,
+			Status:      t.Status,
+			Amount:      t.SignedAmount(),
+			Description: t.Description,
+			CategoryID:  t.CategoryID,
+			Category:    names.category(t.CategoryID, ":"),
+			Tags:        t.Tags,
+			TransferID:  t.TransferID,
+			ExternalID:  t.ExternalID,
+		}
+		if r.Tags == nil {
+			r.Tags = []string{}
+		}
+
+		for _, split := range t.Splits {
+			amount := split.Amount
+			if t.Type == transaction.Expense {
+				amount = amount.Neg()
+			}
+
+			r.Splits = append(r.Splits, splitRecord{
+				CategoryID: split.CategoryID,
+				Category:   names.category(split.CategoryID, ":"),
+				Amount:     amount,
+				Memo:       split.Memo,
+			})
+		}
+
+		return encoder.Encode(&r)
+	})
+	if err != nil {
+		return err
+	}
+
+	return out.Flush()
+}
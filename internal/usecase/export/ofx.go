@@ -0,0 +1,191 @@
+package export
+
+import (
+	"bufio"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nontypeable/financial-tracker/internal/domain/account"
+	"github.com/nontypeable/financial-tracker/internal/domain/transaction"
+)
+
+const ofxHeader = `<?xml version="1.0" encoding="UTF-8" standalone="no"?>
+<?OFX OFXHEADER="200" VERSION="220" SECURITY="NONE" OLDFILEUID="NONE" NEWFILEUID="NONE"?>
+`
+
+// ofxNameLength is the longest NAME an OFX transaction may carry; longer
+// descriptions go to MEMO in full.
+const ofxNameLength = 32
+
+type ofxWriter struct {
+	*bufio.Writer
+}
+
+// writeOFX writes an OFX 2.2 file with one bank statement per account. Each
+// account is streamed separately in date order, since a statement lists its
+// date range before its transactions and ends with the ledger balance.
+func (s *service) writeOFX(ctx context.Context, filter *transaction.Filter, currency string, names *names, w io.Writer) error {
+	if currency == "" {
+		currency = "USD"
+	}
+
+	end := time.Now()
+	if filter.To != nil {
+		end = *filter.To
+	}
+
+	out := ofxWriter{bufio.NewWriter(w)}
+	out.WriteString(ofxHeader)
+	out.WriteString("<OFX>\n<SIGNONMSGSRSV1><SONRS>")
+	out.status()
+	out.element("DTSERVER", ofxTime(time.Now()))
+	out.element("LANGUAGE", "ENG")
+	out.WriteString("</SONRS></SIGNONMSGSRSV1>\n<BANKMSGSRSV1>\n")
+
+	for _, a := range selectAccounts(names.accounts, filter.AccountIDs) {
+		accountFilter := *filter
+		accountFilter.AccountIDs = []uuid.UUID{a.ID}
+		accountFilter.SortBy = transaction.SortByDate
+		accountFilter.Order = transaction.Ascending
+
+		opened := false
+		open := func(start time.Time) {
+			if filter.From != nil {
+				start = *filter.From
+			}
+
+			out.WriteString("<STMTTRNRS>")
+			out.element("TRNUID", a.ID.String())
+			out.status()
+			out.WriteString("<STMTRS>")
+			out.element("CURDEF", currency)
+			out.WriteString("<BANKACCTFROM>")
+			out.element("BANKID", "0")
+			out.element("ACCTID", a.ID.String())
+			out.element("ACCTTYPE", "CHECKING")
+			out.WriteString("</BANKACCTFROM>\n<BANKTRANLIST>")
+			out.element("DTSTART", ofxTime(start))
+			out.element("DTEND", ofxTime(end))
+			out.WriteString("\n")
+			opened = true
+		}
+
+		err := s.transactionRepository.Stream(ctx, &accountFilter, func(t *transaction.Transaction) error {
+			if !opened {
+				open(t.OccurredAt)
+			}
+
+			out.WriteString("<STMTTRN>")
+			out.element("TRNTYPE", ofxType(t))
+			out.element("DTPOSTED", ofxTime(t.OccurredAt))
+			out.element("TRNAMT", t.SignedAmount().String())
+			if t.ExternalID != nil {
+				out.element("FITID", *t.ExternalID)
+			} else {
+				out.element("FITID", t.ID.String())
+			}
+
+			name := []rune(t.Description)
+			if len(name) > ofxNameLength {
+				name = name[:ofxNameLength]
+			}
+			if len(name) > 0 {
+				out.element("NAME", string(name))
+			}
+
+			memo := t.Description
+			if category := names.category(t.CategoryID, ":"); category != "" {
+				memo = strings.TrimSpace(memo + " [" + category + "]")
+			}
+			if memo != string(name) {
+				out.element("MEMO", memo)
+			}
+
+			_, err := out.WriteString("</STMTTRN>\n")
+			return err
+		})
+		if err != nil {
+			return err
+		}
+
+		if !opened {
+			open(end)
+		}
+
+		sum, err := s.transactionRepository.SumByAccountID(ctx, a.ID, &end)
+		if err != nil {
+			return fmt.Errorf("sum transactions: %w", err)
+		}
+
+		out.WriteString("</BANKTRANLIST>\n<LEDGERBAL>")
+		out.element("BALAMT", a.OpeningBalance.Add(sum).String())
+		out.element("DTASOF", ofxTime(end))
+		out.WriteString("</LEDGERBAL>\n</STMTRS></STMTTRNRS>\n")
+	}
+
+	out.WriteString("</BANKMSGSRSV1>\n</OFX>\n")
+	return out.Flush()
+}
+
+// selectAccounts returns the accounts named by the filter, or all of them,
+// ordered by name.
+func selectAccounts(accounts map[uuid.UUID]*account.Account, ids []uuid.UUID) []*account.Account {
+	var selected []*account.Account
+	if len(ids) == 0 {
+		for _, a := range accounts {
+			selected = append(selected, a)
+		}
+	} else {
+		seen := make(map[uuid.UUID]struct{}, len(ids))
+		for _, id := range ids {
+			if _, ok := seen[id]; ok {
+				continue
+			}
+			seen[id] = struct{}{}
+
+			if a, ok := accounts[id]; ok {
+				selected = append(selected, a)
+			}
+		}
+	}
+
+	sort.Slice(selected, func(i, j int) bool {
+		if selected[i].Name != selected[j].Name {
+			return selected[i].Name < selected[j].Name
+		}
+		return selected[i].ID.String() < selected[j].ID.String()
+	})
+
+	return selected
+}
+
+func (w ofxWriter) element(tag, value string) {
+	w.WriteString("<" + tag + ">")
+	xml.EscapeText(w, []byte(value))
+	w.WriteString("</" + tag + ">")
+}
+
+func (w ofxWriter) status() {
+	w.WriteString("<STATUS><CODE>0</CODE><SEVERITY>INFO</SEVERITY></STATUS>")
+}
+
+func ofxType(t *transaction.Transaction) string {
+	switch t.Type {
+	case transaction.Income:
+		return "CREDIT"
+	case transaction.Expense:
+		return "DEBIT"
+	default:
+		return "XFER"
+	}
+}
+
+func ofxTime(t time.Time) string {
+	return t.UTC().Format("20060102150405") + "[0:GMT]"
+}
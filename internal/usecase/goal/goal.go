@@ -0,0 +1,219 @@
+package goal
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nontypeable/financial-tracker/internal/domain/account"
+	"github.com/nontypeable/financial-tracker/internal/domain/goal"
+	apperror "github.com/nontypeable/financial-tracker/internal/errors"
+	"github.com/nontypeable/financial-tracker/internal/transactor"
+	"github.com/shopspring/decimal"
+)
+
+type service struct {
+	repository        goal.Repository
+	accountRepository account.Repository
+	transactor        transactor.Transactor
+}
+
+func NewService(repository goal.Repository, accountRepository account.Repository, transactor transactor.Transactor) goal.Service {
+	return &service{
+		repository:        repository,
+		accountRepository: accountRepository,
+		transactor:        transactor,
+	}
+}
+
+func (s *service) Create(ctx context.Context, userID uuid.UUID, params goal.CreateParams) (uuid.UUID, error) {
+	if !params.TargetAmount.IsPositive() {
+		return uuid.Nil, apperror.ErrInvalidInput
+	}
+
+	if err := s.checkAccounts(ctx, userID, params.Accounts); err != nil {
+		return uuid.Nil, err
+	}
+
+	goal := goal.NewGoal(userID, params.Name, params.TargetAmount, params.TargetDate, params.Accounts)
+
+	var id uuid.UUID
+	err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		id, err = s.repository.Create(ctx, goal)
+		if err != nil {
+			return fmt.Errorf("create goal: %w", err)
+		}
+
+		if err := s.repository.ReplaceAccounts(ctx, id, goal.Accounts); err != nil {
+			return fmt.Errorf("link goal accounts: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	return id, nil
+}
+
+func (s *service) GetByID(ctx context.Context, userID, id uuid.UUID) (*goal.Progress, error) {
+	goal, err := s.ownedGoal(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	progress, err := s.evaluate(ctx, userID, goal)
+	if err != nil {
+		return nil, err
+	}
+
+	return progress[0], nil
+}
+
+func (s *service) List(ctx context.Context, userID uuid.UUID, includeArchived bool) ([]*goal.Progress, error) {
+	goals, err := s.repository.GetByUserID(ctx, userID, includeArchived)
+	if err != nil {
+		return nil, fmt.Errorf("list goals: %w", err)
+	}
+
+	return s.evaluate(ctx, userID, goals...)
+}
+
+func (s *service) Update(ctx context.Context, userID, id uuid.UUID, params goal.UpdateParams) error {
+	goal, err := s.ownedGoal(ctx, userID, id)
+	if err != nil {
+		return err
+	}
+
+	if params.Name != nil {
+		goal.Name = *params.Name
+	}
+
+	if params.TargetAmount != nil {
+		if !params.TargetAmount.IsPositive() {
+			return apperror.ErrInvalidInput
+		}
+		goal.TargetAmount = *params.TargetAmount
+	}
+
+	if params.TargetDate != nil {
+		year, month, day := params.TargetDate.Date()
+		goal.TargetDate = time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	}
+
+	if params.Archived != nil && *params.Archived != goal.IsArchived() {
+		if *params.Archived {
+			goal.Archive()
+		} else {
+			goal.Unarchive()
+		}
+	}
+
+	if params.Accounts != nil {
+		if err := s.checkAccounts(ctx, userID, params.Accounts); err != nil {
+			return err
+		}
+	}
+
+	return s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repository.Update(ctx, goal); err != nil {
+			return fmt.Errorf("update goal: %w", err)
+		}
+
+		if params.Accounts != nil {
+			if err := s.repository.ReplaceAccounts(ctx, goal.ID, params.Accounts); err != nil {
+				return fmt.Errorf("link goal accounts: %w", err)
+			}
+		}
+
+		return nil
+	})
+}
+
+func (s *service) ownedGoal(ctx context.Context, userID, id uuid.UUID) (*goal.Goal, error) {
+	goal, err := s.repository.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("get goal: %w", err)
+	}
+
+	if !goal.BelongsUser(userID) {
+		return nil, apperror.ErrForbidden
+	}
+
+	return goal, nil
+}
+
+// evaluate measures the goals against the user's current account balances
+// and their contributions over the trailing ContributionMonths months.
+func (s *service) evaluate(ctx context.Context, userID uuid.UUID, goals ...*goal.Goal) ([]*goal.Progress, error) {
+	progress := make([]*goal.Progress, 0, len(goals))
+	if len(goals) == 0 {
+		return progress, nil
+	}
+
+	accounts, err := s.accountRepository.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("get accounts: %w", err)
+	}
+
+	balances := make(map[uuid.UUID]decimal.Decimal, len(accounts))
+	for _, a := range accounts {
+		balances[a.ID] = a.Balance
+	}
+
+	ids := make([]uuid.UUID, 0, len(goals))
+	for _, g := range goals {
+		ids = append(ids, g.ID)
+	}
+
+	now := time.Now()
+	contributions, err := s.repository.SumContributions(ctx, ids, now.AddDate(0, -goal.ContributionMonths, 0), now)
+	if err != nil {
+		return nil, fmt.Errorf("sum goal contributions: %w", err)
+	}
+
+	for _, g := range goals {
+		progress = append(progress, goal.Evaluate(g, balances, contributions[g.ID], now))
+	}
+
+	return progress, nil
+}
+
+// checkAccounts makes sure a goal links at least one of the user's
+// accounts, each once and with a share between 0 and 1.
+func (s *service) checkAccounts(ctx context.Context, userID uuid.UUID, links []goal.Link) error {
+	if len(links) == 0 {
+		return apperror.ErrInvalidInput
+	}
+
+	seen := make(map[uuid.UUID]struct{}, len(links))
+	for _, link := range links {
+		if !link.Share.IsPositive() || link.Share.GreaterThan(decimal.NewFromInt(1)) {
+			return apperror.ErrInvalidInput
+		}
+
+		// The column would round a finer share instead of storing it.
+		if !link.Share.Equal(link.Share.Truncate(goal.ShareScale)) {
+			return apperror.ErrInvalidInput
+		}
+
+		if _, ok := seen[link.AccountID]; ok {
+			return apperror.ErrInvalidInput
+		}
+		seen[link.AccountID] = struct{}{}
+
+		account, err := s.accountRepository.GetByID(ctx, link.AccountID)
+		if err != nil {
+			return fmt.Errorf("get account: %w", err)
+		}
+
+		if !account.BelongsUser(userID) {
+			return apperror.ErrForbidden
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,400 @@
+package importer
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/google/uuid"
+	"github.com/nontypeable/financial-tracker/internal/domain/account"
+	"github.com/nontypeable/financial-tracker/internal/domain/category"
+	"github.com/nontypeable/financial-tracker/internal/domain/importer"
+	"github.com/nontypeable/financial-tracker/internal/domain/transaction"
+	"github.com/nontypeable/financial-tracker/internal/domain/transfer"
+	apperror "github.com/nontypeable/financial-tracker/internal/errors"
+	"github.com/nontypeable/financial-tracker/internal/statement"
+	"github.com/nontypeable/financial-tracker/internal/statement/camt"
+	"github.com/nontypeable/financial-tracker/internal/statement/csv"
+	"github.com/nontypeable/financial-tracker/internal/statement/mt940"
+	"github.com/nontypeable/financial-tracker/internal/statement/ofx"
+	"github.com/nontypeable/financial-tracker/internal/transactor"
+)
+
+type service struct {
+	repository            importer.Repository
+	transactionService    transaction.Service
+	transferService       transfer.Service
+	transactionRepository transaction.Repository
+	accountRepository     account.Repository
+	categoryRepository    category.Repository
+	transactor            transactor.Transactor
+}
+
+func NewService(repository importer.Repository, transactionService transaction.Service, transferService transfer.Service, transactionRepository transaction.Repository, accountRepository account.Repository, categoryRepository category.Repository, transactor transactor.Transactor) importer.Service {
+	return &service{
+		repository:            repository,
+		transactionService:    transactionService,
+		transferService:       transferService,
+		transactionRepository: transactionRepository,
+		accountRepository:     accountRepository,
+		categoryRepository:    categoryRepository,
+		transactor:            transactor,
+	}
+}
+
+func (s *service) CreateProfile(ctx context.Context, userID uuid.UUID, name string, settings csv.Profile) (uuid.UUID, error) {
+	if err := settings.Validate(); err != nil {
+		return uuid.Nil, err
+	}
+
+	profile := importer.NewProfile(userID, name, settings)
+
+	id, err := s.repository.CreateProfile(ctx, profile)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("create import profile: %w", err)
+	}
+
+	return id, nil
+}
+
+func (s *service) GetProfile(ctx context.Context, userID, id uuid.UUID) (*importer.Profile, error) {
+	profile, err := s.repository.GetProfileByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("get import profile: %w", err)
+	}
+
+	if !profile.BelongsUser(userID) {
+		return nil, apperror.ErrForbidden
+	}
+
+	return profile, nil
+}
+
+func (s *service) ListProfiles(ctx context.Context, userID uuid.UUID) ([]*importer.Profile, error) {
+	profiles, err := s.repository.GetProfilesByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("list import profiles: %w", err)
+	}
+
+	return profiles, nil
+}
+
+func (s *service) UpdateProfile(ctx context.Context, userID, id uuid.UUID, params importer.UpdateProfileParams) error {
+	profile, err := s.GetProfile(ctx, userID, id)
+	if err != nil {
+		return err
+	}
+
+	if params.Name != nil {
+		profile.Name = *params.Name
+	}
+
+	if params.Settings != nil {
+		if err := params.Settings.Validate(); err != nil {
+			return err
+		}
+		profile.Settings = *params.Settings
+	}
+
+	if err := s.repository.UpdateProfile(ctx, profile); err != nil {
+		return fmt.Errorf("update import profile: %w", err)
+	}
+
+	return nil
+}
+
+func (s *service) DeleteProfile(ctx context.Context, userID, id uuid.UUID) error {
+	if err := s.repository.DeleteProfile(ctx, userID, id); err != nil {
+		return fmt.Errorf("delete import profile: %w", err)
+	}
+
+	return nil
+}
+
+func (s *service) ImportCSV(ctx context.Context, userID, accountID, profileID uuid.UUID, filename string, file io.Reader) (*importer.Batch, error) {
+	if err := s.checkAccount(ctx, userID, accountID); err != nil {
+		return nil, err
+	}
+
+	profile, err := s.GetProfile(ctx, userID, profileID)
+	if err != nil {
+		return nil, err
+	}
+
+	stmt, err := csv.Parse(file, profile.Settings)
+	if err != nil {
+		return nil, fmt.Errorf("parse csv statement: %w", err)
+	}
+
+	batch := importer.NewBatch(userID, accountID, importer.FormatCSV, filename, stmt)
+
+	return s.store(ctx, batch)
+}
+
+func (s *service) ImportStatement(ctx context.Context, userID uuid.UUID, params importer.StatementParams, file io.Reader) (*importer.Batch, error) {
+	if err := s.checkAccount(ctx, userID, params.AccountID); err != nil {
+		return nil, err
+	}
+
+	var (
+		stmt *statement.Statement
+		err  error
+	)
+	switch params.Format {
+	case importer.FormatOFX:
+		stmt, err = ofx.Parse(file)
+	case importer.FormatCAMT053:
+		stmt, err = camt.Parse(file)
+	case importer.FormatMT940:
+		stmt, err = mt940.Parse(file, params.Encoding)
+	default:
+		return nil, apperror.ErrInvalidInput
+	}
+	if err != nil {
+		return nil, fmt.Errorf("parse %s statement: %w", params.Format, err)
+	}
+
+	batch := importer.NewBatch(userID, params.AccountID, params.Format, params.Filename, stmt)
+
+	if _, err := s.markDuplicates(ctx, batch); err != nil {
+		return nil, err
+	}
+
+	return s.store(ctx, batch)
+}
+
+func (s *service) GetBatch(ctx context.Context, userID, id uuid.UUID) (*importer.Batch, error) {
+	batch, err := s.repository.GetBatchByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("get import batch: %w", err)
+	}
+
+	if !batch.BelongsUser(userID) {
+		return nil, apperror.ErrForbidden
+	}
+
+	if err := s.checkBalance(ctx, batch); err != nil {
+		return nil, err
+	}
+
+	if err := s.flagDuplicates(ctx, batch); err != nil {
+		return nil, err
+	}
+
+	return batch, nil
+}
+
+func (s *service) Commit(ctx context.Context, userID, id uuid.UUID, skipDuplicates bool) (*importer.Batch, error) {
+	var result *importer.Batch
+
+	err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		batch, err := s.repository.GetBatchByIDForUpdate(ctx, id)
+		if err != nil {
+			return fmt.Errorf("lock import batch: %w", err)
+		}
+
+		if !batch.BelongsUser(userID) {
+			return apperror.ErrForbidden
+		}
+
+		if batch.IsCommitted() {
+			return apperror.ErrImportAlreadyCommitted
+		}
+
+		// Another batch may have imported the same entries since the
+		// preview was taken.
+		rejected, err := s.markDuplicates(ctx, batch)
+		if err != nil {
+			return err
+		}
+
+		if skipDuplicates {
+			if err := s.flagDuplicates(ctx, batch); err != nil {
+				return err
+			}
+			rejected = append(rejected, batch.RejectFlagged()...)
+		}
+
+		for _, row := range rejected {
+			if err := s.repository.UpdateRow(ctx, row); err != nil {
+				return fmt.Errorf("reject import row: %w", err)
+			}
+		}
+
+		for i := range batch.Rows {
+			row := &batch.Rows[i]
+			if !row.IsValid() {
+				continue
+			}
+
+			params := transaction.CreateParams{
+				AccountID:   batch.AccountID,
+				Amount:      row.Amount.Abs(),
+				Type:        row.Type(),
+				Description: row.Description,
+				OccurredAt:  row.OccurredAt,
+				Status:      transaction.Cleared,
+			}
+			if row.ExternalID != "" {
+				params.ExternalID = &row.ExternalID
+			}
+
+			transactionID, err := s.transactionService.Create(ctx, userID, params)
+			if err != nil {
+				return fmt.Errorf("import line %d: %w", row.Line, err)
+			}
+
+			row.TransactionID = &transactionID
+
+			if err := s.repository.UpdateRow(ctx, row); err != nil {
+				return fmt.Errorf("link import row: %w", err)
+			}
+		}
+
+		batch.Commit()
+
+		if err := s.repository.UpdateBatch(ctx, batch); err != nil {
+			return fmt.Errorf("commit import batch: %w", err)
+		}
+
+		result = batch
+		return s.checkBalance(ctx, batch)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (s *service) Discard(ctx context.Context, userID, id uuid.UUID) error {
+	batch, err := s.GetBatch(ctx, userID, id)
+	if err != nil {
+		return err
+	}
+
+	if batch.IsCommitted() {
+		return apperror.ErrImportAlreadyCommitted
+	}
+
+	if err := s.repository.DeleteBatch(ctx, batch.ID); err != nil {
+		return fmt.Errorf("delete import batch: %w", err)
+	}
+
+	return nil
+}
+
+func (s *service) store(ctx context.Context, batch *importer.Batch) (*importer.Batch, error) {
+	err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		id, err := s.repository.CreateBatch(ctx, batch)
+		if err != nil {
+			return fmt.Errorf("create import batch: %w", err)
+		}
+
+		batch.ID = id
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	stored, err := s.repository.GetBatchByID(ctx, batch.ID)
+	if err != nil {
+		return nil, fmt.Errorf("get import batch: %w", err)
+	}
+
+	if err := s.checkBalance(ctx, stored); err != nil {
+		return nil, err
+	}
+
+	if err := s.flagDuplicates(ctx, stored); err != nil {
+		return nil, err
+	}
+
+	return stored, nil
+}
+
+func (s *service) markDuplicates(ctx context.Context, batch *importer.Batch) ([]*importer.Row, error) {
+	existing := make(map[string]struct{})
+
+	if ids := batch.ExternalIDs(); len(ids) > 0 {
+		var err error
+		existing, err = s.transactionRepository.GetExternalIDs(ctx, batch.AccountID, ids)
+		if err != nil {
+			return nil, fmt.Errorf("get imported external ids: %w", err)
+		}
+	}
+
+	return batch.MarkDuplicates(existing), nil
+}
+
+// checkBalance fills batch.Check when the statement reports a closing
+// balance. The account side is the working balance as of the statement
+// date, so pending transactions count.
+func (s *service) checkBalance(ctx context.Context, batch *importer.Batch) error {
+	if batch.StatementBalance == nil || batch.StatementDate == nil {
+		return nil
+	}
+
+	account, err := s.accountRepository.GetByID(ctx, batch.AccountID)
+	if err != nil {
+		return fmt.Errorf("get account: %w", err)
+	}
+
+	cutoff := batch.Cutoff()
+
+	sum, err := s.transactionRepository.SumByAccountID(ctx, account.ID, &cutoff)
+	if err != nil {
+		return fmt.Errorf("sum transactions: %w", err)
+	}
+
+	balance := account.OpeningBalance.Add(sum)
+	pending := batch.PendingBefore(cutoff)
+
+	batch.Check = &importer.BalanceCheck{
+		StatementBalance: *batch.StatementBalance,
+		StatementDate:    *batch.StatementDate,
+		AccountBalance:   balance,
+		Pending:          pending,
+		Difference:       batch.StatementBalance.Sub(balance.Add(pending)),
+	}
+
+	return nil
+}
+
+// flagDuplicates points the rows of a preview batch at the existing
+// transactions they most likely repeat.
+func (s *service) flagDuplicates(ctx context.Context, batch *importer.Batch) error {
+	if batch.IsCommitted() {
+		return nil
+	}
+
+	from, to, ok := batch.Span()
+	if !ok {
+		return nil
+	}
+
+	// Pad the range by a day beyond the window so time zones cannot hide
+	// a candidate; the scorer applies the exact limit.
+	existing, err := s.transactionRepository.GetByAccountIDBetween(ctx, batch.AccountID,
+		from.AddDate(0, 0, -transaction.DuplicateWindow-1), to.AddDate(0, 0, transaction.DuplicateWindow+2))
+	if err != nil {
+		return fmt.Errorf("get account transactions: %w", err)
+	}
+
+	batch.FlagDuplicates(existing)
+	return nil
+}
+
+func (s *service) checkAccount(ctx context.Context, userID, accountID uuid.UUID) error {
+	account, err := s.accountRepository.GetByID(ctx, accountID)
+	if err != nil {
+		return fmt.Errorf("get account: %w", err)
+	}
+
+	if !account.BelongsUser(userID) {
+		return apperror.ErrForbidden
+	}
+
+	return nil
+}
@@ -0,0 +1,371 @@
+package importer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nontypeable/financial-tracker/internal/domain/account"
+	"github.com/nontypeable/financial-tracker/internal/domain/category"
+	"github.com/nontypeable/financial-tracker/internal/domain/importer"
+	"github.com/nontypeable/financial-tracker/internal/domain/transaction"
+	"github.com/nontypeable/financial-tracker/internal/domain/transfer"
+	apperror "github.com/nontypeable/financial-tracker/internal/errors"
+	"github.com/nontypeable/financial-tracker/internal/statement"
+	"github.com/nontypeable/financial-tracker/internal/statement/qif"
+	"github.com/shopspring/decimal"
+)
+
+// openingBalance is the payee Quicken gives the first entry of a register,
+// a transfer from the register to itself.
+const openingBalance = "Opening Balance"
+
+func (s *service) ImportQIF(ctx context.Context, userID uuid.UUID, params importer.QIFParams, file io.Reader) (*importer.Summary, error) {
+	parsed, err := qif.Parse(file, qif.Options{DateOrder: params.DateOrder, Encoding: params.Encoding})
+	if err != nil {
+		return nil, fmt.Errorf("parse qif file: %w", err)
+	}
+
+	if params.AccountID != nil {
+		if err := s.checkAccount(ctx, userID, *params.AccountID); err != nil {
+			return nil, err
+		}
+	}
+
+	var summary *importer.Summary
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		job, err := s.newQIFJob(ctx, userID)
+		if err != nil {
+			return err
+		}
+
+		if err := job.run(ctx, parsed, params.AccountID); err != nil {
+			return err
+		}
+
+		summary = job.summary
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return summary, nil
+}
+
+// qifJob holds the state of one QIF import. Accounts and categories are
+// matched by name, ignoring case, and created on first use.
+type qifJob struct {
+	s       *service
+	userID  uuid.UUID
+	summary *importer.Summary
+
+	accounts   map[string]uuid.UUID
+	matched    map[string]bool
+	categories map[categoryKey]*category.Category
+	// transfers counts the outgoing transfers posted so far. A transfer
+	// between two registers of the file is listed in both, and the
+	// incoming copy must not be posted again.
+	transfers map[transferKey]int
+}
+
+type categoryKey struct {
+	kind   category.CategoryType
+	parent uuid.UUID
+	name   string
+}
+
+type transferKey struct {
+	from   uuid.UUID
+	to     uuid.UUID
+	date   time.Time
+	amount string
+}
+
+type incomingTransfer struct {
+	from uuid.UUID
+	to   uuid.UUID
+	txn  qif.Transaction
+}
+
+func (s *service) newQIFJob(ctx context.Context, userID uuid.UUID) (*qifJob, error) {
+	job := &qifJob{
+		s:          s,
+		userID:     userID,
+		summary:    &importer.Summary{},
+		accounts:   make(map[string]uuid.UUID),
+		matched:    make(map[string]bool),
+		categories: make(map[categoryKey]*category.Category),
+		transfers:  make(map[transferKey]int),
+	}
+
+	accounts, err := s.accountRepository.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("list accounts: %w", err)
+	}
+	for _, a := range accounts {
+		job.accounts[strings.ToLower(a.Name)] = a.ID
+	}
+
+	// Archived categories are loaded too: their names are still taken.
+	categories, err := s.categoryRepository.GetByUserID(ctx, userID, true)
+	if err != nil {
+		return nil, fmt.Errorf("list categories: %w", err)
+	}
+	for _, c := range categories {
+		job.categories[newCategoryKey(c.Type, c.ParentID, c.Name)] = c
+	}
+
+	return job, nil
+}
+
+func (j *qifJob) run(ctx context.Context, file *qif.File, defaultAccountID *uuid.UUID) error {
+	j.summary.Skipped = append(j.summary.Skipped, file.Errors...)
+
+	// Every register is resolved first so transfers can name any of them.
+	registers := make(map[*qif.Account]uuid.UUID, len(file.Accounts))
+	for _, a := range file.Accounts {
+		if a.Name != "" {
+			id, err := j.account(ctx, a.Name)
+			if err != nil {
+				return err
+			}
+			registers[a] = id
+			continue
+		}
+
+		if len(a.Transactions) == 0 {
+			continue
+		}
+		if defaultAccountID == nil {
+			return fmt.Errorf("%w: the file has transactions outside a named account", apperror.ErrInvalidInput)
+		}
+		registers[a] = *defaultAccountID
+	}
+
+	var incoming []incomingTransfer
+	for _, a := range file.Accounts {
+		accountID, ok := registers[a]
+		if !ok {
+			continue
+		}
+
+		for _, txn := range a.Transactions {
+			if txn.Transfer == "" || len(txn.Splits) > 0 || isOpeningBalance(a, txn) {
+				if err := j.transaction(ctx, accountID, txn); err != nil {
+					return err
+				}
+				continue
+			}
+
+			otherID, err := j.account(ctx, txn.Transfer)
+			if err != nil {
+				return err
+			}
+
+			if txn.Amount.IsNegative() {
+				if err := j.transfer(ctx, accountID, otherID, txn); err != nil {
+					return err
+				}
+				continue
+			}
+			incoming = append(incoming, incomingTransfer{from: otherID, to: accountID, txn: txn})
+		}
+	}
+
+	for _, in := range incoming {
+		key := newTransferKey(in.from, in.to, in.txn)
+		if j.transfers[key] > 0 {
+			j.transfers[key]--
+			continue
+		}
+
+		if err := j.transfer(ctx, in.from, in.to, in.txn); err != nil {
+			return err
+		}
+	}
+
+	sort.SliceStable(j.summary.Skipped, func(a, b int) bool {
+		return j.summary.Skipped[a].Line < j.summary.Skipped[b].Line
+	})
+
+	return nil
+}
+
+func (j *qifJob) transaction(ctx context.Context, accountID uuid.UUID, txn qif.Transaction) error {
+	kind := transaction.Income
+	categoryType := category.Income
+	if txn.Amount.IsNegative() {
+		kind = transaction.Expense
+		categoryType = category.Expense
+	}
+
+	params := transaction.CreateParams{
+		AccountID:   accountID,
+		Amount:      txn.Amount.Abs(),
+		Type:        kind,
+		Description: statement.Describe(txn.Payee, txn.Memo),
+		OccurredAt:  &txn.Date,
+		Status:      status(txn),
+	}
+
+	if txn.Category != "" && len(txn.Splits) == 0 {
+		id, err := j.category(ctx, txn.Category, categoryType)
+		if err != nil {
+			return err
+		}
+		params.CategoryID = id
+	}
+
+	for _, split := range txn.Splits {
+		if split.Amount.Sign() != txn.Amount.Sign() {
+			j.skip(txn.Line, "split amounts must have the same sign as the transaction")
+			return nil
+		}
+
+		line := transaction.Split{Amount: split.Amount.Abs(), Memo: split.Memo}
+		switch {
+		case split.Transfer != "":
+			// A split cannot move money to another account; the line is
+			// kept uncategorized with the account named in the memo.
+			line.Memo = strings.TrimSpace("[" + split.Transfer + "] " + split.Memo)
+		case split.Category != "":
+			id, err := j.category(ctx, split.Category, categoryType)
+			if err != nil {
+				return err
+			}
+			line.CategoryID = id
+		}
+
+		params.Splits = append(params.Splits, line)
+	}
+
+	if _, err := j.s.transactionService.Create(ctx, j.userID, params); err != nil {
+		// These are checked before anything is written, so the database
+		// transaction can go on with the next record.
+		if errors.Is(err, apperror.ErrSplitsMismatch) || errors.Is(err, apperror.ErrCategoryTypeMismatch) {
+			j.skip(txn.Line, err.Error())
+			return nil
+		}
+		return fmt.Errorf("import line %d: %w", txn.Line, err)
+	}
+
+	j.summary.Transactions++
+	return nil
+}
+
+func (j *qifJob) transfer(ctx context.Context, fromID, toID uuid.UUID, txn qif.Transaction) error {
+	_, err := j.s.transferService.Create(ctx, j.userID, transfer.CreateParams{
+		FromAccountID: fromID,
+		ToAccountID:   toID,
+		Amount:        txn.Amount.Abs(),
+		Description:   statement.Describe(txn.Payee, txn.Memo),
+		OccurredAt:    &txn.Date,
+		Status:        status(txn),
+	})
+	if err != nil {
+		return fmt.Errorf("import line %d: %w", txn.Line, err)
+	}
+
+	j.transfers[newTransferKey(fromID, toID, txn)]++
+	j.summary.Transfers++
+	return nil
+}
+
+func (j *qifJob) account(ctx context.Context, name string) (uuid.UUID, error) {
+	key := strings.ToLower(name)
+	if id, ok := j.accounts[key]; ok {
+		if !j.matched[key] {
+			j.matched[key] = true
+			j.summary.AccountsMatched = append(j.summary.AccountsMatched, name)
+		}
+		return id, nil
+	}
+
+	id, err := j.s.accountRepository.Create(ctx, account.NewAccount(j.userID, name, account.Asset, decimal.Zero))
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("create account %q: %w", name, err)
+	}
+
+	j.accounts[key] = id
+	j.matched[key] = true
+	j.summary.AccountsCreated = append(j.summary.AccountsCreated, name)
+	return id, nil
+}
+
+// category resolves a path such as "Food:Groceries", creating missing
+// levels. An archived category leaves the transaction uncategorized.
+func (j *qifJob) category(ctx context.Context, path string, kind category.CategoryType) (*uuid.UUID, error) {
+	var (
+		current *category.Category
+		names   []string
+	)
+
+	for _, name := range strings.Split(path, ":") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		names = append(names, name)
+
+		var parentID *uuid.UUID
+		if current != nil {
+			parentID = &current.ID
+		}
+
+		key := newCategoryKey(kind, parentID, name)
+		if c, ok := j.categories[key]; ok {
+			current = c
+			continue
+		}
+
+		c := category.NewCategory(j.userID, parentID, name, kind)
+		id, err := j.s.categoryRepository.Create(ctx, c)
+		if err != nil {
+			return nil, fmt.Errorf("create category %q: %w", strings.Join(names, ":"), err)
+		}
+		c.ID = id
+
+		j.categories[key] = c
+		j.summary.CategoriesCreated = append(j.summary.CategoriesCreated, strings.Join(names, ":"))
+		current = c
+	}
+
+	if current == nil || current.IsArchived() {
+		return nil, nil
+	}
+
+	return &current.ID, nil
+}
+
+func (j *qifJob) skip(line int, message string) {
+	j.summary.Skipped = append(j.summary.Skipped, statement.RowError{Line: line, Message: message})
+}
+
+func isOpeningBalance(a *qif.Account, txn qif.Transaction) bool {
+	return strings.EqualFold(txn.Transfer, a.Name) || strings.EqualFold(txn.Payee, openingBalance)
+}
+
+func status(txn qif.Transaction) transaction.Status {
+	if txn.Cleared {
+		return transaction.Cleared
+	}
+	return transaction.Pending
+}
+
+func newCategoryKey(kind category.CategoryType, parentID *uuid.UUID, name string) categoryKey {
+	key := categoryKey{kind: kind, name: strings.ToLower(name)}
+	if parentID != nil {
+		key.parent = *parentID
+	}
+	return key
+}
+
+func newTransferKey(from, to uuid.UUID, txn qif.Transaction) transferKey {
+	return transferKey{from: from, to: to, date: txn.Date, amount: txn.Amount.Abs().String()}
+}
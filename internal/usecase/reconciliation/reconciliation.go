@@ -0,0 +1,243 @@
+package reconciliation
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nontypeable/financial-tracker/internal/domain/account"
+	"github.com/nontypeable/financial-tracker/internal/domain/reconciliation"
+	"github.com/nontypeable/financial-tracker/internal/domain/transaction"
+	apperror "github.com/nontypeable/financial-tracker/internal/errors"
+	"github.com/nontypeable/financial-tracker/internal/transactor"
+	"github.com/shopspring/decimal"
+)
+
+type service struct {
+	repository            reconciliation.Repository
+	accountRepository     account.Repository
+	transactionRepository transaction.Repository
+	transactor            transactor.Transactor
+}
+
+func NewService(repository reconciliation.Repository, accountRepository account.Repository, transactionRepository transaction.Repository, transactor transactor.Transactor) reconciliation.Service {
+	return &service{
+		repository:            repository,
+		accountRepository:     accountRepository,
+		transactionRepository: transactionRepository,
+		transactor:            transactor,
+	}
+}
+
+func (s *service) Start(ctx context.Context, userID, accountID uuid.UUID, statementDate time.Time, statementBalance decimal.Decimal) (uuid.UUID, error) {
+	if _, err := s.ownedAccount(ctx, userID, accountID); err != nil {
+		return uuid.Nil, err
+	}
+
+	reconciliation := reconciliation.NewReconciliation(accountID, statementDate, statementBalance)
+
+	id, err := s.repository.Create(ctx, reconciliation)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("create reconciliation: %w", err)
+	}
+
+	return id, nil
+}
+
+func (s *service) GetByID(ctx context.Context, userID, id uuid.UUID) (*reconciliation.Summary, error) {
+	reconciliation, err := s.repository.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("get reconciliation: %w", err)
+	}
+
+	account, err := s.ownedAccount(ctx, userID, reconciliation.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.summarize(ctx, reconciliation, account)
+}
+
+func (s *service) List(ctx context.Context, userID, accountID uuid.UUID) ([]*reconciliation.Reconciliation, error) {
+	if _, err := s.ownedAccount(ctx, userID, accountID); err != nil {
+		return nil, err
+	}
+
+	reconciliations, err := s.repository.GetByAccountID(ctx, accountID)
+	if err != nil {
+		return nil, fmt.Errorf("list reconciliations: %w", err)
+	}
+
+	return reconciliations, nil
+}
+
+func (s *service) Clear(ctx context.Context, userID, id uuid.UUID, transactionIDs []uuid.UUID, cleared bool) (*reconciliation.Summary, error) {
+	status := transaction.Pending
+	if cleared {
+		status = transaction.Cleared
+	}
+
+	ids := uniqueIDs(transactionIDs)
+
+	var summary *reconciliation.Summary
+	err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		reconciliation, account, err := s.lockOpen(ctx, userID, id)
+		if err != nil {
+			return err
+		}
+
+		if len(ids) > 0 {
+			n, err := s.transactionRepository.SetStatus(ctx, account.ID, ids, reconciliation.Cutoff(), status)
+			if err != nil {
+				return fmt.Errorf("set transaction status: %w", err)
+			}
+
+			// Every ID must be an unreconciled transaction of this account
+			// covered by the statement.
+			if n != int64(len(ids)) {
+				return apperror.ErrTransactionNotFound
+			}
+		}
+
+		summary, err = s.summarize(ctx, reconciliation, account)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return summary, nil
+}
+
+func (s *service) Complete(ctx context.Context, userID, id uuid.UUID) error {
+	return s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		reconciliation, account, err := s.lockOpen(ctx, userID, id)
+		if err != nil {
+			return err
+		}
+
+		cleared, err := s.clearedBalance(ctx, account, reconciliation.Cutoff())
+		if err != nil {
+			return err
+		}
+
+		if !cleared.Equal(reconciliation.StatementBalance) {
+			return apperror.ErrReconciliationUnbalanced
+		}
+
+		if _, err := s.transactionRepository.Reconcile(ctx, account.ID, reconciliation.Cutoff(), reconciliation.ID); err != nil {
+			return fmt.Errorf("reconcile transactions: %w", err)
+		}
+
+		reconciliation.Complete()
+
+		if err := s.repository.Update(ctx, reconciliation); err != nil {
+			return fmt.Errorf("complete reconciliation: %w", err)
+		}
+
+		return nil
+	})
+}
+
+func (s *service) Cancel(ctx context.Context, userID, id uuid.UUID) error {
+	return s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		reconciliation, _, err := s.lockOpen(ctx, userID, id)
+		if err != nil {
+			return err
+		}
+
+		if err := s.repository.Delete(ctx, reconciliation.ID); err != nil {
+			return fmt.Errorf("delete reconciliation: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// lockOpen locks the account and then the reconciliation. Transaction edits
+// lock the same account row, so they cannot interleave with ticking off or
+// completing a reconciliation.
+func (s *service) lockOpen(ctx context.Context, userID, id uuid.UUID) (*reconciliation.Reconciliation, *account.Account, error) {
+	reconciliation, err := s.repository.GetByID(ctx, id)
+	if err != nil {
+		return nil, nil, fmt.Errorf("get reconciliation: %w", err)
+	}
+
+	account, err := s.accountRepository.GetByIDForUpdate(ctx, reconciliation.AccountID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("lock account: %w", err)
+	}
+
+	if !account.BelongsUser(userID) {
+		return nil, nil, apperror.ErrForbidden
+	}
+
+	reconciliation, err = s.repository.GetByIDForUpdate(ctx, id)
+	if err != nil {
+		return nil, nil, fmt.Errorf("lock reconciliation: %w", err)
+	}
+
+	if reconciliation.IsCompleted() {
+		return nil, nil, apperror.ErrReconciliationCompleted
+	}
+
+	return reconciliation, account, nil
+}
+
+func (s *service) summarize(ctx context.Context, r *reconciliation.Reconciliation, account *account.Account) (*reconciliation.Summary, error) {
+	cleared, err := s.clearedBalance(ctx, account, r.Cutoff())
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &reconciliation.Summary{
+		Reconciliation: r,
+		ClearedBalance: cleared,
+		Difference:     r.StatementBalance.Sub(cleared),
+	}
+
+	if !r.IsCompleted() {
+		summary.Transactions, err = s.transactionRepository.GetUnreconciled(ctx, account.ID, r.Cutoff())
+		if err != nil {
+			return nil, fmt.Errorf("get unreconciled transactions: %w", err)
+		}
+	}
+
+	return summary, nil
+}
+
+func (s *service) clearedBalance(ctx context.Context, account *account.Account, before time.Time) (decimal.Decimal, error) {
+	sums, err := s.transactionRepository.SumClearedByAccountIDs(ctx, []uuid.UUID{account.ID}, &before)
+	if err != nil {
+		return decimal.Zero, fmt.Errorf("sum cleared transactions: %w", err)
+	}
+
+	return account.OpeningBalance.Add(sums[account.ID]), nil
+}
+
+func (s *service) ownedAccount(ctx context.Context, userID, accountID uuid.UUID) (*account.Account, error) {
+	account, err := s.accountRepository.GetByID(ctx, accountID)
+	if err != nil {
+		return nil, fmt.Errorf("get account: %w", err)
+	}
+
+	if !account.BelongsUser(userID) {
+		return nil, apperror.ErrForbidden
+	}
+
+	return account, nil
+}
+
+func uniqueIDs(ids []uuid.UUID) []uuid.UUID {
+	seen := make(map[uuid.UUID]struct{}, len(ids))
+	unique := make([]uuid.UUID, 0, len(ids))
+	for _, id := range ids {
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		unique = append(unique, id)
+	}
+	return unique
+}
@@ -0,0 +1,333 @@
+package recurring
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nontypeable/financial-tracker/internal/domain/account"
+	"github.com/nontypeable/financial-tracker/internal/domain/category"
+	"github.com/nontypeable/financial-tracker/internal/domain/recurring"
+	"github.com/nontypeable/financial-tracker/internal/domain/transaction"
+	apperror "github.com/nontypeable/financial-tracker/internal/errors"
+	"github.com/nontypeable/financial-tracker/internal/transactor"
+)
+
+type service struct {
+	repository         recurring.Repository
+	transactionService transaction.Service
+	accountRepository  account.Repository
+	categoryRepository category.Repository
+	transactor         transactor.Transactor
+}
+
+func NewService(repository recurring.Repository, transactionService transaction.Service, accountRepository account.Repository, categoryRepository category.Repository, transactor transactor.Transactor) recurring.Service {
+	return &service{
+		repository:         repository,
+		transactionService: transactionService,
+		accountRepository:  accountRepository,
+		categoryRepository: categoryRepository,
+		transactor:         transactor,
+	}
+}
+
+func (s *service) Create(ctx context.Context, userID uuid.UUID, params recurring.CreateParams) (uuid.UUID, error) {
+	if !params.Amount.IsPositive() || params.Type == transaction.Transfer || params.Interval < 0 {
+		return uuid.Nil, apperror.ErrInvalidInput
+	}
+
+	if params.EndDate != nil && recurring.Date(*params.EndDate).Before(recurring.Date(params.StartDate)) {
+		return uuid.Nil, apperror.ErrInvalidInput
+	}
+
+	account, err := s.accountRepository.GetByID(ctx, params.AccountID)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("get account: %w", err)
+	}
+
+	if !account.BelongsUser(userID) {
+		return uuid.Nil, apperror.ErrForbidden
+	}
+
+	if params.CategoryID != nil {
+		if err := s.checkCategory(ctx, userID, *params.CategoryID, params.Type); err != nil {
+			return uuid.Nil, err
+		}
+	}
+
+	rule := recurring.NewRule(userID, params)
+
+	id, err := s.repository.Create(ctx, rule)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("create recurring rule: %w", err)
+	}
+
+	return id, nil
+}
+
+func (s *service) GetByID(ctx context.Context, userID, id uuid.UUID) (*recurring.Rule, error) {
+	rule, err := s.repository.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("get recurring rule: %w", err)
+	}
+
+	if !rule.BelongsUser(userID) {
+		return nil, apperror.ErrForbidden
+	}
+
+	return rule, nil
+}
+
+func (s *service) List(ctx context.Context, userID uuid.UUID) ([]*recurring.Rule, error) {
+	rules, err := s.repository.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("list recurring rules: %w", err)
+	}
+
+	return rules, nil
+}
+
+func (s *service) Delete(ctx context.Context, userID, id uuid.UUID) error {
+	if err := s.repository.Delete(ctx, userID, id); err != nil {
+		return fmt.Errorf("delete recurring rule: %w", err)
+	}
+
+	return nil
+}
+
+func (s *service) Preview(ctx context.Context, userID, id uuid.UUID, limit int) ([]recurring.Occurrence, error) {
+	if limit <= 0 {
+		limit = recurring.DefaultPreviewLimit
+	}
+	if limit > recurring.MaxPreviewLimit {
+		limit = recurring.MaxPreviewLimit
+	}
+
+	rule, err := s.GetByID(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	skipped, err := s.skips(ctx, rule.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	dates := rule.Occurrences(rule.LastOccurrence, nil, limit)
+
+	occurrences := make([]recurring.Occurrence, 0, len(dates))
+	for _, date := range dates {
+		occurrences = append(occurrences, recurring.Occurrence{
+			Date:    date,
+			Skipped: skipped[date],
+		})
+	}
+
+	return occurrences, nil
+}
+
+func (s *service) Skip(ctx context.Context, userID, id uuid.UUID, date time.Time) error {
+	return s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		rule, err := s.lockOwnedRule(ctx, userID, id)
+		if err != nil {
+			return err
+		}
+
+		date = recurring.Date(date)
+		if !rule.IsScheduled(date) {
+			return apperror.ErrOccurrenceNotScheduled
+		}
+
+		if err := s.repository.AddSkip(ctx, rule.ID, date); err != nil {
+			return fmt.Errorf("skip occurrence: %w", err)
+		}
+
+		return nil
+	})
+}
+
+func (s *service) Pause(ctx context.Context, userID, id uuid.UUID) error {
+	return s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		rule, err := s.lockOwnedRule(ctx, userID, id)
+		if err != nil {
+			return err
+		}
+
+		if rule.IsPaused() {
+			return nil
+		}
+
+		rule.Pause()
+
+		if err := s.repository.Update(ctx, rule); err != nil {
+			return fmt.Errorf("pause recurring rule: %w", err)
+		}
+
+		return nil
+	})
+}
+
+func (s *service) Resume(ctx context.Context, userID, id uuid.UUID) error {
+	return s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		rule, err := s.lockOwnedRule(ctx, userID, id)
+		if err != nil {
+			return err
+		}
+
+		if !rule.IsPaused() {
+			return nil
+		}
+
+		rule.Resume(time.Now())
+
+		if err := s.repository.Update(ctx, rule); err != nil {
+			return fmt.Errorf("resume recurring rule: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// MaterializeDue handles each due rule in its own database transaction so a
+// failing rule does not hold back the others. The rule row lock serializes
+// concurrent workers, and the unique (recurring_rule_id, occurrence_date)
+// index guarantees an occurrence is never posted twice.
+func (s *service) MaterializeDue(ctx context.Context, today time.Time) (int, error) {
+	today = recurring.Date(today)
+
+	ids, err := s.repository.ListDue(ctx, today)
+	if err != nil {
+		return 0, fmt.Errorf("list due recurring rules: %w", err)
+	}
+
+	var posted int
+	var errs []error
+	for _, id := range ids {
+		n, err := s.materialize(ctx, id, today)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("materialize recurring rule %s: %w", id, err))
+			continue
+		}
+		posted += n
+	}
+
+	return posted, errors.Join(errs...)
+}
+
+func (s *service) materialize(ctx context.Context, id uuid.UUID, today time.Time) (int, error) {
+	var posted int
+	err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		posted = 0
+
+		rule, err := s.repository.GetByIDForUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		// Another worker may have handled the rule while we waited for the lock.
+		if rule.IsPaused() || rule.NextOccurrence == nil || rule.NextOccurrence.After(today) {
+			return nil
+		}
+
+		skipped, err := s.skips(ctx, rule.ID)
+		if err != nil {
+			return err
+		}
+
+		for _, date := range rule.Occurrences(rule.LastOccurrence, &today, 0) {
+			if !skipped[date] {
+				_, err := s.transactionService.Create(ctx, rule.UserID, transaction.CreateParams{
+					AccountID:       rule.AccountID,
+					Amount:          rule.Amount,
+					Type:            rule.Type,
+					Description:     rule.Description,
+					CategoryID:      rule.CategoryID,
+					RecurringRuleID: &rule.ID,
+					OccurredAt:      &date,
+					OccurrenceDate:  &date,
+				})
+				switch {
+				case errors.Is(err, apperror.ErrAccountNotFound),
+					errors.Is(err, apperror.ErrForbidden),
+					errors.Is(err, apperror.ErrCategoryNotFound),
+					errors.Is(err, apperror.ErrCategoryArchived),
+					errors.Is(err, apperror.ErrCategoryTypeMismatch):
+					// The template no longer fits the user's data; pause the rule
+					// instead of failing on every run.
+					rule.Pause()
+					return s.repository.Update(ctx, rule)
+				case errors.Is(err, apperror.ErrTransactionLocked):
+					// The date lies in a period already reconciled against the
+					// bank, which certified the balance without it.
+				case err != nil:
+					return err
+				default:
+					posted++
+				}
+			}
+
+			rule.Advance(date)
+		}
+
+		if err := s.repository.Update(ctx, rule); err != nil {
+			return fmt.Errorf("advance recurring rule: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return posted, nil
+}
+
+func (s *service) lockOwnedRule(ctx context.Context, userID, id uuid.UUID) (*recurring.Rule, error) {
+	rule, err := s.repository.GetByIDForUpdate(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("lock recurring rule: %w", err)
+	}
+
+	if !rule.BelongsUser(userID) {
+		return nil, apperror.ErrForbidden
+	}
+
+	return rule, nil
+}
+
+func (s *service) skips(ctx context.Context, ruleID uuid.UUID) (map[time.Time]bool, error) {
+	dates, err := s.repository.GetSkips(ctx, ruleID)
+	if err != nil {
+		return nil, fmt.Errorf("get skipped occurrences: %w", err)
+	}
+
+	skipped := make(map[time.Time]bool, len(dates))
+	for _, date := range dates {
+		skipped[recurring.Date(date)] = true
+	}
+
+	return skipped, nil
+}
+
+func (s *service) checkCategory(ctx context.Context, userID, categoryID uuid.UUID, transactionType transaction.TransactionType) error {
+	category, err := s.categoryRepository.GetByID(ctx, categoryID)
+	if err != nil {
+		return fmt.Errorf("get category: %w", err)
+	}
+
+	if !category.BelongsUser(userID) {
+		return apperror.ErrForbidden
+	}
+
+	if category.IsArchived() {
+		return apperror.ErrCategoryArchived
+	}
+
+	if string(category.Type) != string(transactionType) {
+		return apperror.ErrCategoryTypeMismatch
+	}
+
+	return nil
+}
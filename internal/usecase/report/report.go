@@ -0,0 +1,149 @@
+package report
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/nontypeable/financial-tracker/internal/domain/account"
+	"github.com/nontypeable/financial-tracker/internal/domain/report"
+	"github.com/nontypeable/financial-tracker/internal/domain/transaction"
+	apperror "github.com/nontypeable/financial-tracker/internal/errors"
+)
+
+// MaxPeriods caps how many periods one report may span, so a daily report
+// over decades cannot produce an unbounded response.
+const MaxPeriods = 1000
+
+type service struct {
+	transactionRepository transaction.Repository
+	accountRepository     account.Repository
+}
+
+func NewService(transactionRepository transaction.Repository, accountRepository account.Repository) report.Service {
+	return &service{
+		transactionRepository: transactionRepository,
+		accountRepository:     accountRepository,
+	}
+}
+
+func (s *service) CashFlow(ctx context.Context, userID uuid.UUID, params report.CashFlowParams) (*transaction.CashFlow, error) {
+	filter := params.Filter
+	filter.UserID = userID
+
+	if filter.From == nil || filter.To == nil || !filter.From.Before(*filter.To) {
+		return nil, apperror.ErrInvalidInput
+	}
+
+	if periods(params.Interval, filter.To.Sub(*filter.From).Hours()/24) > MaxPeriods {
+		return nil, apperror.ErrInvalidInput
+	}
+
+	cashFlow, err := s.transactionRepository.CashFlow(ctx, &filter, params.Interval)
+	if err != nil {
+		return nil, fmt.Errorf("get cash flow: %w", err)
+	}
+
+	return cashFlow, nil
+}
+
+func (s *service) Breakdown(ctx context.Context, userID uuid.UUID, params report.BreakdownParams) (*report.Breakdown, error) {
+	filter := params.Filter
+	filter.UserID = userID
+
+	if filter.From == nil || filter.To == nil || !filter.From.Before(*filter.To) {
+		return nil, apperror.ErrInvalidInput
+	}
+
+	transactionType := transaction.Expense
+	if filter.Type != nil {
+		transactionType = *filter.Type
+	}
+	if transactionType == transaction.Transfer {
+		return nil, apperror.ErrInvalidInput
+	}
+	filter.Type = &transactionType
+
+	top := params.Top
+	if top <= 0 {
+		top = report.DefaultTop
+	}
+	top = min(top, report.MaxTop)
+
+	previousFrom := report.PreviousPeriod(*filter.From, *filter.To)
+
+	categories, err := s.transactionRepository.CategoryBreakdown(ctx, &filter, previousFrom)
+	if err != nil {
+		return nil, fmt.Errorf("get category breakdown: %w", err)
+	}
+
+	descriptions, err := s.transactionRepository.TopDescriptions(ctx, &filter, previousFrom, top)
+	if err != nil {
+		return nil, fmt.Errorf("get top descriptions: %w", err)
+	}
+
+	breakdown := &report.Breakdown{
+		Type:         transactionType,
+		From:         *filter.From,
+		To:           *filter.To,
+		PreviousFrom: previousFrom,
+		Total:        report.Compare(categories.Current, categories.Previous, categories.Current),
+		Categories:   make([]report.CategoryShare, 0, len(categories.Categories)),
+		Descriptions: make([]report.DescriptionShare, 0, len(descriptions)),
+	}
+
+	for _, c := range categories.Categories {
+		breakdown.Categories = append(breakdown.Categories, report.CategoryShare{
+			CategoryID: c.CategoryID,
+			ParentID:   c.ParentID,
+			Name:       c.Name,
+			Comparison: report.Compare(c.Current, c.Previous, categories.Current),
+		})
+	}
+
+	for _, d := range descriptions {
+		breakdown.Descriptions = append(breakdown.Descriptions, report.DescriptionShare{
+			Description: d.Description,
+			Count:       d.Count,
+			Comparison:  report.Compare(d.Current, d.Previous, categories.Current),
+		})
+	}
+
+	return breakdown, nil
+}
+
+func (s *service) NetWorth(ctx context.Context, userID uuid.UUID, params report.NetWorthParams) (*report.NetWorth, error) {
+	if !params.From.Before(params.To) {
+		return nil, apperror.ErrInvalidInput
+	}
+
+	if periods(params.Interval, params.To.Sub(params.From).Hours()/24) > MaxPeriods {
+		return nil, apperror.ErrInvalidInput
+	}
+
+	accounts, err := s.accountRepository.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("get accounts: %w", err)
+	}
+
+	balances, err := s.transactionRepository.BalanceHistory(ctx, userID, params.AccountIDs, params.From, params.To, params.Interval)
+	if err != nil {
+		return nil, fmt.Errorf("get balance history: %w", err)
+	}
+
+	return report.SumNetWorth(params.Interval, accounts, balances), nil
+}
+
+// periods estimates how many periods of the interval a range of days spans.
+func periods(interval transaction.Interval, days float64) float64 {
+	switch interval {
+	case transaction.Weekly:
+		return days / 7
+	case transaction.Monthly:
+		return days / 28
+	case transaction.Yearly:
+		return days / 365
+	default:
+		return days
+	}
+}
@@ -0,0 +1,98 @@
+package tag
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/nontypeable/financial-tracker/internal/domain/tag"
+	apperror "github.com/nontypeable/financial-tracker/internal/errors"
+	"github.com/nontypeable/financial-tracker/internal/transactor"
+)
+
+type service struct {
+	repository tag.Repository
+	transactor transactor.Transactor
+}
+
+func NewService(repository tag.Repository, transactor transactor.Transactor) tag.Service {
+	return &service{
+		repository: repository,
+		transactor: transactor,
+	}
+}
+
+func (s *service) List(ctx context.Context, userID uuid.UUID) ([]*tag.Tag, error) {
+	tags, err := s.repository.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("list tags: %w", err)
+	}
+
+	return tags, nil
+}
+
+func (s *service) Rename(ctx context.Context, userID, id uuid.UUID, name string) error {
+	t, err := s.owned(ctx, userID, id)
+	if err != nil {
+		return err
+	}
+
+	name = tag.NormalizeName(name)
+	if name == "" {
+		return apperror.ErrInvalidInput
+	}
+
+	if name == t.Name {
+		return nil
+	}
+
+	t.Name = name
+
+	if err := s.repository.Update(ctx, t); err != nil {
+		return fmt.Errorf("rename tag: %w", err)
+	}
+
+	return nil
+}
+
+func (s *service) Merge(ctx context.Context, userID, fromID, intoID uuid.UUID) error {
+	if fromID == intoID {
+		return apperror.ErrInvalidInput
+	}
+
+	if _, err := s.owned(ctx, userID, fromID); err != nil {
+		return err
+	}
+
+	if _, err := s.owned(ctx, userID, intoID); err != nil {
+		return err
+	}
+
+	return s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repository.Merge(ctx, fromID, intoID); err != nil {
+			return fmt.Errorf("merge tags: %w", err)
+		}
+		return nil
+	})
+}
+
+func (s *service) Delete(ctx context.Context, userID, id uuid.UUID) error {
+	if err := s.repository.Delete(ctx, userID, id); err != nil {
+		return fmt.Errorf("delete tag: %w", err)
+	}
+
+	return nil
+}
+
+func (s *service) owned(ctx context.Context, userID, id uuid.UUID) (*tag.Tag, error) {
+	t, err := s.repository.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("get tag: %w", err)
+	}
+
+	if !t.BelongsUser(userID) {
+		return nil, apperror.ErrForbidden
+	}
+
+	return t, nil
+}
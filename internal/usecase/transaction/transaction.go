@@ -3,29 +3,717 @@ package transaction
 import (
 	"context"
 	"fmt"
+	"sort"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/nontypeable/financial-tracker/internal/domain/account"
+	"github.com/nontypeable/financial-tracker/internal/domain/category"
+	"github.com/nontypeable/financial-tracker/internal/domain/reconciliation"
+	"github.com/nontypeable/financial-tracker/internal/domain/tag"
 	"github.com/nontypeable/financial-tracker/internal/domain/transaction"
+	apperror "github.com/nontypeable/financial-tracker/internal/errors"
+	"github.com/nontypeable/financial-tracker/internal/transactor"
 	"github.com/shopspring/decimal"
 )
 
 type service struct {
-	repository transaction.Repository
+	repository               transaction.Repository
+	accountRepository        account.Repository
+	categoryRepository       category.Repository
+	tagRepository            tag.Repository
+	reconciliationRepository reconciliation.Repository
+	transactor               transactor.Transactor
 }
 
-func NewService(repository transaction.Repository) transaction.Service {
+func NewService(repository transaction.Repository, accountRepository account.Repository, categoryRepository category.Repository, tagRepository tag.Repository, reconciliationRepository reconciliation.Repository, transactor transactor.Transactor) transaction.Service {
 	return &service{
-		repository: repository,
+		repository:               repository,
+		accountRepository:        accountRepository,
+		categoryRepository:       categoryRepository,
+		tagRepository:            tagRepository,
+		reconciliationRepository: reconciliationRepository,
+		transactor:               transactor,
 	}
 }
 
-func (s *service) Create(ctx context.Context, accountID uuid.UUID, amount decimal.Decimal, transactionType transaction.TransactionType, description string) (uuid.UUID, error) {
-	transaction := transaction.NewTransaction(accountID, amount, transactionType, description)
+func (s *service) Create(ctx context.Context, userID uuid.UUID, params transaction.CreateParams) (uuid.UUID, error) {
+	if !params.Amount.IsPositive() || params.Type == transaction.Transfer || params.Status == transaction.Reconciled {
+		return uuid.Nil, apperror.ErrInvalidInput
+	}
+
+	if params.CategoryID != nil {
+		if err := s.checkCategory(ctx, userID, *params.CategoryID, params.Type); err != nil {
+			return uuid.Nil, err
+		}
+	}
+
+	transaction := transaction.NewTransaction(params.AccountID, params.Amount, params.Type, params.Description, params.CategoryID)
+	transaction.Splits = params.Splits
+	if params.OccurredAt != nil {
+		transaction.OccurredAt = *params.OccurredAt
+	}
+	if params.Status != "" {
+		transaction.Status = params.Status
+	}
+	transaction.RecurringRuleID = params.RecurringRuleID
+	transaction.OccurrenceDate = params.OccurrenceDate
+	transaction.ExternalID = params.ExternalID
+
+	if err := s.checkSplits(ctx, userID, transaction); err != nil {
+		return uuid.Nil, err
+	}
+
+	var id uuid.UUID
+	err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		accounts, err := s.lockOwnedAccounts(ctx, userID, params.AccountID)
+		if err != nil {
+			return err
+		}
+
+		if err := s.checkUncertified(ctx, transaction); err != nil {
+			return err
+		}
+
+		id, err = s.repository.Create(ctx, transaction)
+		if err != nil {
+			return fmt.Errorf("create transaction: %w", err)
+		}
 
-	id, err := s.repository.Create(ctx, transaction)
+		if len(transaction.Splits) > 0 {
+			if err := s.repository.ReplaceSplits(ctx, id, transaction.Splits); err != nil {
+				return fmt.Errorf("create splits: %w", err)
+			}
+		}
+
+		if err := s.attachTags(ctx, userID, []uuid.UUID{id}, params.Tags); err != nil {
+			return err
+		}
+
+		return s.applyDeltas(ctx, accounts, map[uuid.UUID]decimal.Decimal{
+			params.AccountID: transaction.SignedAmount(),
+		})
+	})
 	if err != nil {
-		return uuid.Nil, fmt.Errorf("create transaction: %w", err)
+		return uuid.Nil, err
 	}
 
 	return id, nil
 }
+
+func (s *service) GetByID(ctx context.Context, userID, id uuid.UUID) (*transaction.Transaction, error) {
+	transaction, err := s.repository.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("get transaction: %w", err)
+	}
+
+	if _, err := s.ownedAccount(ctx, userID, transaction.AccountID); err != nil {
+		return nil, err
+	}
+
+	return transaction, nil
+}
+
+func (s *service) List(ctx context.Context, userID uuid.UUID, filter transaction.Filter) ([]*transaction.Transaction, *transaction.Cursor, error) {
+	filter.UserID = userID
+	filter.Normalize()
+
+	transactions, err := s.repository.List(ctx, &filter)
+	if err != nil {
+		return nil, nil, fmt.Errorf("list transactions: %w", err)
+	}
+
+	var next *transaction.Cursor
+	if len(transactions) > filter.Limit {
+		transactions = transactions[:filter.Limit]
+		next = transaction.NewCursor(transactions[len(transactions)-1], filter.SortBy)
+	}
+
+	return transactions, next, nil
+}
+
+func (s *service) Update(ctx context.Context, userID, id uuid.UUID, params transaction.UpdateParams) error {
+	if params.Amount != nil && !params.Amount.IsPositive() {
+		return apperror.ErrInvalidInput
+	}
+
+	if params.Status != nil && *params.Status == transaction.Reconciled {
+		return apperror.ErrInvalidInput
+	}
+
+	return s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		legs, err := s.lockLegs(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		accounts, err := s.lockOwnedAccounts(ctx, userID, accountIDs(legs)...)
+		if err != nil {
+			return err
+		}
+
+		before := signedAmounts(legs)
+
+		// Changing when, how much or whether a transaction counts moves the
+		// balance both where it was and where it ends up.
+		moves := params.OccurredAt != nil || params.Amount != nil || params.Status != nil || params.Type != nil
+
+		for _, leg := range legs {
+			if leg.DeletedAt != nil {
+				return apperror.ErrTransactionNotFound
+			}
+
+			if leg.IsReconciled() {
+				return apperror.ErrTransactionLocked
+			}
+
+			if moves {
+				if err := s.checkUncertified(ctx, leg); err != nil {
+					return err
+				}
+			}
+		}
+
+		for _, leg := range legs {
+			if params.Status != nil && leg.ID == id {
+				leg.Status = *params.Status
+			}
+
+			if params.Type != nil && *params.Type != leg.Type {
+				if leg.IsTransfer() || *params.Type == transaction.Transfer {
+					return apperror.ErrInvalidInput
+				}
+				leg.Type = *params.Type
+			}
+
+			if params.Amount != nil {
+				if leg.Amount.IsNegative() {
+					leg.Amount = params.Amount.Neg()
+				} else {
+					leg.Amount = *params.Amount
+				}
+			}
+
+			if params.Description != nil {
+				leg.Description = *params.Description
+			}
+
+			if params.OccurredAt != nil {
+				leg.OccurredAt = *params.OccurredAt
+			}
+
+			if params.Splits != nil {
+				if leg.IsTransfer() {
+					return apperror.ErrInvalidInput
+				}
+
+				leg.Splits = *params.Splits
+				if len(leg.Splits) > 0 && params.CategoryID == nil {
+					leg.CategoryID = nil
+				}
+			}
+
+			if err := s.applyCategory(ctx, userID, leg, params); err != nil {
+				return err
+			}
+
+			if params.Splits != nil || params.Amount != nil || params.Type != nil {
+				if err := s.checkSplits(ctx, userID, leg); err != nil {
+					return err
+				}
+			}
+
+			if moves {
+				if err := s.checkUncertified(ctx, leg); err != nil {
+					return err
+				}
+			}
+
+			if err := s.repository.Update(ctx, leg); err != nil {
+				return fmt.Errorf("update transaction: %w", err)
+			}
+
+			if params.Splits != nil {
+				if err := s.repository.ReplaceSplits(ctx, leg.ID, leg.Splits); err != nil {
+					return fmt.Errorf("replace splits: %w", err)
+				}
+			}
+		}
+
+		return s.applyDeltas(ctx, accounts, diff(before, signedAmounts(legs)))
+	})
+}
+
+func (s *service) Delete(ctx context.Context, userID, id uuid.UUID) error {
+	return s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		legs, err := s.lockLegs(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		accounts, err := s.lockOwnedAccounts(ctx, userID, accountIDs(legs)...)
+		if err != nil {
+			return err
+		}
+
+		deltas := make(map[uuid.UUID]decimal.Decimal, len(legs))
+		for _, leg := range legs {
+			if leg.DeletedAt != nil {
+				return apperror.ErrTransactionNotFound
+			}
+
+			if leg.IsReconciled() {
+				return apperror.ErrTransactionLocked
+			}
+		}
+
+		for _, leg := range legs {
+			if err := s.repository.Delete(ctx, leg.AccountID, leg.ID); err != nil {
+				return fmt.Errorf("delete transaction: %w", err)
+			}
+
+			deltas[leg.AccountID] = deltas[leg.AccountID].Sub(leg.SignedAmount())
+		}
+
+		return s.applyDeltas(ctx, accounts, deltas)
+	})
+}
+
+func (s *service) Restore(ctx context.Context, userID, id uuid.UUID) error {
+	return s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		deleted, err := s.repository.GetDeletedByIDForUpdate(ctx, id)
+		if err != nil {
+			return fmt.Errorf("get deleted transaction: %w", err)
+		}
+
+		legs := []*transaction.Transaction{deleted}
+		if deleted.TransferID != nil {
+			legs, err = s.repository.GetByTransferIDForUpdate(ctx, *deleted.TransferID)
+			if err != nil {
+				return fmt.Errorf("get transfer legs: %w", err)
+			}
+		}
+
+		accounts, err := s.lockOwnedAccounts(ctx, userID, accountIDs(legs)...)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		deltas := make(map[uuid.UUID]decimal.Decimal, len(legs))
+		for _, leg := range legs {
+			if !leg.Restorable(now) {
+				return apperror.ErrTransactionNotRestorable
+			}
+
+			if err := s.checkUncertified(ctx, leg); err != nil {
+				return err
+			}
+
+			if err := s.repository.Restore(ctx, leg.AccountID, leg.ID); err != nil {
+				return fmt.Errorf("restore transaction: %w", err)
+			}
+
+			deltas[leg.AccountID] = deltas[leg.AccountID].Add(leg.SignedAmount())
+		}
+
+		return s.applyDeltas(ctx, accounts, deltas)
+	})
+}
+
+// checkUncertified rejects a transaction dated within a completed
+// reconciliation of its account: adding, moving or bringing it back would
+// change the balance that reconciliation certified.
+func (s *service) checkUncertified(ctx context.Context, t *transaction.Transaction) error {
+	reconciliations, err := s.reconciliationRepository.GetByAccountID(ctx, t.AccountID)
+	if err != nil {
+		return fmt.Errorf("get reconciliations: %w", err)
+	}
+
+	for _, r := range reconciliations {
+		if r.Certifies(t) {
+			return apperror.ErrTransactionLocked
+		}
+	}
+
+	return nil
+}
+
+func (s *service) ListDeleted(ctx context.Context, userID uuid.UUID) ([]*transaction.Transaction, error) {
+	transactions, err := s.repository.ListDeleted(ctx, userID, time.Now().Add(-transaction.TrashRetention))
+	if err != nil {
+		return nil, fmt.Errorf("list deleted transactions: %w", err)
+	}
+
+	return transactions, nil
+}
+
+func (s *service) AddTags(ctx context.Context, userID, id uuid.UUID, names []string) error {
+	if _, err := s.GetByID(ctx, userID, id); err != nil {
+		return err
+	}
+
+	return s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		return s.attachTags(ctx, userID, []uuid.UUID{id}, names)
+	})
+}
+
+func (s *service) RemoveTag(ctx context.Context, userID, id, tagID uuid.UUID) error {
+	if _, err := s.GetByID(ctx, userID, id); err != nil {
+		return err
+	}
+
+	tag, err := s.tagRepository.GetByID(ctx, tagID)
+	if err != nil {
+		return fmt.Errorf("get tag: %w", err)
+	}
+
+	if !tag.BelongsUser(userID) {
+		return apperror.ErrForbidden
+	}
+
+	if err := s.repository.RemoveTags(ctx, id, []uuid.UUID{tagID}); err != nil {
+		return fmt.Errorf("remove tag: %w", err)
+	}
+
+	return nil
+}
+
+func (s *service) BulkTag(ctx context.Context, userID uuid.UUID, filter transaction.Filter, names []string) (int64, error) {
+	names = tag.NormalizeNames(names)
+	if len(names) == 0 {
+		return 0, apperror.ErrInvalidInput
+	}
+
+	filter.UserID = userID
+
+	var tagged int64
+	err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		tags, err := s.tagRepository.Ensure(ctx, userID, names)
+		if err != nil {
+			return fmt.Errorf("ensure tags: %w", err)
+		}
+
+		tagged, err = s.repository.AddTagsByFilter(ctx, &filter, tagIDs(tags))
+		if err != nil {
+			return fmt.Errorf("tag transactions: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return tagged, nil
+}
+
+func (s *service) ListDuplicates(ctx context.Context, userID uuid.UUID, filter transaction.DuplicateFilter) ([]*transaction.DuplicatePair, error) {
+	if filter.AccountID != nil {
+		if _, err := s.ownedAccount(ctx, userID, *filter.AccountID); err != nil {
+			return nil, err
+		}
+	}
+
+	filter.UserID = userID
+	if filter.From == nil {
+		from := time.Now().Add(-transaction.DuplicateLookback)
+		filter.From = &from
+	}
+
+	pairs, err := s.repository.FindDuplicates(ctx, &filter)
+	if err != nil {
+		return nil, fmt.Errorf("find duplicates: %w", err)
+	}
+
+	likely := make([]*transaction.DuplicatePair, 0, len(pairs))
+	for _, pair := range pairs {
+		pair.Score = transaction.DuplicateScore(pair.Original.Candidate(), pair.Duplicate.Candidate())
+		if pair.Score >= transaction.DuplicateThreshold {
+			likely = append(likely, pair)
+		}
+	}
+
+	sort.SliceStable(likely, func(i, j int) bool {
+		return likely[i].Score > likely[j].Score
+	})
+
+	return likely, nil
+}
+
+func (s *service) Merge(ctx context.Context, userID, id, duplicateID uuid.UUID) (*transaction.Transaction, error) {
+	if id == duplicateID {
+		return nil, apperror.ErrInvalidInput
+	}
+
+	var merged *transaction.Transaction
+	err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		// Both rows are locked in ID order so that merging a into b and b
+		// into a at the same time cannot deadlock.
+		ids := []uuid.UUID{id, duplicateID}
+		if ids[1].String() < ids[0].String() {
+			ids[0], ids[1] = ids[1], ids[0]
+		}
+
+		locked := make(map[uuid.UUID]*transaction.Transaction, len(ids))
+		for _, lockID := range ids {
+			t, err := s.repository.GetByIDForUpdate(ctx, lockID)
+			if err != nil {
+				return fmt.Errorf("lock transaction: %w", err)
+			}
+			locked[lockID] = t
+		}
+		kept, duplicate := locked[id], locked[duplicateID]
+
+		accounts, err := s.lockOwnedAccounts(ctx, userID, kept.AccountID, duplicate.AccountID)
+		if err != nil {
+			return err
+		}
+
+		if kept.IsTransfer() || duplicate.IsTransfer() || kept.AccountID != duplicate.AccountID ||
+			kept.Type != duplicate.Type || !kept.Amount.Equal(duplicate.Amount) {
+			return apperror.ErrTransactionsNotMergeable
+		}
+
+		if duplicate.IsReconciled() {
+			return apperror.ErrTransactionLocked
+		}
+
+		// A reconciled row keeps what it has; only its tags and external ID
+		// can still change.
+		hadSplits := len(kept.Splits) > 0
+		if !kept.IsReconciled() && kept.Absorb(duplicate) {
+			if err := s.repository.Update(ctx, kept); err != nil {
+				return fmt.Errorf("update transaction: %w", err)
+			}
+
+			if !hadSplits && len(kept.Splits) > 0 {
+				if err := s.repository.ReplaceSplits(ctx, kept.ID, kept.Splits); err != nil {
+					return fmt.Errorf("replace splits: %w", err)
+				}
+			}
+		}
+
+		if kept.ExternalID == nil && duplicate.ExternalID != nil {
+			if err := s.repository.SetExternalID(ctx, duplicate.ID, nil); err != nil {
+				return fmt.Errorf("clear external id: %w", err)
+			}
+
+			if err := s.repository.SetExternalID(ctx, kept.ID, duplicate.ExternalID); err != nil {
+				return fmt.Errorf("set external id: %w", err)
+			}
+		}
+
+		if err := s.attachTags(ctx, userID, []uuid.UUID{kept.ID}, duplicate.Tags); err != nil {
+			return err
+		}
+
+		if err := s.repository.Delete(ctx, duplicate.AccountID, duplicate.ID); err != nil {
+			return fmt.Errorf("delete transaction: %w", err)
+		}
+
+		if err := s.applyDeltas(ctx, accounts, map[uuid.UUID]decimal.Decimal{duplicate.AccountID: duplicate.SignedAmount().Neg()}); err != nil {
+			return err
+		}
+
+		merged, err = s.repository.GetByID(ctx, kept.ID)
+		if err != nil {
+			return fmt.Errorf("get transaction: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return merged, nil
+}
+
+func (s *service) attachTags(ctx context.Context, userID uuid.UUID, transactionIDs []uuid.UUID, names []string) error {
+	names = tag.NormalizeNames(names)
+	if len(names) == 0 {
+		return nil
+	}
+
+	tags, err := s.tagRepository.Ensure(ctx, userID, names)
+	if err != nil {
+		return fmt.Errorf("ensure tags: %w", err)
+	}
+
+	if err := s.repository.AddTags(ctx, transactionIDs, tagIDs(tags)); err != nil {
+		return fmt.Errorf("add tags: %w", err)
+	}
+
+	return nil
+}
+
+func (s *service) ownedAccount(ctx context.Context, userID, accountID uuid.UUID) (*account.Account, error) {
+	account, err := s.accountRepository.GetByID(ctx, accountID)
+	if err != nil {
+		return nil, fmt.Errorf("get account: %w", err)
+	}
+
+	if !account.BelongsUser(userID) {
+		return nil, apperror.ErrForbidden
+	}
+
+	return account, nil
+}
+
+// lockLegs locks the transaction and, for transfers, its counterpart leg.
+// Transfer legs are always locked through their shared transfer ID so that
+// concurrent edits of either leg take the row locks in the same order.
+func (s *service) lockLegs(ctx context.Context, id uuid.UUID) ([]*transaction.Transaction, error) {
+	t, err := s.repository.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("get transaction: %w", err)
+	}
+
+	if t.TransferID == nil {
+		t, err = s.repository.GetByIDForUpdate(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("lock transaction: %w", err)
+		}
+		return []*transaction.Transaction{t}, nil
+	}
+
+	legs, err := s.repository.GetByTransferIDForUpdate(ctx, *t.TransferID)
+	if err != nil {
+		return nil, fmt.Errorf("lock transfer legs: %w", err)
+	}
+
+	return legs, nil
+}
+
+func (s *service) lockOwnedAccounts(ctx context.Context, userID uuid.UUID, ids ...uuid.UUID) (map[uuid.UUID]*account.Account, error) {
+	locked, err := s.accountRepository.GetByIDsForUpdate(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("lock accounts: %w", err)
+	}
+
+	accounts := make(map[uuid.UUID]*account.Account, len(locked))
+	for _, a := range locked {
+		if !a.BelongsUser(userID) {
+			return nil, apperror.ErrForbidden
+		}
+		accounts[a.ID] = a
+	}
+
+	for _, id := range ids {
+		if _, ok := accounts[id]; !ok {
+			return nil, apperror.ErrAccountNotFound
+		}
+	}
+
+	return accounts, nil
+}
+
+func (s *service) applyDeltas(ctx context.Context, accounts map[uuid.UUID]*account.Account, deltas map[uuid.UUID]decimal.Decimal) error {
+	for accountID, delta := range deltas {
+		if delta.IsZero() {
+			continue
+		}
+
+		account := accounts[accountID]
+		account.Apply(delta)
+
+		if err := s.accountRepository.Update(ctx, account); err != nil {
+			return fmt.Errorf("update account balance: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (s *service) applyCategory(ctx context.Context, userID uuid.UUID, t *transaction.Transaction, params transaction.UpdateParams) error {
+	switch {
+	case params.ClearCategory:
+		t.CategoryID = nil
+	case params.CategoryID != nil:
+		t.CategoryID = params.CategoryID
+	case params.Type == nil:
+		return nil
+	}
+
+	if t.CategoryID == nil {
+		return nil
+	}
+
+	if t.IsTransfer() {
+		return apperror.ErrInvalidInput
+	}
+
+	return s.checkCategory(ctx, userID, *t.CategoryID, t.Type)
+}
+
+func (s *service) checkSplits(ctx context.Context, userID uuid.UUID, t *transaction.Transaction) error {
+	if err := t.ValidateSplits(); err != nil {
+		return err
+	}
+
+	for _, split := range t.Splits {
+		if split.CategoryID == nil {
+			continue
+		}
+
+		if err := s.checkCategory(ctx, userID, *split.CategoryID, t.Type); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *service) checkCategory(ctx context.Context, userID, categoryID uuid.UUID, transactionType transaction.TransactionType) error {
+	category, err := s.categoryRepository.GetByID(ctx, categoryID)
+	if err != nil {
+		return fmt.Errorf("get category: %w", err)
+	}
+
+	if !category.BelongsUser(userID) {
+		return apperror.ErrForbidden
+	}
+
+	if category.IsArchived() {
+		return apperror.ErrCategoryArchived
+	}
+
+	if string(category.Type) != string(transactionType) {
+		return apperror.ErrCategoryTypeMismatch
+	}
+
+	return nil
+}
+
+func tagIDs(tags []*tag.Tag) []uuid.UUID {
+	ids := make([]uuid.UUID, 0, len(tags))
+	for _, t := range tags {
+		ids = append(ids, t.ID)
+	}
+	return ids
+}
+
+func accountIDs(legs []*transaction.Transaction) []uuid.UUID {
+	ids := make([]uuid.UUID, 0, len(legs))
+	for _, leg := range legs {
+		ids = append(ids, leg.AccountID)
+	}
+	return ids
+}
+
+func signedAmounts(legs []*transaction.Transaction) map[uuid.UUID]decimal.Decimal {
+	sums := make(map[uuid.UUID]decimal.Decimal, len(legs))
+	for _, leg := range legs {
+		sums[leg.AccountID] = sums[leg.AccountID].Add(leg.SignedAmount())
+	}
+	return sums
+}
+
+func diff(before, after map[uuid.UUID]decimal.Decimal) map[uuid.UUID]decimal.Decimal {
+	deltas := make(map[uuid.UUID]decimal.Decimal, len(after))
+	for accountID, amount := range after {
+		deltas[accountID] = amount.Sub(before[accountID])
+	}
+	return deltas
+}
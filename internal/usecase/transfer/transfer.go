@@ -0,0 +1,145 @@
+package transfer
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/nontypeable/financial-tracker/internal/domain/account"
+	"github.com/nontypeable/financial-tracker/internal/domain/reconciliation"
+	"github.com/nontypeable/financial-tracker/internal/domain/transaction"
+	"github.com/nontypeable/financial-tracker/internal/domain/transfer"
+	apperror "github.com/nontypeable/financial-tracker/internal/errors"
+	"github.com/nontypeable/financial-tracker/internal/transactor"
+)
+
+type service struct {
+	transactionRepository    transaction.Repository
+	accountRepository        account.Repository
+	reconciliationRepository reconciliation.Repository
+	transactor               transactor.Transactor
+}
+
+func NewService(transactionRepository transaction.Repository, accountRepository account.Repository, reconciliationRepository reconciliation.Repository, transactor transactor.Transactor) transfer.Service {
+	return &service{
+		transactionRepository:    transactionRepository,
+		accountRepository:        accountRepository,
+		reconciliationRepository: reconciliationRepository,
+		transactor:               transactor,
+	}
+}
+
+func (s *service) Create(ctx context.Context, userID uuid.UUID, params transfer.CreateParams) (uuid.UUID, error) {
+	if !params.Amount.IsPositive() {
+		return uuid.Nil, apperror.ErrInvalidInput
+	}
+
+	if params.Status == transaction.Reconciled {
+		return uuid.Nil, apperror.ErrInvalidInput
+	}
+
+	fromAccountID, toAccountID := params.FromAccountID, params.ToAccountID
+	if fromAccountID == toAccountID {
+		return uuid.Nil, apperror.ErrTransferSameAccount
+	}
+
+	outgoing, incoming := transaction.NewTransferLegs(fromAccountID, toAccountID, params.Amount, params.Description)
+	for _, leg := range []*transaction.Transaction{outgoing, incoming} {
+		if params.OccurredAt != nil {
+			leg.OccurredAt = *params.OccurredAt
+		}
+		if params.Status != "" {
+			leg.Status = params.Status
+		}
+	}
+
+	err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		accounts, err := s.accountRepository.GetByIDsForUpdate(ctx, []uuid.UUID{fromAccountID, toAccountID})
+		if err != nil {
+			return fmt.Errorf("lock accounts: %w", err)
+		}
+
+		if len(accounts) != 2 {
+			return apperror.ErrAccountNotFound
+		}
+
+		for _, a := range accounts {
+			if !a.BelongsUser(userID) {
+				return apperror.ErrForbidden
+			}
+		}
+
+		for _, leg := range []*transaction.Transaction{outgoing, incoming} {
+			if err := s.checkUncertified(ctx, leg); err != nil {
+				return err
+			}
+		}
+
+		for _, leg := range []*transaction.Transaction{outgoing, incoming} {
+			if _, err := s.transactionRepository.Create(ctx, leg); err != nil {
+				return fmt.Errorf("create transfer leg: %w", err)
+			}
+		}
+
+		for _, a := range accounts {
+			if a.ID == fromAccountID {
+				a.Apply(outgoing.SignedAmount())
+			} else {
+				a.Apply(incoming.SignedAmount())
+			}
+
+			if err := s.accountRepository.Update(ctx, a); err != nil {
+				return fmt.Errorf("update account balance: %w", err)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	return *outgoing.TransferID, nil
+}
+
+func (s *service) GetByID(ctx context.Context, userID, id uuid.UUID) (*transfer.Transfer, error) {
+	legs, err := s.transactionRepository.GetByTransferID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("get transfer legs: %w", err)
+	}
+
+	transfer, ok := transfer.NewTransfer(legs)
+	if !ok {
+		return nil, apperror.ErrTransferNotFound
+	}
+
+	for _, leg := range legs {
+		account, err := s.accountRepository.GetByID(ctx, leg.AccountID)
+		if err != nil {
+			return nil, fmt.Errorf("get account: %w", err)
+		}
+
+		if !account.BelongsUser(userID) {
+			return nil, apperror.ErrForbidden
+		}
+	}
+
+	return transfer, nil
+}
+
+// checkUncertified rejects a leg dated within a completed reconciliation of
+// its account, whose certified balance the transfer would otherwise change.
+func (s *service) checkUncertified(ctx context.Context, leg *transaction.Transaction) error {
+	reconciliations, err := s.reconciliationRepository.GetByAccountID(ctx, leg.AccountID)
+	if err != nil {
+		return fmt.Errorf("get reconciliations: %w", err)
+	}
+
+	for _, r := range reconciliations {
+		if r.Certifies(leg) {
+			return apperror.ErrTransactionLocked
+		}
+	}
+
+	return nil
+}
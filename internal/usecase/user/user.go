@@ -7,20 +7,26 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/nontypeable/financial-tracker/internal/auth"
+	"github.com/nontypeable/financial-tracker/internal/domain/category"
 	"github.com/nontypeable/financial-tracker/internal/domain/user"
 	apperror "github.com/nontypeable/financial-tracker/internal/errors"
+	"github.com/nontypeable/financial-tracker/internal/transactor"
 	"golang.org/x/crypto/bcrypt"
 )
 
 type service struct {
-	repository   user.Repository
-	tokenManager auth.TokenManager
+	repository      user.Repository
+	categoryService category.Service
+	tokenManager    auth.TokenManager
+	transactor      transactor.Transactor
 }
 
-func NewService(repository user.Repository, tokenManager auth.TokenManager) user.Service {
+func NewService(repository user.Repository, categoryService category.Service, tokenManager auth.TokenManager, transactor transactor.Transactor) user.Service {
 	return &service{
-		repository:   repository,
-		tokenManager: tokenManager,
+		repository:      repository,
+		categoryService: categoryService,
+		tokenManager:    tokenManager,
+		transactor:      transactor,
 	}
 }
 
@@ -30,9 +36,21 @@ func (s *service) SignUp(ctx context.Context, email, password, firstName, lastNa
 		return "", "", fmt.Errorf("invalid user data: %w", err)
 	}
 
-	id, err := s.repository.Create(ctx, user)
+	var id uuid.UUID
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		id, err = s.repository.Create(ctx, user)
+		if err != nil {
+			return fmt.Errorf("create user: %w", err)
+		}
+
+		if err := s.categoryService.SeedDefaults(ctx, id); err != nil {
+			return fmt.Errorf("seed default categories: %w", err)
+		}
+
+		return nil
+	})
 	if err != nil {
-		return "", "", fmt.Errorf("create user: %w", err)
+		return "", "", err
 	}
 
 	accessToken, err := s.tokenManager.GenerateAccessToken(id)
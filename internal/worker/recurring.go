@@ -0,0 +1,58 @@
+package worker
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+const DefaultRecurringInterval = time.Minute
+
+type Materializer interface {
+	MaterializeDue(ctx context.Context, today time.Time) (int, error)
+}
+
+// RecurringWorker periodically posts due recurring transactions. Runs are
+// idempotent, so several instances may run side by side.
+type RecurringWorker struct {
+	materializer Materializer
+	interval     time.Duration
+}
+
+func NewRecurringWorker(materializer Materializer, interval time.Duration) *RecurringWorker {
+	if interval <= 0 {
+		interval = DefaultRecurringInterval
+	}
+
+	return &RecurringWorker{
+		materializer: materializer,
+		interval:     interval,
+	}
+}
+
+// Run blocks until ctx is cancelled.
+func (w *RecurringWorker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		w.runOnce(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (w *RecurringWorker) runOnce(ctx context.Context) {
+	posted, err := w.materializer.MaterializeDue(ctx, time.Now())
+	if err != nil {
+		log.Printf("recurring worker: %v", err)
+	}
+
+	if posted > 0 {
+		log.Printf("recurring worker: posted %d transactions", posted)
+	}
+}
@@ -0,0 +1,12 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE accounts
+    ADD COLUMN IF NOT EXISTS opening_balance DECIMAL(32,18) NOT NULL DEFAULT 0.000000000000000000;
+
+UPDATE accounts SET opening_balance = balance;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE accounts DROP COLUMN IF EXISTS opening_balance;
+-- +goose StatementEnd
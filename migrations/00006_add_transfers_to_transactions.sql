@@ -0,0 +1,28 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE transactions DROP CONSTRAINT IF EXISTS transactions_type_check;
+ALTER TABLE transactions
+    ADD CONSTRAINT transactions_type_check CHECK (type IN ('income', 'expense', 'transfer'));
+
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS transfer_id UUID NULL;
+
+ALTER TABLE transactions
+    ADD CONSTRAINT transactions_transfer_id_check CHECK ((type = 'transfer') = (transfer_id IS NOT NULL));
+
+CREATE INDEX IF NOT EXISTS idx_transactions_transfer_id
+    ON transactions (transfer_id)
+    WHERE transfer_id IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DELETE FROM transactions WHERE type = 'transfer';
+
+DROP INDEX IF EXISTS idx_transactions_transfer_id;
+ALTER TABLE transactions DROP CONSTRAINT IF EXISTS transactions_transfer_id_check;
+ALTER TABLE transactions DROP COLUMN IF EXISTS transfer_id;
+
+ALTER TABLE transactions DROP CONSTRAINT IF EXISTS transactions_type_check;
+ALTER TABLE transactions
+    ADD CONSTRAINT transactions_type_check CHECK (type IN ('income', 'expense'));
+-- +goose StatementEnd
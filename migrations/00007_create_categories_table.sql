@@ -0,0 +1,31 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS categories (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id),
+    parent_id UUID NULL REFERENCES categories(id),
+    name VARCHAR(100) NOT NULL,
+    type VARCHAR(20) NOT NULL CHECK (type IN ('income', 'expense')),
+    archived_at TIMESTAMP WITH TIME ZONE NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    deleted_at TIMESTAMP WITH TIME ZONE NULL
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_categories_user_id_parent_id_name
+    ON categories (user_id, type, COALESCE(parent_id, '00000000-0000-0000-0000-000000000000'), LOWER(name))
+    WHERE deleted_at IS NULL;
+
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS category_id UUID NULL REFERENCES categories(id);
+
+CREATE INDEX IF NOT EXISTS idx_transactions_category_id
+    ON transactions (category_id)
+    WHERE category_id IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_transactions_category_id;
+ALTER TABLE transactions DROP COLUMN IF EXISTS category_id;
+DROP TABLE IF EXISTS categories;
+-- +goose StatementEnd
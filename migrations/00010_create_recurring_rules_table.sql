@@ -0,0 +1,54 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS recurring_rules (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id),
+    account_id UUID NOT NULL REFERENCES accounts(id),
+    amount DECIMAL(32,18) NOT NULL CHECK (amount > 0),
+    type VARCHAR(20) NOT NULL CHECK (type IN ('income', 'expense')),
+    description TEXT,
+    category_id UUID NULL REFERENCES categories(id),
+    frequency VARCHAR(10) NOT NULL CHECK (frequency IN ('daily', 'weekly', 'monthly', 'yearly')),
+    interval_count INTEGER NOT NULL DEFAULT 1 CHECK (interval_count > 0),
+    by_month_day INTEGER NULL CHECK (by_month_day BETWEEN 1 AND 31),
+    start_date DATE NOT NULL,
+    end_date DATE NULL,
+    max_occurrences INTEGER NULL CHECK (max_occurrences > 0),
+    last_occurrence DATE NULL,
+    next_occurrence DATE NULL,
+    paused_at TIMESTAMP WITH TIME ZONE NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    deleted_at TIMESTAMP WITH TIME ZONE NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_recurring_rules_user_id ON recurring_rules (user_id);
+CREATE INDEX IF NOT EXISTS idx_recurring_rules_next_occurrence
+    ON recurring_rules (next_occurrence)
+    WHERE paused_at IS NULL AND deleted_at IS NULL;
+
+CREATE TABLE IF NOT EXISTS recurring_rule_skips (
+    rule_id UUID NOT NULL REFERENCES recurring_rules(id) ON DELETE CASCADE,
+    occurrence_date DATE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    PRIMARY KEY (rule_id, occurrence_date)
+);
+
+ALTER TABLE transactions
+    ADD COLUMN IF NOT EXISTS recurring_rule_id UUID NULL REFERENCES recurring_rules(id),
+    ADD COLUMN IF NOT EXISTS occurrence_date DATE NULL;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_transactions_recurring_rule_id_occurrence_date
+    ON transactions (recurring_rule_id, occurrence_date)
+    WHERE recurring_rule_id IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_transactions_recurring_rule_id_occurrence_date;
+ALTER TABLE transactions
+    DROP COLUMN IF EXISTS occurrence_date,
+    DROP COLUMN IF EXISTS recurring_rule_id;
+DROP TABLE IF EXISTS recurring_rule_skips;
+DROP TABLE IF EXISTS recurring_rules;
+-- +goose StatementEnd
@@ -0,0 +1,27 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS occurred_at TIMESTAMP WITH TIME ZONE NULL;
+
+UPDATE transactions SET occurred_at = created_at WHERE occurred_at IS NULL;
+
+ALTER TABLE transactions
+    ALTER COLUMN occurred_at SET DEFAULT NOW(),
+    ALTER COLUMN occurred_at SET NOT NULL;
+
+DROP INDEX IF EXISTS idx_transactions_account_id_created_at_id;
+
+CREATE INDEX IF NOT EXISTS idx_transactions_account_id_occurred_at_id
+    ON transactions (account_id, occurred_at, id)
+    WHERE deleted_at IS NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_transactions_account_id_occurred_at_id;
+
+CREATE INDEX IF NOT EXISTS idx_transactions_account_id_created_at_id
+    ON transactions (account_id, created_at, id)
+    WHERE deleted_at IS NULL;
+
+ALTER TABLE transactions DROP COLUMN IF EXISTS occurred_at;
+-- +goose StatementEnd
@@ -0,0 +1,40 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS reconciliations (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    account_id UUID NOT NULL REFERENCES accounts(id),
+    statement_date DATE NOT NULL,
+    statement_balance DECIMAL(32,18) NOT NULL,
+    completed_at TIMESTAMP WITH TIME ZONE NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_reconciliations_account_id ON reconciliations (account_id, statement_date);
+
+-- At most one reconciliation per account may be in progress.
+CREATE UNIQUE INDEX IF NOT EXISTS idx_reconciliations_account_id_open
+    ON reconciliations (account_id)
+    WHERE completed_at IS NULL;
+
+ALTER TABLE transactions
+    ADD COLUMN IF NOT EXISTS status VARCHAR(20) NOT NULL DEFAULT 'cleared'
+        CHECK (status IN ('pending', 'cleared', 'reconciled')),
+    ADD COLUMN IF NOT EXISTS reconciliation_id UUID NULL REFERENCES reconciliations(id);
+
+-- Existing history is treated as cleared; new rows start out pending.
+ALTER TABLE transactions ALTER COLUMN status SET DEFAULT 'pending';
+
+CREATE INDEX IF NOT EXISTS idx_transactions_account_id_status
+    ON transactions (account_id, status)
+    WHERE deleted_at IS NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_transactions_account_id_status;
+ALTER TABLE transactions
+    DROP COLUMN IF EXISTS reconciliation_id,
+    DROP COLUMN IF EXISTS status;
+DROP TABLE IF EXISTS reconciliations;
+-- +goose StatementEnd
@@ -0,0 +1,47 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS import_profiles (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id),
+    name VARCHAR(100) NOT NULL,
+    format VARCHAR(20) NOT NULL CHECK (format IN ('csv')),
+    settings JSONB NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    UNIQUE (user_id, name)
+);
+
+CREATE TABLE IF NOT EXISTS import_batches (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id),
+    account_id UUID NOT NULL REFERENCES accounts(id),
+    format VARCHAR(20) NOT NULL,
+    filename TEXT,
+    status VARCHAR(20) NOT NULL DEFAULT 'preview' CHECK (status IN ('preview', 'committed')),
+    committed_at TIMESTAMP WITH TIME ZONE NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_import_batches_user_id ON import_batches (user_id, created_at);
+
+CREATE TABLE IF NOT EXISTS import_rows (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    batch_id UUID NOT NULL REFERENCES import_batches(id) ON DELETE CASCADE,
+    line INTEGER NOT NULL,
+    occurred_at TIMESTAMP WITH TIME ZONE NULL,
+    amount DECIMAL(32,18) NULL,
+    description TEXT,
+    error TEXT NULL,
+    transaction_id UUID NULL REFERENCES transactions(id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_import_rows_batch_id ON import_rows (batch_id, line);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS import_rows;
+DROP TABLE IF EXISTS import_batches;
+DROP TABLE IF EXISTS import_profiles;
+-- +goose StatementEnd
@@ -0,0 +1,26 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS external_id VARCHAR(255) NULL;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_transactions_account_id_external_id
+    ON transactions (account_id, external_id)
+    WHERE external_id IS NOT NULL;
+
+ALTER TABLE import_rows ADD COLUMN IF NOT EXISTS external_id VARCHAR(255) NULL;
+
+ALTER TABLE import_batches
+    ADD COLUMN IF NOT EXISTS statement_balance DECIMAL(32,18) NULL,
+    ADD COLUMN IF NOT EXISTS statement_date DATE NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE import_batches
+    DROP COLUMN IF EXISTS statement_date,
+    DROP COLUMN IF EXISTS statement_balance;
+
+ALTER TABLE import_rows DROP COLUMN IF EXISTS external_id;
+
+DROP INDEX IF EXISTS idx_transactions_account_id_external_id;
+ALTER TABLE transactions DROP COLUMN IF EXISTS external_id;
+-- +goose StatementEnd
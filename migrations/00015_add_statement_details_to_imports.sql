@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE import_rows
+    ADD COLUMN IF NOT EXISTS booking_date DATE NULL,
+    ADD COLUMN IF NOT EXISTS counterparty TEXT NULL,
+    ADD COLUMN IF NOT EXISTS counterparty_account VARCHAR(64) NULL;
+
+ALTER TABLE import_batches
+    ADD COLUMN IF NOT EXISTS opening_balance DECIMAL(32,18) NULL,
+    ADD COLUMN IF NOT EXISTS opening_date DATE NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE import_batches
+    DROP COLUMN IF EXISTS opening_date,
+    DROP COLUMN IF EXISTS opening_balance;
+
+ALTER TABLE import_rows
+    DROP COLUMN IF EXISTS counterparty_account,
+    DROP COLUMN IF EXISTS counterparty,
+    DROP COLUMN IF EXISTS booking_date;
+-- +goose StatementEnd
@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS budgets (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id),
+    category_id UUID NOT NULL REFERENCES categories(id),
+    month DATE NOT NULL CHECK (EXTRACT(DAY FROM month) = 1),
+    amount DECIMAL(32,18) NOT NULL CHECK (amount >= 0),
+    rollover BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_budgets_category_id_month ON budgets (category_id, month);
+CREATE INDEX IF NOT EXISTS idx_budgets_user_id_month ON budgets (user_id, month);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS budgets;
+-- +goose StatementEnd
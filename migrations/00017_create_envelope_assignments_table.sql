@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS envelope_assignments (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id),
+    category_id UUID NOT NULL REFERENCES categories(id),
+    month DATE NOT NULL CHECK (EXTRACT(DAY FROM month) = 1),
+    amount DECIMAL(32,18) NOT NULL CHECK (amount <> 0),
+    -- Both halves of a move between envelopes share a move ID.
+    move_id UUID NULL,
+    note TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_envelope_assignments_user_id_month ON envelope_assignments (user_id, month);
+CREATE INDEX IF NOT EXISTS idx_envelope_assignments_category_id_month ON envelope_assignments (category_id, month);
+CREATE INDEX IF NOT EXISTS idx_envelope_assignments_move_id
+    ON envelope_assignments (move_id)
+    WHERE move_id IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS envelope_assignments;
+-- +goose StatementEnd
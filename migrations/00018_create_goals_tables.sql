@@ -0,0 +1,31 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS goals (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id),
+    name VARCHAR(100) NOT NULL,
+    target_amount DECIMAL(32,18) NOT NULL CHECK (target_amount > 0),
+    target_date DATE NOT NULL,
+    archived_at TIMESTAMP WITH TIME ZONE NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_goals_user_id ON goals (user_id);
+
+-- share is the fraction of the account's balance set aside for the goal.
+CREATE TABLE IF NOT EXISTS goal_accounts (
+    goal_id UUID NOT NULL REFERENCES goals(id) ON DELETE CASCADE,
+    account_id UUID NOT NULL REFERENCES accounts(id),
+    share DECIMAL(5,4) NOT NULL DEFAULT 1 CHECK (share > 0 AND share <= 1),
+    PRIMARY KEY (goal_id, account_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_goal_accounts_account_id ON goal_accounts (account_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS goal_accounts;
+DROP TABLE IF EXISTS goals;
+-- +goose StatementEnd